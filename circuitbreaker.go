@@ -0,0 +1,138 @@
+package gousuredis
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	gousu "github.com/indece-official/go-gousu"
+)
+
+// ErrCircuitOpen is returned without contacting redis while the circuit breaker is open
+var ErrCircuitOpen = errors.New("redis circuit breaker open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker fails commands fast after repeated connection errors
+//
+// The circuit opens after threshold consecutive connection errors and rejects all
+// commands for openDuration. Afterwards it is half-open and lets up to halfOpenProbes
+// commands through: the first success closes the circuit, a failure opens it again.
+// A nil circuitBreaker allows all commands.
+type circuitBreaker struct {
+	mutex          sync.Mutex
+	log            *gousu.Log
+	threshold      int
+	openDuration   time.Duration
+	halfOpenProbes int
+	state          circuitState
+	failures       int
+	since          time.Time
+	probes         int
+}
+
+func newCircuitBreaker(log *gousu.Log, threshold int, openDuration time.Duration, halfOpenProbes int) *circuitBreaker {
+	if halfOpenProbes < 1 {
+		halfOpenProbes = 1
+	}
+
+	return &circuitBreaker{
+		log:            log,
+		threshold:      threshold,
+		openDuration:   openDuration,
+		halfOpenProbes: halfOpenProbes,
+	}
+}
+
+// allow checks if a command may be sent, returns ErrCircuitOpen else
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+
+	switch b.state {
+	case circuitOpen:
+		if now.Sub(b.since) < b.openDuration {
+			return ErrCircuitOpen
+		}
+
+		b.state = circuitHalfOpen
+		b.since = now
+		b.probes = 0
+	case circuitHalfOpen:
+		// Release probes which never reported a result (e.g. connections closed unused)
+		if now.Sub(b.since) >= b.openDuration {
+			b.since = now
+			b.probes = 0
+		}
+	default:
+		return nil
+	}
+
+	if b.probes >= b.halfOpenProbes {
+		return ErrCircuitOpen
+	}
+
+	b.probes++
+
+	return nil
+}
+
+// record updates the state of the circuit with the result of a command
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	failed := IsConnError(err)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case circuitClosed:
+		if !failed {
+			b.failures = 0
+
+			return
+		}
+
+		b.failures++
+		if b.failures >= b.threshold {
+			b.open()
+		}
+	case circuitHalfOpen:
+		if failed {
+			b.open()
+
+			return
+		}
+
+		b.state = circuitClosed
+		b.failures = 0
+
+		if b.log != nil {
+			b.log.Infof("Redis circuit breaker closed")
+		}
+	}
+}
+
+func (b *circuitBreaker) open() {
+	b.state = circuitOpen
+	b.since = time.Now()
+
+	if b.log != nil {
+		b.log.Warnf("Redis circuit breaker opened for %s due to connection errors", b.openDuration)
+	}
+}
@@ -0,0 +1,45 @@
+package gousuredis
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := newCircuitBreaker(nil, 2, 50*time.Millisecond, 1)
+
+	assert.NoError(t, breaker.allow())
+	breaker.record(io.EOF)
+	breaker.record(nil)
+	breaker.record(io.EOF)
+	breaker.record(errors.New("WRONGTYPE"))
+	assert.NoError(t, breaker.allow())
+
+	breaker.record(io.EOF)
+	breaker.record(io.EOF)
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+
+	// half-open: a single probe is let through and fails
+	assert.NoError(t, breaker.allow())
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+	breaker.record(io.EOF)
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+
+	// half-open: the probe succeeds and closes the circuit
+	assert.NoError(t, breaker.allow())
+	breaker.record(nil)
+	assert.NoError(t, breaker.allow())
+	assert.NoError(t, breaker.allow())
+
+	var disabled *circuitBreaker
+	disabled.record(io.EOF)
+	assert.NoError(t, disabled.allow())
+}
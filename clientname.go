@@ -0,0 +1,42 @@
+package gousuredis
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// clientName returns the name set on all connections via CLIENT SETNAME
+//
+// Defaults to "<program>.<service name>@<hostname>", so connections can be assigned to
+// applications and instances (e.g. pods) in CLIENT LIST.
+func (s *Service) clientName() string {
+	if s.options.ClientName == "-" {
+		return ""
+	}
+
+	if s.options.ClientName != "" {
+		return sanitizeClientName(s.options.ClientName)
+	}
+
+	program := filepath.Base(os.Args[0])
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return sanitizeClientName(fmt.Sprintf("%s.%s@%s", program, s.name, hostname))
+}
+
+// sanitizeClientName replaces characters not allowed in client names (spaces, newlines)
+func sanitizeClientName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return '_'
+		}
+
+		return r
+	}, name)
+}
@@ -0,0 +1,24 @@
+package gousuredis
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientName(t *testing.T) {
+	options := DefaultOptions()
+
+	service := NewServiceWithOptions("cache", options)
+
+	hostname, _ := os.Hostname()
+	assert.True(t, strings.HasSuffix(service.clientName(), ".cache@"+sanitizeClientName(hostname)))
+
+	options.ClientName = "my app"
+	assert.Equal(t, "my_app", service.clientName())
+
+	options.ClientName = "-"
+	assert.Equal(t, "", service.clientName())
+}
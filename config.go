@@ -0,0 +1,405 @@
+package gousuredis
+
+import (
+	"strings"
+	"time"
+
+	"github.com/namsral/flag"
+)
+
+// Options contains the configuration of a Service
+//
+// Use DefaultOptions() as base and adjust the required fields, zero values are not
+// replaced by defaults.
+type Options struct {
+	URL      string
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// DB is the index of the logical database selected on all connections, overriding the
+	// database of URL if set
+	DB int
+
+	// KeyPrefix is prepended to all keys, so multiple applications can share one database
+	KeyPrefix string
+
+	// ClientName is set on all connections via CLIENT SETNAME, defaults to
+	// "<program>.<service name>@<hostname>" if empty, "-" disables it
+	ClientName string
+
+	// RESP3 negotiates protocol version 3 via HELLO on all connections (redis >= 6), so the
+	// server can send push messages (e.g. client-side caching invalidations), see OnPush
+	RESP3 bool
+
+	// DNSDiscovery resolves Host via DNS (A / AAAA or SRV records), caches the addresses
+	// and re-resolves them if none of them can be dialed
+	DNSDiscovery DNSDiscovery
+
+	MaxIdle         int
+	MaxActive       int
+	IdleTimeout     time.Duration
+	Wait            bool
+	MaxConnLifetime time.Duration
+	// ConnRecycleInterval is the interval in which all idle connections are pinged, replacing
+	// dead ones and ones exceeding MaxConnLifetime (0 = disabled), so connections dropped
+	// silently by NAT gateways or load balancers don't fail on their next use
+	ConnRecycleInterval time.Duration
+	// ConnectTimeout, ReadTimeout and WriteTimeout are applied to all connections (0 = none),
+	// blocking commands (e.g. BLPop, BLMove, XRead) override the read timeout with their
+	// own timeout plus a margin, so a hung connection never blocks them forever
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+
+	// StartBackground lets Start succeed if redis is unreachable and connects in the background,
+	// Health returns ErrNotReady until connected
+	StartBackground bool
+
+	ClusterMode  bool
+	ClusterNodes []string
+
+	SentinelAddrs    []string
+	SentinelMaster   string
+	SentinelPassword string
+
+	// ReplicaAddrs are the addresses (host:port) of read replicas of the master, in cluster
+	// mode the replicas are discovered automatically
+	ReplicaAddrs []string
+	// ReadPreference defines if read-only commands are sent to the replicas
+	ReadPreference ReadPreference
+
+	TLS           bool
+	TLSSkipVerify bool
+	TLSCAFile     string
+	TLSCertFile   string
+	TLSKeyFile    string
+	TLSServerName string
+
+	// AdaptiveTimeout derives read timeouts from the p99 latency of each command (times
+	// AdaptiveTimeoutFactor, between AdaptiveTimeoutMin and AdaptiveTimeoutMax). Not supported
+	// in cluster mode, where the connections don't support read timeouts
+	AdaptiveTimeout       bool
+	AdaptiveTimeoutMin    time.Duration
+	AdaptiveTimeoutMax    time.Duration
+	AdaptiveTimeoutFactor float64
+
+	ScriptKillTimeout    time.Duration
+	TxMaxRetries         int
+	QueueMonitorInterval time.Duration
+
+	// RetryMaxAttempts is the maximum number of attempts of idempotent commands failing due to
+	// connection errors (1 = no retries), waiting RetryBackoff doubled on every attempt up to
+	// RetryMaxBackoff and reduced by a random share of up to RetryJitter (0 - 1) in between
+	RetryMaxAttempts int
+	RetryBackoff     time.Duration
+	RetryMaxBackoff  time.Duration
+	RetryJitter      float64
+
+	// CircuitBreakerThreshold is the number of consecutive connection errors after which all
+	// commands fail with ErrCircuitOpen for CircuitBreakerOpenDuration (0 = disabled). Afterwards
+	// up to CircuitBreakerHalfOpenProbes commands are let through to probe the connection.
+	CircuitBreakerThreshold      int
+	CircuitBreakerOpenDuration   time.Duration
+	CircuitBreakerHalfOpenProbes int
+
+	// HealthMaxLatency, HealthMaxReplicationLag and HealthMaxMemoryUsage (0 - 1) are thresholds
+	// marking the service unhealthy if exceeded (0 = disabled), see HealthDetails
+	HealthMaxLatency        time.Duration
+	HealthMaxReplicationLag time.Duration
+	HealthMaxMemoryUsage    float64
+	// HealthFailOnRejectedConnections marks the service unhealthy if redis rejected connections
+	// since the previous health check
+	HealthFailOnRejectedConnections bool
+
+	// StopTimeout is the maximum time Stop waits for subscriptions and consumers to deliver
+	// all buffered messages before closing them
+	StopTimeout time.Duration
+
+	// StreamReclaimMinIdle lets consumer groups claim pending events idle for longer, e.g.
+	// as their consumer crashed, and deliver them again (0 = disabled, requires redis >= 6.2)
+	StreamReclaimMinIdle time.Duration
+
+	// AllowFlush enables FlushDB and FlushAll, which should only be used in test environments
+	AllowFlush bool
+
+	// SubscribeReconnect redials failed subscriptions and resubscribes all channels
+	SubscribeReconnect bool
+
+	// SlowThreshold logs all non-blocking commands taking longer (0 = disabled)
+	SlowThreshold time.Duration
+
+	// Tracer creates a span for every command, see Tracer
+	Tracer Tracer
+
+	// EncryptionKeys are base64 encoded AES keys (16, 24 or 32 bytes) enabling the
+	// encryption of the values of strings and hash fields. The last key encrypts new
+	// values, the previous ones are kept to decrypt values written before a key rotation.
+	// Counters and partial reads / writes (e.g. HIncrBy, GetRange) work on plain values.
+	EncryptionKeys []string
+	// EncryptionKeyFunc loads the keys by version (e.g. from a KMS) and overrides EncryptionKeys
+	EncryptionKeyFunc EncryptionKeyFunc
+	// EncryptionKeyVersion is the version of the key used to encrypt new values via EncryptionKeyFunc
+	EncryptionKeyVersion byte
+}
+
+// DefaultOptions returns the default options, which are also used as defaults for the flags
+func DefaultOptions() *Options {
+	return &Options{
+		Host: "127.0.0.1",
+		Port: 6379,
+
+		MaxIdle:        3,
+		MaxActive:      50,
+		IdleTimeout:    240 * time.Second,
+		ConnectTimeout: 5 * time.Second,
+
+		SentinelMaster: "mymaster",
+
+		ReadPreference: ReadPreferenceMaster,
+
+		AdaptiveTimeoutMin:    50 * time.Millisecond,
+		AdaptiveTimeoutMax:    5 * time.Second,
+		AdaptiveTimeoutFactor: 3,
+
+		TxMaxRetries:         3,
+		QueueMonitorInterval: 10 * time.Second,
+
+		RetryMaxAttempts: 1,
+		RetryBackoff:     50 * time.Millisecond,
+		RetryMaxBackoff:  1 * time.Second,
+		RetryJitter:      0.5,
+
+		CircuitBreakerOpenDuration:   10 * time.Second,
+		CircuitBreakerHalfOpenProbes: 1,
+
+		StopTimeout: 5 * time.Second,
+	}
+}
+
+// flagSet contains the flags of a Service, all prefixed with the same prefix
+type flagSet struct {
+	url                             *string
+	host                            *string
+	port                            *int
+	username                        *string
+	password                        *string
+	db                              *int
+	keyPrefix                       *string
+	clientName                      *string
+	resp3                           *bool
+	dnsDiscovery                    *string
+	maxIdle                         *int
+	maxActive                       *int
+	idleTimeout                     *int
+	wait                            *bool
+	maxConnLifetime                 *int
+	connRecycleInterval             *int
+	connectTimeout                  *int
+	readTimeout                     *int
+	writeTimeout                    *int
+	startBackground                 *bool
+	clusterMode                     *bool
+	clusterNodes                    *string
+	sentinelAddrs                   *string
+	sentinelMaster                  *string
+	sentinelPassword                *string
+	replicaAddrs                    *string
+	readPreference                  *string
+	tls                             *bool
+	tlsSkipVerify                   *bool
+	tlsCAFile                       *string
+	tlsCertFile                     *string
+	tlsKeyFile                      *string
+	tlsServerName                   *string
+	adaptiveTimeout                 *bool
+	adaptiveTimeoutMin              *int
+	adaptiveTimeoutMax              *int
+	adaptiveTimeoutFactor           *float64
+	scriptKillTimeout               *int
+	txMaxRetries                    *int
+	queueMonitorInterval            *int
+	retryMaxAttempts                *int
+	retryBackoff                    *int
+	retryMaxBackoff                 *int
+	retryJitter                     *float64
+	circuitBreakerThreshold         *int
+	circuitBreakerOpenDuration      *int
+	circuitBreakerHalfOpenProbes    *int
+	healthMaxLatency                *int
+	healthMaxReplicationLag         *int
+	healthMaxMemoryUsage            *float64
+	healthFailOnRejectedConnections *bool
+	stopTimeout                     *int
+	streamReclaimMinIdle            *int
+	allowFlush                      *bool
+	slowThreshold                   *int
+	subscribeReconnect              *bool
+	encryptionKeys                  *string
+}
+
+// defaultFlags are the flags of the default service created via NewService
+var defaultFlags = newFlagSet("")
+
+// newFlagSet registers all flags of a Service with a prefix (e.g. "cache_" for "cache_redis_host")
+func newFlagSet(prefix string) *flagSet {
+	defaults := DefaultOptions()
+
+	return &flagSet{
+		url:                             flag.String(prefix+"redis_url", defaults.URL, "Redis URL (redis:// or rediss://), overrides redis_host, redis_port and credentials"),
+		host:                            flag.String(prefix+"redis_host", defaults.Host, "Redis host"),
+		port:                            flag.Int(prefix+"redis_port", defaults.Port, "Redis port"),
+		username:                        flag.String(prefix+"redis_username", defaults.Username, "Redis username"),
+		password:                        flag.String(prefix+"redis_password", defaults.Password, "Redis password"),
+		db:                              flag.Int(prefix+"redis_db", defaults.DB, "Redis index of the logical database (not supported in cluster mode)"),
+		keyPrefix:                       flag.String(prefix+"redis_key_prefix", defaults.KeyPrefix, "Redis prefix prepended to all keys"),
+		clientName:                      flag.String(prefix+"redis_client_name", defaults.ClientName, "Redis client name set on all connections (defaults to <program>.<service name>@<hostname>, '-' disables it)"),
+		resp3:                           flag.Bool(prefix+"redis_resp3", defaults.RESP3, "Redis negotiate protocol version 3 (RESP3) on all connections"),
+		dnsDiscovery:                    flag.String(prefix+"redis_dns_discovery", string(defaults.DNSDiscovery), "Redis resolve redis_host via dns (a or srv) and re-resolve it on dial failures (empty = disabled)"),
+		maxIdle:                         flag.Int(prefix+"redis_max_idle", defaults.MaxIdle, "Redis maximum idle connections"),
+		maxActive:                       flag.Int(prefix+"redis_max_active", defaults.MaxActive, "Redis maximum active connections"),
+		idleTimeout:                     flag.Int(prefix+"redis_idle_timeout", int(defaults.IdleTimeout/time.Second), "Redis idle connection timeout"),
+		wait:                            flag.Bool(prefix+"redis_wait", defaults.Wait, "Redis wait for a free connection if the pool is at redis_max_active"),
+		maxConnLifetime:                 flag.Int(prefix+"redis_max_conn_lifetime", int(defaults.MaxConnLifetime/time.Second), "Redis maximum connection lifetime in seconds (0 = unlimited)"),
+		connRecycleInterval:             flag.Int(prefix+"redis_conn_recycle_interval", int(defaults.ConnRecycleInterval/time.Second), "Redis interval in seconds for pinging idle connections and replacing dead ones (0 = disabled)"),
+		connectTimeout:                  flag.Int(prefix+"redis_connect_timeout_ms", int(defaults.ConnectTimeout/time.Millisecond), "Redis connect timeout in milliseconds"),
+		readTimeout:                     flag.Int(prefix+"redis_read_timeout_ms", int(defaults.ReadTimeout/time.Millisecond), "Redis read timeout in milliseconds (0 = none)"),
+		writeTimeout:                    flag.Int(prefix+"redis_write_timeout_ms", int(defaults.WriteTimeout/time.Millisecond), "Redis write timeout in milliseconds (0 = none)"),
+		startBackground:                 flag.Bool(prefix+"redis_start_background", defaults.StartBackground, "Redis let the start succeed if redis is unreachable and connect in the background"),
+		clusterMode:                     flag.Bool(prefix+"redis_cluster", defaults.ClusterMode, "Redis cluster mode"),
+		clusterNodes:                    flag.String(prefix+"redis_cluster_nodes", strings.Join(defaults.ClusterNodes, ","), "Redis cluster seed nodes as comma-separated list of host:port (defaults to redis_host:redis_port)"),
+		sentinelAddrs:                   flag.String(prefix+"redis_sentinel_addrs", strings.Join(defaults.SentinelAddrs, ","), "Redis sentinel addresses as comma-separated list of host:port (enables sentinel mode)"),
+		sentinelMaster:                  flag.String(prefix+"redis_sentinel_master", defaults.SentinelMaster, "Redis sentinel master name"),
+		sentinelPassword:                flag.String(prefix+"redis_sentinel_password", defaults.SentinelPassword, "Redis sentinel password"),
+		replicaAddrs:                    flag.String(prefix+"redis_replica_addrs", strings.Join(defaults.ReplicaAddrs, ","), "Redis read replica addresses as comma-separated list of host:port"),
+		readPreference:                  flag.String(prefix+"redis_read_preference", string(defaults.ReadPreference), "Redis read preference for read-only commands (master, replica or replica_preferred)"),
+		tls:                             flag.Bool(prefix+"redis_tls", defaults.TLS, "Redis use TLS"),
+		tlsSkipVerify:                   flag.Bool(prefix+"redis_tls_skip_verify", defaults.TLSSkipVerify, "Redis skip TLS certificate verification"),
+		tlsCAFile:                       flag.String(prefix+"redis_tls_ca_file", defaults.TLSCAFile, "Redis TLS CA certificate file (PEM)"),
+		tlsCertFile:                     flag.String(prefix+"redis_tls_cert_file", defaults.TLSCertFile, "Redis TLS client certificate file (PEM)"),
+		tlsKeyFile:                      flag.String(prefix+"redis_tls_key_file", defaults.TLSKeyFile, "Redis TLS client key file (PEM)"),
+		tlsServerName:                   flag.String(prefix+"redis_tls_server_name", defaults.TLSServerName, "Redis TLS server name (defaults to redis_host)"),
+		adaptiveTimeout:                 flag.Bool(prefix+"redis_adaptive_timeout", defaults.AdaptiveTimeout, "Redis adaptive read timeouts based on command latencies (not supported in cluster mode)"),
+		adaptiveTimeoutMin:              flag.Int(prefix+"redis_adaptive_timeout_min_ms", int(defaults.AdaptiveTimeoutMin/time.Millisecond), "Redis adaptive read timeout lower bound in milliseconds"),
+		adaptiveTimeoutMax:              flag.Int(prefix+"redis_adaptive_timeout_max_ms", int(defaults.AdaptiveTimeoutMax/time.Millisecond), "Redis adaptive read timeout upper bound in milliseconds"),
+		adaptiveTimeoutFactor:           flag.Float64(prefix+"redis_adaptive_timeout_factor", defaults.AdaptiveTimeoutFactor, "Redis adaptive read timeout as multiple of the p99 command latency"),
+		scriptKillTimeout:               flag.Int(prefix+"redis_script_kill_timeout_ms", int(defaults.ScriptKillTimeout/time.Millisecond), "Redis timeout in milliseconds after which busy read-only scripts get killed (0 = disabled)"),
+		txMaxRetries:                    flag.Int(prefix+"redis_tx_max_retries", defaults.TxMaxRetries, "Redis maximum retries of transactions on conflicting changes of watched keys"),
+		queueMonitorInterval:            flag.Int(prefix+"redis_queue_monitor_interval_ms", int(defaults.QueueMonitorInterval/time.Millisecond), "Redis queue monitor sampling interval in milliseconds (0 disables the monitor)"),
+		retryMaxAttempts:                flag.Int(prefix+"redis_retry_max_attempts", defaults.RetryMaxAttempts, "Redis maximum attempts of idempotent commands failing due to connection errors (1 = no retries)"),
+		retryBackoff:                    flag.Int(prefix+"redis_retry_backoff_ms", int(defaults.RetryBackoff/time.Millisecond), "Redis initial backoff between retries in milliseconds, doubled on every attempt"),
+		retryMaxBackoff:                 flag.Int(prefix+"redis_retry_max_backoff_ms", int(defaults.RetryMaxBackoff/time.Millisecond), "Redis maximum backoff between retries in milliseconds"),
+		retryJitter:                     flag.Float64(prefix+"redis_retry_jitter", defaults.RetryJitter, "Redis maximum share (0 - 1) by which the backoff between retries is randomly reduced"),
+		circuitBreakerThreshold:         flag.Int(prefix+"redis_circuit_breaker_threshold", defaults.CircuitBreakerThreshold, "Redis consecutive connection errors after which the circuit breaker opens (0 = disabled)"),
+		circuitBreakerOpenDuration:      flag.Int(prefix+"redis_circuit_breaker_open_duration_ms", int(defaults.CircuitBreakerOpenDuration/time.Millisecond), "Redis duration in milliseconds the circuit breaker stays open before probing the connection"),
+		circuitBreakerHalfOpenProbes:    flag.Int(prefix+"redis_circuit_breaker_half_open_probes", defaults.CircuitBreakerHalfOpenProbes, "Redis maximum commands let through to probe the connection while the circuit breaker is half-open"),
+		healthMaxLatency:                flag.Int(prefix+"redis_health_max_latency_ms", int(defaults.HealthMaxLatency/time.Millisecond), "Redis maximum PING latency in milliseconds before the service is unhealthy (0 = disabled)"),
+		healthMaxReplicationLag:         flag.Int(prefix+"redis_health_max_replication_lag_ms", int(defaults.HealthMaxReplicationLag/time.Millisecond), "Redis maximum replication lag in milliseconds before the service is unhealthy (0 = disabled)"),
+		healthMaxMemoryUsage:            flag.Float64(prefix+"redis_health_max_memory_usage", defaults.HealthMaxMemoryUsage, "Redis maximum share (0 - 1) of maxmemory used before the service is unhealthy (0 = disabled)"),
+		healthFailOnRejectedConnections: flag.Bool(prefix+"redis_health_fail_on_rejected_connections", defaults.HealthFailOnRejectedConnections, "Redis service is unhealthy if connections were rejected since the previous health check"),
+		stopTimeout:                     flag.Int(prefix+"redis_stop_timeout_ms", int(defaults.StopTimeout/time.Millisecond), "Redis maximum time in milliseconds to wait for subscriptions and consumers to finish on stop"),
+		streamReclaimMinIdle:            flag.Int(prefix+"redis_stream_reclaim_min_idle_ms", int(defaults.StreamReclaimMinIdle/time.Millisecond), "Redis minimum idle time in milliseconds of pending stream events before consumer groups claim them from other consumers (0 = disabled)"),
+		allowFlush:                      flag.Bool(prefix+"redis_allow_flush", defaults.AllowFlush, "Redis allow deleting all keys via FlushDB / FlushAll (for test environments)"),
+		slowThreshold:                   flag.Int(prefix+"redis_slow_threshold_ms", int(defaults.SlowThreshold/time.Millisecond), "Redis log commands taking longer than this threshold in milliseconds (0 = disabled)"),
+		subscribeReconnect:              flag.Bool(prefix+"redis_subscribe_reconnect", defaults.SubscribeReconnect, "Redis reconnect and resubscribe failed subscriptions automatically"),
+		encryptionKeys:                  flag.String(prefix+"redis_encryption_keys", strings.Join(defaults.EncryptionKeys, ","), "Redis value encryption keys as comma-separated list of base64 encoded AES keys, the last one is used for new values"),
+	}
+}
+
+// splitList splits a comma-separated list and removes empty entries
+func splitList(list string) []string {
+	entries := []string{}
+
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// options returns the options from the parsed flags
+func (f *flagSet) options() *Options {
+	return &Options{
+		URL:      *f.url,
+		Host:     *f.host,
+		Port:     *f.port,
+		Username: *f.username,
+		Password: *f.password,
+		DB:       *f.db,
+
+		KeyPrefix: *f.keyPrefix,
+
+		ClientName: *f.clientName,
+
+		RESP3: *f.resp3,
+
+		DNSDiscovery: DNSDiscovery(*f.dnsDiscovery),
+
+		MaxIdle:             *f.maxIdle,
+		MaxActive:           *f.maxActive,
+		IdleTimeout:         time.Duration(*f.idleTimeout) * time.Second,
+		Wait:                *f.wait,
+		MaxConnLifetime:     time.Duration(*f.maxConnLifetime) * time.Second,
+		ConnRecycleInterval: time.Duration(*f.connRecycleInterval) * time.Second,
+		ConnectTimeout:      time.Duration(*f.connectTimeout) * time.Millisecond,
+		ReadTimeout:         time.Duration(*f.readTimeout) * time.Millisecond,
+		WriteTimeout:        time.Duration(*f.writeTimeout) * time.Millisecond,
+
+		StartBackground: *f.startBackground,
+
+		ClusterMode:  *f.clusterMode,
+		ClusterNodes: splitList(*f.clusterNodes),
+
+		SentinelAddrs:    splitList(*f.sentinelAddrs),
+		SentinelMaster:   *f.sentinelMaster,
+		SentinelPassword: *f.sentinelPassword,
+
+		ReplicaAddrs:   splitList(*f.replicaAddrs),
+		ReadPreference: ReadPreference(*f.readPreference),
+
+		TLS:           *f.tls,
+		TLSSkipVerify: *f.tlsSkipVerify,
+		TLSCAFile:     *f.tlsCAFile,
+		TLSCertFile:   *f.tlsCertFile,
+		TLSKeyFile:    *f.tlsKeyFile,
+		TLSServerName: *f.tlsServerName,
+
+		AdaptiveTimeout:       *f.adaptiveTimeout,
+		AdaptiveTimeoutMin:    time.Duration(*f.adaptiveTimeoutMin) * time.Millisecond,
+		AdaptiveTimeoutMax:    time.Duration(*f.adaptiveTimeoutMax) * time.Millisecond,
+		AdaptiveTimeoutFactor: *f.adaptiveTimeoutFactor,
+
+		ScriptKillTimeout:    time.Duration(*f.scriptKillTimeout) * time.Millisecond,
+		TxMaxRetries:         *f.txMaxRetries,
+		QueueMonitorInterval: time.Duration(*f.queueMonitorInterval) * time.Millisecond,
+
+		RetryMaxAttempts: *f.retryMaxAttempts,
+		RetryBackoff:     time.Duration(*f.retryBackoff) * time.Millisecond,
+		RetryMaxBackoff:  time.Duration(*f.retryMaxBackoff) * time.Millisecond,
+		RetryJitter:      *f.retryJitter,
+
+		CircuitBreakerThreshold:      *f.circuitBreakerThreshold,
+		CircuitBreakerOpenDuration:   time.Duration(*f.circuitBreakerOpenDuration) * time.Millisecond,
+		CircuitBreakerHalfOpenProbes: *f.circuitBreakerHalfOpenProbes,
+
+		HealthMaxLatency:                time.Duration(*f.healthMaxLatency) * time.Millisecond,
+		HealthMaxReplicationLag:         time.Duration(*f.healthMaxReplicationLag) * time.Millisecond,
+		HealthMaxMemoryUsage:            *f.healthMaxMemoryUsage,
+		HealthFailOnRejectedConnections: *f.healthFailOnRejectedConnections,
+
+		StopTimeout: time.Duration(*f.stopTimeout) * time.Millisecond,
+
+		StreamReclaimMinIdle: time.Duration(*f.streamReclaimMinIdle) * time.Millisecond,
+
+		AllowFlush: *f.allowFlush,
+
+		SlowThreshold: time.Duration(*f.slowThreshold) * time.Millisecond,
+
+		SubscribeReconnect: *f.subscribeReconnect,
+
+		EncryptionKeys: splitList(*f.encryptionKeys),
+	}
+}
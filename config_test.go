@@ -0,0 +1,63 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/namsral/flag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServiceFactory(t *testing.T) {
+	factory := NewServiceFactory("cache", "cache_")
+
+	assert.NotNil(t, flag.Lookup("cache_redis_host"))
+	assert.NoError(t, flag.Set("cache_redis_port", "6380"))
+	assert.NoError(t, flag.Set("cache_redis_cluster_nodes", "node1:6379, node2:6379,"))
+
+	service := factory(nil).(*Service)
+	assert.Equal(t, "cache", service.Name())
+
+	options := service.flags.options()
+	assert.Equal(t, 6380, options.Port)
+	assert.Equal(t, []string{"node1:6379", "node2:6379"}, options.ClusterNodes)
+	assert.Equal(t, 240*time.Second, options.IdleTimeout)
+
+	assert.Equal(t, 6379, defaultFlags.options().Port)
+}
+
+func TestNewServiceWithOptions(t *testing.T) {
+	options := DefaultOptions()
+	options.Host = "redis.local"
+
+	service := NewServiceWithOptions("custom", options)
+	assert.Equal(t, "custom", service.Name())
+	assert.Equal(t, "redis.local", service.options.Host)
+	assert.Equal(t, []string{"redis.local:6379"}, service.clusterNodes())
+}
+
+func TestStartInvalidDB(t *testing.T) {
+	options := DefaultOptions()
+	options.ClusterMode = true
+	options.DB = 2
+
+	service := NewServiceWithOptions("redis", options)
+	assert.Error(t, service.Start())
+}
+
+func TestDurationFlagUnits(t *testing.T) {
+	factory := NewServiceFactory("units", "units_")
+
+	assert.NoError(t, flag.Set("units_redis_adaptive_timeout_min_ms", "20"))
+	assert.NoError(t, flag.Set("units_redis_adaptive_timeout_max_ms", "2000"))
+	assert.NoError(t, flag.Set("units_redis_script_kill_timeout_ms", "500"))
+	assert.NoError(t, flag.Set("units_redis_queue_monitor_interval_ms", "1500"))
+
+	options := factory(nil).(*Service).flags.options()
+	assert.Equal(t, 20*time.Millisecond, options.AdaptiveTimeoutMin)
+	assert.Equal(t, 2*time.Second, options.AdaptiveTimeoutMax)
+	assert.Equal(t, 500*time.Millisecond, options.ScriptKillTimeout)
+	assert.Equal(t, 1500*time.Millisecond, options.QueueMonitorInterval)
+
+	assert.Equal(t, 10*time.Second, defaultFlags.options().QueueMonitorInterval)
+}
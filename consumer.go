@@ -0,0 +1,303 @@
+package gousuredis
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	consumerReadTimeout  = 1 * time.Second
+	consumerErrorBackoff = 1 * time.Second
+)
+
+// ConsumerMessage is a message received by a Consumer
+type ConsumerMessage struct {
+	// Data is the payload of a list message, nil for stream events
+	Data []byte
+	// Event is the event of a stream, nil for list messages
+	Event *XEvent
+	// Attempt is the number of the delivery attempt of a list message, starting at 1
+	Attempt int
+
+	envelope *consumerEnvelope
+}
+
+// ConsumerHandler processes a message, returning an error marks the message as failed
+type ConsumerHandler func(msg *ConsumerMessage) error
+
+// ConsumerStats contains the number of messages processed by a Consumer
+type ConsumerStats struct {
+	Received  uint64
+	Succeeded uint64
+	// Failed is the number of messages whose handler returned an error or panicked
+	Failed uint64
+}
+
+// IConsumer defines the interface of Consumer
+type IConsumer interface {
+	Stats() ConsumerStats
+	Close() error
+}
+
+// Consumer receives messages from a list or stream in a loop and processes them on a
+// bounded pool of workers
+//
+// Consumers are stopped by Service.Stop like subscriptions: no further messages are
+// received and the running handlers finish within redis_stop_timeout_ms.
+type Consumer struct {
+	// counters are accessed atomically and must stay 64-bit aligned
+	received  uint64
+	succeeded uint64
+	failed    uint64
+
+	service       *Service
+	handler       ConsumerHandler
+	receive       func() (*ConsumerMessage, error)
+	ack           func(msg *ConsumerMessage) error
+	retryPolicy   *ConsumerRetryPolicy
+	retryQueue    *DelayedQueue
+	deadLetterKey string
+	workers       chan struct{}
+	inFlight      sync.WaitGroup
+	stop          chan struct{}
+	stopOnce      sync.Once
+	stopped       chan struct{}
+}
+
+var _ (IConsumer) = (*Consumer)(nil)
+
+// Stats returns the number of messages processed since the start
+func (c *Consumer) Stats() ConsumerStats {
+	return ConsumerStats{
+		Received:  atomic.LoadUint64(&c.received),
+		Succeeded: atomic.LoadUint64(&c.succeeded),
+		Failed:    atomic.LoadUint64(&c.failed),
+	}
+}
+
+// Close stops receiving messages and waits for the running handlers to finish
+func (c *Consumer) Close() error {
+	select {
+	case <-c.stop:
+		return fmt.Errorf("already closed")
+	default:
+	}
+
+	c.drain()
+	<-c.stopped
+
+	return nil
+}
+
+// drain stops receiving messages after the current read
+func (c *Consumer) drain() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+// kill does nothing, as running handlers can't be aborted
+func (c *Consumer) kill() {}
+
+func (c *Consumer) start() {
+	done := c.service.startWorker(c)
+
+	go func() {
+		defer done()
+		defer close(c.stopped)
+		defer c.inFlight.Wait()
+
+		if c.retryQueue != nil {
+			defer c.retryQueue.Close()
+		}
+
+		c.run()
+	}()
+}
+
+func (c *Consumer) run() {
+	for {
+		// a worker is reserved before receiving, so no message is taken from the queue
+		// which can't be processed
+		select {
+		case c.workers <- struct{}{}:
+		case <-c.stop:
+			return
+		}
+
+		msg, err := c.receive()
+		if err != nil {
+			<-c.workers
+
+			if err != ErrNil {
+				c.service.log.Warnf("Can't receive message: %s", err)
+
+				select {
+				case <-time.After(consumerErrorBackoff):
+				case <-c.stop:
+					return
+				}
+			}
+
+			select {
+			case <-c.stop:
+				return
+			default:
+			}
+
+			continue
+		}
+
+		atomic.AddUint64(&c.received, 1)
+		c.inFlight.Add(1)
+
+		go func() {
+			defer func() {
+				<-c.workers
+				c.inFlight.Done()
+			}()
+
+			c.process(msg)
+		}()
+	}
+}
+
+// process runs the handler, recovering panics
+func (c *Consumer) process(msg *ConsumerMessage) {
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("handler panicked: %v", r)
+			}
+		}()
+
+		return c.handler(msg)
+	}()
+	if err != nil {
+		atomic.AddUint64(&c.failed, 1)
+
+		c.service.log.Warnf("Error processing message: %s", err)
+
+		if c.retryPolicy != nil {
+			err = c.retryFailed(msg, err)
+			if err != nil {
+				c.service.log.Warnf("Can't schedule retry of failed message: %s", err)
+			}
+		}
+
+		return
+	}
+
+	atomic.AddUint64(&c.succeeded, 1)
+
+	if c.ack != nil {
+		err = c.ack(msg)
+		if err != nil {
+			c.service.log.Warnf("Can't acknowledge message: %s", err)
+		}
+	}
+}
+
+func newConsumer(service *Service, concurrency int, handler ConsumerHandler) *Consumer {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &Consumer{
+		service: service.withoutContext(),
+		handler: handler,
+		workers: make(chan struct{}, concurrency),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// NewConsumer starts a consumer popping messages from the head of the list at queueKey
+// (e.g. filled via RPush) and processing them on up to concurrency workers
+//
+// Messages are removed from the list when received, so failed messages are lost. Use
+// NewConsumerWithRetryPolicy to retry them.
+func NewConsumer(service *Service, queueKey string, concurrency int, handler ConsumerHandler) *Consumer {
+	return NewConsumerWithRetryPolicy(service, queueKey, concurrency, nil, handler)
+}
+
+// NewConsumerWithRetryPolicy starts a consumer like NewConsumer, which retries failed messages
+// as configured by retryPolicy and moves them to a dead-letter list after the last attempt
+func NewConsumerWithRetryPolicy(service *Service, queueKey string, concurrency int, retryPolicy *ConsumerRetryPolicy, handler ConsumerHandler) *Consumer {
+	consumer := newConsumer(service, concurrency, handler)
+
+	consumer.receive = func() (*ConsumerMessage, error) {
+		data, err := consumer.service.BLPop(queueKey, int(consumerReadTimeout.Seconds()))
+		if err != nil {
+			return nil, err
+		}
+
+		envelope := unwrapEnvelope(data)
+
+		return &ConsumerMessage{
+			Data:     envelope.Data,
+			Attempt:  envelope.Attempt,
+			envelope: envelope,
+		}, nil
+	}
+
+	if retryPolicy != nil {
+		pollInterval := retryPolicy.Backoff
+		if pollInterval <= 0 || pollInterval > consumerReadTimeout {
+			pollInterval = consumerReadTimeout
+		}
+
+		consumer.retryPolicy = retryPolicy
+		consumer.retryQueue = NewDelayedQueue(service, fmt.Sprintf("%s:retry", queueKey), queueKey, pollInterval)
+		consumer.deadLetterKey = retryPolicy.DeadLetterKey
+
+		if consumer.deadLetterKey == "" {
+			consumer.deadLetterKey = fmt.Sprintf("%s:dead", queueKey)
+		}
+	}
+
+	consumer.start()
+
+	return consumer
+}
+
+// NewStreamConsumer creates the consumer group (and the stream) if necessary and starts a
+// consumer reading new events of the stream at key and processing them on up to concurrency
+// workers
+//
+// Events are acknowledged after they were processed successfully, failed events stay pending
+// and are delivered again after redis_stream_reclaim_min_idle_ms if set.
+func NewStreamConsumer(service *Service, key string, groupName string, consumerName string, concurrency int, handler ConsumerHandler) (*Consumer, error) {
+	err := service.XGroupCreate(groupName, key, XGroupCreateOffsetLast, true, true)
+	if err != nil {
+		return nil, fmt.Errorf("can't create consumer group: %s", err)
+	}
+
+	consumer := newConsumer(service, concurrency, handler)
+
+	reclaimer := newStreamReclaimer(consumer.service, groupName, consumerName, key)
+
+	consumer.receive = func() (*ConsumerMessage, error) {
+		evt, err := reclaimer.next()
+		if err == ErrNil {
+			evt, err = consumer.service.XReadGroup(groupName, consumerName, key, consumerReadTimeout, XReadGroupIDStreamNew)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &ConsumerMessage{Event: evt}, nil
+	}
+
+	consumer.ack = func(msg *ConsumerMessage) error {
+		_, err := consumer.service.XAck(groupName, key, msg.Event.ID)
+
+		return err
+	}
+
+	consumer.start()
+
+	return consumer, nil
+}
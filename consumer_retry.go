@@ -0,0 +1,111 @@
+package gousuredis
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ConsumerRetryPolicy configures retries of failed messages of a list Consumer
+//
+// Failed messages are wrapped in an envelope carrying the attempt count and scheduled in a
+// DelayedQueue at queueKey:retry, which moves them back to the queue after the backoff.
+// Messages failing MaxAttempts times are moved to the dead-letter list. In cluster mode
+// queueKey and DeadLetterKey must contain the same hash tag (e.g. "{jobs}").
+type ConsumerRetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one (e.g. 5)
+	MaxAttempts int
+	// Backoff is the delay before the first retry, which is doubled on each further retry
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// DeadLetterKey is the list failed messages are moved to, queueKey:dead if empty
+	DeadLetterKey string
+}
+
+// backoff returns the delay before the attempt (starting at 2 for the first retry)
+func (p *ConsumerRetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.Backoff << uint(attempt-2)
+	if p.MaxBackoff > 0 && (backoff > p.MaxBackoff || backoff <= 0) {
+		backoff = p.MaxBackoff
+	}
+
+	return backoff
+}
+
+// consumerEnvelope wraps a message with its delivery metadata
+type consumerEnvelope struct {
+	Envelope int `json:"gousu_envelope"`
+	// ID makes envelopes unique, as the DelayedQueue deduplicates identical messages
+	ID       string `json:"id"`
+	Attempt  int    `json:"attempt"`
+	Data     []byte `json:"data"`
+	Error    string `json:"error,omitempty"`
+	FailedAt string `json:"failed_at,omitempty"`
+}
+
+const consumerEnvelopeVersion = 1
+
+// unwrapEnvelope decodes an envelope, raw messages (e.g. pushed by producers) are returned
+// as first attempt
+func unwrapEnvelope(raw []byte) *consumerEnvelope {
+	envelope := &consumerEnvelope{}
+
+	err := json.Unmarshal(raw, envelope)
+	if err != nil || envelope.Envelope != consumerEnvelopeVersion {
+		return &consumerEnvelope{
+			Envelope: consumerEnvelopeVersion,
+			ID:       newEnvelopeID(),
+			Attempt:  1,
+			Data:     raw,
+		}
+	}
+
+	return envelope
+}
+
+func newEnvelopeID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+func (e *consumerEnvelope) marshal() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal envelope: %s", err)
+	}
+
+	return data, nil
+}
+
+// retryFailed schedules a failed message for the next attempt or moves it to the
+// dead-letter list
+func (c *Consumer) retryFailed(msg *ConsumerMessage, handlerErr error) error {
+	envelope := *msg.envelope
+	envelope.Attempt++
+	envelope.Error = handlerErr.Error()
+
+	if envelope.Attempt <= c.retryPolicy.MaxAttempts {
+		data, err := envelope.marshal()
+		if err != nil {
+			return err
+		}
+
+		return c.retryQueue.Enqueue(data, time.Now().Add(c.retryPolicy.backoff(envelope.Attempt)))
+	}
+
+	envelope.Attempt = msg.Attempt
+	envelope.FailedAt = time.Now().UTC().Format(time.RFC3339)
+
+	data, err := envelope.marshal()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.service.RPush(c.deadLetterKey, data)
+
+	return err
+}
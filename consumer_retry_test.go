@@ -0,0 +1,37 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerRetryPolicyBackoff(t *testing.T) {
+	policy := &ConsumerRetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  3 * time.Second,
+	}
+
+	assert.Equal(t, time.Second, policy.backoff(2))
+	assert.Equal(t, 2*time.Second, policy.backoff(3))
+	assert.Equal(t, 3*time.Second, policy.backoff(4))
+	assert.Equal(t, 3*time.Second, policy.backoff(100))
+}
+
+func TestUnwrapEnvelope(t *testing.T) {
+	envelope := unwrapEnvelope([]byte(`{"id":1}`))
+	assert.Equal(t, 1, envelope.Attempt)
+	assert.Equal(t, []byte(`{"id":1}`), envelope.Data)
+	assert.NotEmpty(t, envelope.ID)
+
+	envelope.Attempt = 3
+	envelope.Error = "failed"
+
+	data, err := envelope.marshal()
+	assert.NoError(t, err)
+
+	unwrapped := unwrapEnvelope(data)
+	assert.Equal(t, envelope, unwrapped)
+}
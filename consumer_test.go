@@ -0,0 +1,64 @@
+package gousuredis
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumer(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	mutex := sync.Mutex{}
+	queue := []string{"ok", "fail", "panic", "ok"}
+	acked := 0
+
+	consumer := newConsumer(service, 2, func(msg *ConsumerMessage) error {
+		switch string(msg.Data) {
+		case "fail":
+			return fmt.Errorf("failed")
+		case "panic":
+			panic("boom")
+		}
+
+		return nil
+	})
+
+	consumer.receive = func() (*ConsumerMessage, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if len(queue) == 0 {
+			return nil, ErrNil
+		}
+
+		msg := &ConsumerMessage{Data: []byte(queue[0])}
+		queue = queue[1:]
+
+		return msg, nil
+	}
+
+	consumer.ack = func(msg *ConsumerMessage) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		acked++
+
+		return nil
+	}
+
+	consumer.start()
+
+	assert.Eventually(t, func() bool {
+		return consumer.Stats().Received == 4
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, consumer.Close())
+	assert.Error(t, consumer.Close())
+
+	assert.Equal(t, ConsumerStats{Received: 4, Succeeded: 2, Failed: 2}, consumer.Stats())
+	assert.Equal(t, 2, acked)
+}
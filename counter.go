@@ -0,0 +1,164 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// fixedWindowCounterScript increments a counter and starts its window on the first increment
+//
+// KEYS[1] = key, ARGV[1] = window in ms, ARGV[2] = increment
+var fixedWindowCounterScript = redis.NewScript(1, `
+local count = redis.call('INCRBY', KEYS[1], ARGV[2])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// rollingWindowCounterScript increments the current bucket of a hash, removes buckets which
+// left the window and returns the sum of the remaining buckets, using the server time
+//
+// KEYS[1] = key, ARGV[1] = window in ms, ARGV[2] = bucket size in ms, ARGV[3] = increment
+var rollingWindowCounterScript = redis.NewScript(1, `
+local window = tonumber(ARGV[1])
+local bucketSize = tonumber(ARGV[2])
+local increment = tonumber(ARGV[3])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local current = math.floor(now / bucketSize)
+local oldest = math.floor((now - window) / bucketSize) + 1
+
+if increment ~= 0 then
+	redis.call('HINCRBY', KEYS[1], current, increment)
+	redis.call('PEXPIRE', KEYS[1], window + bucketSize)
+end
+
+local count = 0
+local buckets = redis.call('HGETALL', KEYS[1])
+for i = 1, #buckets, 2 do
+	if tonumber(buckets[i]) < oldest then
+		redis.call('HDEL', KEYS[1], buckets[i])
+	else
+		count = count + tonumber(buckets[i + 1])
+	end
+end
+
+return count
+`)
+
+// ICounter defines the interface of Counter
+type ICounter interface {
+	Incr(identifier string) (int, error)
+	IncrBy(identifier string, increment int) (int, error)
+	Count(identifier string) (int, error)
+}
+
+// Counter counts events per identifier within a fixed or rolling window
+//
+// Increments are atomic, so the expiration can't get lost between INCR and EXPIRE.
+type Counter struct {
+	service    *Service
+	key        string
+	window     time.Duration
+	bucketSize time.Duration
+}
+
+var _ (ICounter) = (*Counter)(nil)
+
+func (c *Counter) counterKey(identifier string) string {
+	return fmt.Sprintf("%s:%s", c.key, identifier)
+}
+
+// Incr increments the counter of the identifier (e.g. an user id or ip) by 1
+//
+// Returns the count in the current window including the increment
+func (c *Counter) Incr(identifier string) (int, error) {
+	return c.IncrBy(identifier, 1)
+}
+
+// IncrBy increments the counter of the identifier by increment
+//
+// Returns the count in the current window including the increment
+func (c *Counter) IncrBy(identifier string, increment int) (int, error) {
+	conn, err := c.service.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	if c.bucketSize == 0 {
+		return redis.Int(fixedWindowCounterScript.Do(
+			conn,
+			c.counterKey(identifier),
+			c.window.Milliseconds(),
+			increment,
+		))
+	}
+
+	return redis.Int(rollingWindowCounterScript.Do(
+		conn,
+		c.counterKey(identifier),
+		c.window.Milliseconds(),
+		c.bucketSize.Milliseconds(),
+		increment,
+	))
+}
+
+// Count returns the count of the identifier in the current window without incrementing it
+func (c *Counter) Count(identifier string) (int, error) {
+	if c.bucketSize != 0 {
+		// expired buckets are removed on read, so the script must run on the master
+		return c.IncrBy(identifier, 0)
+	}
+
+	conn, err := c.service.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	count, err := redis.Int(conn.Do("GET", c.counterKey(identifier)))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+
+	return count, err
+}
+
+// NewFixedWindowCounter creates a new counter which is reset window after the first
+// increment of an identifier
+//
+// The counts are stored at key:identifier.
+func NewFixedWindowCounter(service *Service, key string, window time.Duration) *Counter {
+	return &Counter{
+		service: service,
+		key:     key,
+		window:  window,
+	}
+}
+
+// NewRollingWindowCounter creates a new counter which counts the increments within the
+// last window
+//
+// The window is split into buckets (e.g. 10), the count can miss up to one bucket of
+// the oldest increments within the window. The buckets are stored in hashes at key:identifier.
+func NewRollingWindowCounter(service *Service, key string, window time.Duration, buckets int) *Counter {
+	if buckets < 1 {
+		buckets = 1
+	}
+
+	bucketSize := window / time.Duration(buckets)
+	if bucketSize < time.Millisecond {
+		bucketSize = time.Millisecond
+	}
+
+	return &Counter{
+		service:    service,
+		key:        key,
+		window:     window,
+		bucketSize: bucketSize,
+	}
+}
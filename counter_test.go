@@ -0,0 +1,22 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRollingWindowCounter(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	counter := NewRollingWindowCounter(service, "logins", time.Minute, 10)
+	assert.Equal(t, 6*time.Second, counter.bucketSize)
+	assert.Equal(t, "logins:127.0.0.1", counter.counterKey("127.0.0.1"))
+
+	counter = NewRollingWindowCounter(service, "logins", time.Millisecond, 0)
+	assert.Equal(t, time.Millisecond, counter.bucketSize)
+
+	counter = NewFixedWindowCounter(service, "logins", time.Minute)
+	assert.Equal(t, time.Duration(0), counter.bucketSize)
+}
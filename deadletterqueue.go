@@ -0,0 +1,153 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// deadLetterRequeueScript replaces a dead letter by a new envelope at the tail of the queue
+//
+// KEYS[1] = dead-letter list, KEYS[2] = queue, ARGV[1] = dead letter, ARGV[2] = new envelope
+var deadLetterRequeueScript = redis.NewScript(2, `
+local removed = redis.call('LREM', KEYS[1], 1, ARGV[1])
+if removed > 0 then
+	redis.call('RPUSH', KEYS[2], ARGV[2])
+end
+return removed
+`)
+
+// DeadLetter is a message which failed on all attempts of a ConsumerRetryPolicy
+type DeadLetter struct {
+	Data     []byte
+	Attempts int
+	// Error is the error returned by the handler on the last attempt
+	Error    string
+	FailedAt time.Time
+
+	raw      []byte
+	envelope *consumerEnvelope
+}
+
+// IDeadLetterQueue defines the interface of DeadLetterQueue
+type IDeadLetterQueue interface {
+	Len() (int, error)
+	List(start int, stop int) ([]*DeadLetter, error)
+	Requeue(letter *DeadLetter) (bool, error)
+	RequeueAll() (int, error)
+	Delete(letter *DeadLetter) (bool, error)
+}
+
+// DeadLetterQueue inspects the dead-letter list of a Consumer and moves messages back to
+// its queue for another round of attempts
+//
+// In cluster mode both keys must contain the same hash tag.
+type DeadLetterQueue struct {
+	service  *Service
+	key      string
+	queueKey string
+}
+
+var _ (IDeadLetterQueue) = (*DeadLetterQueue)(nil)
+
+// Len returns the number of dead letters
+func (q *DeadLetterQueue) Len() (int, error) {
+	return q.service.LLen(q.key)
+}
+
+// List loads the dead letters from index start to stop (inclusive, -1 is the last one),
+// oldest first
+func (q *DeadLetterQueue) List(start int, stop int) ([]*DeadLetter, error) {
+	items, err := q.service.LRange(q.key, start, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]*DeadLetter, len(items))
+	for i, raw := range items {
+		envelope := unwrapEnvelope(raw)
+
+		letters[i] = &DeadLetter{
+			Data:     envelope.Data,
+			Attempts: envelope.Attempt,
+			Error:    envelope.Error,
+			raw:      raw,
+			envelope: envelope,
+		}
+
+		letters[i].FailedAt, _ = time.Parse(time.RFC3339, envelope.FailedAt)
+	}
+
+	return letters, nil
+}
+
+// Requeue moves a dead letter returned by List to the tail of the queue, resetting its attempts
+//
+// Returns false if the dead letter was already requeued or deleted
+func (q *DeadLetterQueue) Requeue(letter *DeadLetter) (bool, error) {
+	envelope := *letter.envelope
+	envelope.Attempt = 1
+	envelope.Error = ""
+	envelope.FailedAt = ""
+
+	data, err := envelope.marshal()
+	if err != nil {
+		return false, err
+	}
+
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(deadLetterRequeueScript.Do(conn, q.key, q.queueKey, letter.raw, data))
+}
+
+// RequeueAll moves all dead letters to the queue and returns their number
+func (q *DeadLetterQueue) RequeueAll() (int, error) {
+	count := 0
+
+	for {
+		letters, err := q.List(0, 0)
+		if err != nil {
+			return count, err
+		}
+
+		if len(letters) == 0 {
+			return count, nil
+		}
+
+		requeued, err := q.Requeue(letters[0])
+		if err != nil {
+			return count, err
+		}
+
+		if requeued {
+			count++
+		}
+	}
+}
+
+// Delete removes a dead letter returned by List
+//
+// Returns false if the dead letter was already requeued or deleted
+func (q *DeadLetterQueue) Delete(letter *DeadLetter) (bool, error) {
+	removed, err := q.service.LRem(q.key, 1, letter.raw)
+	if err != nil {
+		return false, err
+	}
+
+	return removed > 0, nil
+}
+
+// NewDeadLetterQueue creates a new accessor for the dead-letter list at key of the consumer
+// of the queue at queueKey (key is queueKey:dead by default)
+func NewDeadLetterQueue(service *Service, key string, queueKey string) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		service:  service,
+		key:      key,
+		queueKey: queueKey,
+	}
+}
@@ -0,0 +1,143 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// delayedQueueMoveScript moves all due messages from the sorted set to the ready list using the server time
+//
+// KEYS[1] = sorted set, KEYS[2] = ready list, ARGV[1] = maximum number of messages to move
+var delayedQueueMoveScript = redis.NewScript(2, `
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local messages = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', now, 'LIMIT', 0, tonumber(ARGV[1]))
+for _, message in ipairs(messages) do
+	redis.call('ZREM', KEYS[1], message)
+	redis.call('RPUSH', KEYS[2], message)
+end
+return #messages
+`)
+
+const (
+	delayedQueueBatchSize = 100
+	// delayedQueueDefaultPollInterval is used if no poll interval is passed to NewDelayedQueue
+	delayedQueueDefaultPollInterval = 1 * time.Second
+)
+
+// IDelayedQueue defines the interface of DelayedQueue
+type IDelayedQueue interface {
+	Enqueue(data []byte, runAt time.Time) error
+	MoveDue() (int, error)
+	Close() error
+}
+
+// DelayedQueue schedules messages in a sorted set (scored by their execution time)
+// and moves them to a ready list once they are due
+//
+// Due messages are appended to the ready list in the order of their execution time, so it
+// can be consumed from the head via BLPop(...) / LPop(...) or by a Consumer of the ready list.
+// Messages are members of a sorted set, so identical payloads are deduplicated.
+// In cluster mode both keys must contain the same hash tag.
+type DelayedQueue struct {
+	service      *Service
+	key          string
+	readyKey     string
+	pollInterval time.Duration
+	stop         chan struct{}
+}
+
+var _ (IDelayedQueue) = (*DelayedQueue)(nil)
+
+// Enqueue schedules a message to be moved to the ready list at runAt
+func (q *DelayedQueue) Enqueue(data []byte, runAt time.Time) error {
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("ZADD", q.key, runAt.UnixNano()/int64(time.Millisecond), data)
+
+	return err
+}
+
+// MoveDue moves all due messages to the ready list and returns their number
+func (q *DelayedQueue) MoveDue() (int, error) {
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	total := 0
+
+	for {
+		count, err := redis.Int(delayedQueueMoveScript.Do(conn, q.key, q.readyKey, delayedQueueBatchSize))
+		if err != nil {
+			return total, err
+		}
+
+		total += count
+
+		if count < delayedQueueBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Close stops the poller
+func (q *DelayedQueue) Close() error {
+	select {
+	case <-q.stop:
+		return fmt.Errorf("already closed")
+	default:
+	}
+
+	close(q.stop)
+
+	return nil
+}
+
+func (q *DelayedQueue) runPoller() {
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-q.service.done:
+			return
+		case <-ticker.C:
+		}
+
+		_, err := q.MoveDue()
+		if err != nil {
+			q.service.log.Warnf("Moving due messages of delayed queue %s failed: %s", q.key, err)
+		}
+	}
+}
+
+// NewDelayedQueue creates a new delayed queue and starts polling for due messages every pollInterval
+//
+// A pollInterval <= 0 is replaced by 1 second.
+func NewDelayedQueue(service *Service, key string, readyKey string, pollInterval time.Duration) *DelayedQueue {
+	if pollInterval <= 0 {
+		pollInterval = delayedQueueDefaultPollInterval
+	}
+
+	queue := &DelayedQueue{
+		service:      service.withoutContext(),
+		key:          key,
+		readyKey:     readyKey,
+		pollInterval: pollInterval,
+		stop:         make(chan struct{}),
+	}
+
+	go queue.runPoller()
+
+	return queue
+}
@@ -0,0 +1,47 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelayedQueue(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	queue := gousuredis.NewDelayedQueue(service, "jobs:delayed", "jobs", time.Hour)
+	defer queue.Close()
+
+	now := time.Now()
+
+	require.NoError(t, queue.Enqueue([]byte("c"), now.Add(time.Hour)))
+	require.NoError(t, queue.Enqueue([]byte("b"), now.Add(-1*time.Second)))
+	require.NoError(t, queue.Enqueue([]byte("a"), now.Add(-2*time.Second)))
+
+	// identical messages are deduplicated
+	require.NoError(t, queue.Enqueue([]byte("a"), now.Add(-2*time.Second)))
+
+	count, err := queue.MoveDue()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// due messages are appended in the order of their execution time
+	data, err := service.LPop("jobs")
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	data, err = service.LPop("jobs")
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(data))
+
+	_, err = service.LPop("jobs")
+	assert.Equal(t, gousuredis.ErrNil, err)
+
+	scheduled, err := service.ZCard("jobs:delayed")
+	require.NoError(t, err)
+	assert.Equal(t, 1, scheduled)
+}
@@ -0,0 +1,16 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayedQueuePollInterval(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	queue := NewDelayedQueue(service, "jobs:delayed", "jobs", 0)
+	defer queue.Close()
+
+	assert.Equal(t, delayedQueueDefaultPollInterval, queue.pollInterval)
+}
@@ -0,0 +1,184 @@
+package gousuredis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	gousu "github.com/indece-official/go-gousu"
+)
+
+// DNSDiscovery defines how the addresses of redis are discovered via DNS
+type DNSDiscovery string
+
+const (
+	// DNSDiscoveryNone dials redis_host:redis_port (default)
+	DNSDiscoveryNone DNSDiscovery = ""
+	// DNSDiscoveryA resolves the A / AAAA records of redis_host
+	DNSDiscoveryA DNSDiscovery = "a"
+	// DNSDiscoverySRV resolves the SRV records of redis_host (e.g. "_redis._tcp.redis.service.consul"),
+	// ignoring redis_port
+	DNSDiscoverySRV DNSDiscovery = "srv"
+)
+
+const dnsLookupTimeout = 5 * time.Second
+
+// endpointResolver caches the addresses of redis resolved via DNS and re-resolves them
+// if no cached address can be dialed, e.g. after the master was moved to another IP
+type endpointResolver struct {
+	mutex  sync.Mutex
+	log    *gousu.Log
+	name   string
+	lookup func() ([]string, error)
+	addrs  []string
+}
+
+func newEndpointResolver(log *gousu.Log, mode DNSDiscovery, host string, port int) (*endpointResolver, error) {
+	resolver := &endpointResolver{
+		log:  log,
+		name: host,
+	}
+
+	switch mode {
+	case DNSDiscoveryA:
+		resolver.lookup = func() ([]string, error) {
+			return lookupHostAddrs(host, port)
+		}
+	case DNSDiscoverySRV:
+		resolver.lookup = func() ([]string, error) {
+			return lookupSRVAddrs(host)
+		}
+	default:
+		return nil, fmt.Errorf("invalid dns discovery mode '%s'", mode)
+	}
+
+	return resolver, nil
+}
+
+func lookupHostAddrs(host string, port int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+
+	ips, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.JoinHostPort(ip, strconv.Itoa(port))
+	}
+
+	return addrs, nil
+}
+
+// lookupSRVAddrs returns the targets of the SRV records ordered by priority
+func lookupSRVAddrs(name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, len(records))
+	for i, record := range records {
+		addrs[i] = net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port)))
+	}
+
+	return addrs, nil
+}
+
+// resolve looks up the addresses and replaces the cached ones
+func (r *endpointResolver) resolve() ([]string, error) {
+	addrs, err := r.lookup()
+	if err != nil {
+		return nil, fmt.Errorf("can't resolve %s: %s", r.name, err)
+	}
+
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("can't resolve %s: no addresses found", r.name)
+	}
+
+	r.mutex.Lock()
+	changed := strings.Join(addrs, ",") != strings.Join(r.addrs, ",")
+	r.addrs = addrs
+	r.mutex.Unlock()
+
+	if changed && r.log != nil {
+		r.log.Infof("Resolved redis %s to %s", r.name, strings.Join(addrs, ", "))
+	}
+
+	return addrs, nil
+}
+
+// dial dials the cached addresses and re-resolves them if none of them can be dialed
+func (r *endpointResolver) dial(dial func(addr string) (redis.Conn, error)) (redis.Conn, error) {
+	r.mutex.Lock()
+	addrs := r.addrs
+	r.mutex.Unlock()
+
+	var lastErr error
+
+	failed := map[string]bool{}
+
+	for _, addr := range addrs {
+		conn, err := dial(addr)
+		if err == nil {
+			return conn, nil
+		}
+
+		failed[addr] = true
+		lastErr = err
+	}
+
+	resolvedAddrs, err := r.resolve()
+	if err != nil {
+		if lastErr != nil {
+			return nil, fmt.Errorf("%s (last dial error: %w)", err, lastErr)
+		}
+
+		return nil, err
+	}
+
+	for _, addr := range resolvedAddrs {
+		if failed[addr] {
+			continue
+		}
+
+		conn, err := dial(addr)
+		if err == nil {
+			return conn, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// createDiscoveryPool creates a pool dialing the addresses discovered via DNS
+func (s *Service) createDiscoveryPool(opts ...redis.DialOption) (*redis.Pool, error) {
+	resolver, err := newEndpointResolver(s.log, s.options.DNSDiscovery, s.options.Host, s.options.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := s.newPool("", opts...)
+
+	pool.Dial = func() (redis.Conn, error) {
+		return resolver.dial(func(addr string) (redis.Conn, error) {
+			return redis.Dial("tcp", addr, opts...)
+		})
+	}
+
+	s.watchPool(s.options.Host, pool)
+
+	return pool, nil
+}
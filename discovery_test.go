@@ -0,0 +1,75 @@
+package gousuredis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointResolver(t *testing.T) {
+	lookups := 0
+	records := []string{"10.0.0.1:6379"}
+
+	resolver := &endpointResolver{
+		name: "redis.local",
+		lookup: func() ([]string, error) {
+			lookups++
+
+			return records, nil
+		},
+	}
+
+	reachable := map[string]bool{"10.0.0.1:6379": true}
+	dialed := []string{}
+
+	dial := func(addr string) (redis.Conn, error) {
+		dialed = append(dialed, addr)
+
+		if !reachable[addr] {
+			return nil, errors.New("connection refused")
+		}
+
+		return nil, nil
+	}
+
+	_, err := resolver.dial(dial)
+	assert.NoError(t, err)
+	_, err = resolver.dial(dial)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lookups)
+
+	// master moved to another ip
+	records = []string{"10.0.0.2:6379"}
+	reachable = map[string]bool{"10.0.0.2:6379": true}
+	dialed = []string{}
+
+	_, err = resolver.dial(dial)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lookups)
+	assert.Equal(t, []string{"10.0.0.1:6379", "10.0.0.2:6379"}, dialed)
+
+	// unchanged addresses aren't dialed twice
+	reachable = map[string]bool{}
+	dialed = []string{}
+
+	_, err = resolver.dial(dial)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"10.0.0.2:6379"}, dialed)
+}
+
+func TestNewEndpointResolver(t *testing.T) {
+	_, err := newEndpointResolver(nil, DNSDiscoverySRV, "_redis._tcp.redis.local", 0)
+	assert.NoError(t, err)
+
+	_, err = newEndpointResolver(nil, "aaaa", "redis.local", 6379)
+	assert.Error(t, err)
+
+	resolver, err := newEndpointResolver(nil, DNSDiscoveryA, "127.0.0.1", 6380)
+	assert.NoError(t, err)
+
+	addrs, err := resolver.resolve()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:6380"}, addrs)
+}
@@ -0,0 +1,226 @@
+package gousuredis
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// EncryptionKeyFunc returns the AES key (16, 24 or 32 bytes) of a key version, e.g. loaded from a KMS
+type EncryptionKeyFunc func(version byte) ([]byte, error)
+
+// valueCipher encrypts values with AES-GCM
+//
+// Encrypted values are stored as version byte, nonce and sealed data, so values
+// written with an older key version can still be decrypted after a key rotation.
+type valueCipher struct {
+	keyFunc EncryptionKeyFunc
+	version byte
+
+	mutex sync.Mutex
+	aeads map[byte]cipher.AEAD
+}
+
+// newValueCipher creates the cipher configured in the options, returns nil if encryption is disabled
+func newValueCipher(options *Options) (*valueCipher, error) {
+	keyFunc := options.EncryptionKeyFunc
+	version := options.EncryptionKeyVersion
+
+	if keyFunc == nil {
+		if len(options.EncryptionKeys) == 0 {
+			return nil, nil
+		}
+
+		if len(options.EncryptionKeys) > 255 {
+			return nil, fmt.Errorf("too many encryption keys (max. 255)")
+		}
+
+		keys := make([][]byte, len(options.EncryptionKeys))
+		for i, encodedKey := range options.EncryptionKeys {
+			key, err := base64.StdEncoding.DecodeString(encodedKey)
+			if err != nil {
+				return nil, fmt.Errorf("can't decode encryption key %d: %s", i+1, err)
+			}
+
+			keys[i] = key
+		}
+
+		keyFunc = func(version byte) ([]byte, error) {
+			if version == 0 || int(version) > len(keys) {
+				return nil, fmt.Errorf("unknown key version %d", version)
+			}
+
+			return keys[version-1], nil
+		}
+		version = byte(len(keys))
+	}
+
+	c := &valueCipher{
+		keyFunc: keyFunc,
+		version: version,
+		aeads:   map[byte]cipher.AEAD{},
+	}
+
+	// Fail early if the current key is invalid
+	_, err := c.aead(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *valueCipher) aead(version byte) (cipher.AEAD, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	aead, ok := c.aeads[version]
+	if ok {
+		return aead, nil
+	}
+
+	key, err := c.keyFunc(version)
+	if err != nil {
+		return nil, fmt.Errorf("can't load encryption key: %s", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key %d: %s", version, err)
+	}
+
+	aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	c.aeads[version] = aead
+
+	return aead, nil
+}
+
+// valueAAD returns the additional data binding an encrypted value to its key, so values
+// can't be moved to another key unnoticed
+func valueAAD(key string) []byte {
+	return []byte("s:" + key)
+}
+
+// fieldAAD returns the additional data binding an encrypted value to its hash field
+func fieldAAD(key string, field string) []byte {
+	return []byte("h:" + strconv.Itoa(len(key)) + ":" + key + field)
+}
+
+func (c *valueCipher) encrypt(data []byte, aad []byte) ([]byte, error) {
+	aead, err := c.aead(c.version)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("can't generate nonce: %s", err)
+	}
+
+	encrypted := make([]byte, 0, 1+len(nonce)+len(data)+aead.Overhead())
+	encrypted = append(encrypted, c.version)
+	encrypted = append(encrypted, nonce...)
+
+	return aead.Seal(encrypted, nonce, data, aad), nil
+}
+
+func (c *valueCipher) decrypt(encrypted []byte, aad []byte) ([]byte, error) {
+	if len(encrypted) < 1 {
+		return nil, fmt.Errorf("value too short")
+	}
+
+	aead, err := c.aead(encrypted[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encrypted) < 1+aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("value too short")
+	}
+
+	nonce := encrypted[1 : 1+aead.NonceSize()]
+
+	return aead.Open(nil, nonce, encrypted[1+aead.NonceSize():], aad)
+}
+
+// encryptValue encrypts the value of a key if encryption is enabled
+//
+// Keys are passed without key prefix, so values stay readable if the prefix changes.
+func (s *Service) encryptValue(key string, data []byte) ([]byte, error) {
+	return s.encrypt(data, valueAAD(key))
+}
+
+// encryptField encrypts the value of a hash field if encryption is enabled
+func (s *Service) encryptField(key string, field string, data []byte) ([]byte, error) {
+	return s.encrypt(data, fieldAAD(key, field))
+}
+
+func (s *Service) encrypt(data []byte, aad []byte) ([]byte, error) {
+	if s.cipher == nil {
+		return data, nil
+	}
+
+	encrypted, err := s.cipher.encrypt(data, aad)
+	if err != nil {
+		return nil, fmt.Errorf("can't encrypt value: %s", err)
+	}
+
+	return encrypted, nil
+}
+
+// decryptValue decrypts the value of a key loaded via redis.Bytes if encryption is enabled
+func (s *Service) decryptValue(key string, data []byte, err error) ([]byte, error) {
+	if err != nil {
+		return data, err
+	}
+
+	return s.decrypt(data, valueAAD(key))
+}
+
+// decryptField decrypts the value of a hash field loaded via redis.Bytes if encryption is enabled
+func (s *Service) decryptField(key string, field string, data []byte, err error) ([]byte, error) {
+	if err != nil {
+		return data, err
+	}
+
+	return s.decrypt(data, fieldAAD(key, field))
+}
+
+func (s *Service) decrypt(data []byte, aad []byte) ([]byte, error) {
+	if s.cipher == nil {
+		return data, nil
+	}
+
+	decrypted, err := s.cipher.decrypt(data, aad)
+	if err != nil {
+		return nil, fmt.Errorf("can't decrypt value: %s", err)
+	}
+
+	return decrypted, nil
+}
+
+// decryptHash decrypts all values of a hash loaded via hashReply if encryption is enabled
+func (s *Service) decryptHash(key string, values map[string][]byte, err error) (map[string][]byte, error) {
+	if err != nil || s.cipher == nil {
+		return values, err
+	}
+
+	for field, data := range values {
+		values[field], err = s.decryptField(key, field, data, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,112 @@
+package gousuredis
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueCipher(t *testing.T) {
+	key1 := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	key2 := base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))
+
+	c, err := newValueCipher(&Options{})
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	_, err = newValueCipher(&Options{EncryptionKeys: []string{"c2hvcnQ="}})
+	assert.Error(t, err)
+
+	c1, err := newValueCipher(&Options{EncryptionKeys: []string{key1}})
+	assert.NoError(t, err)
+
+	encrypted, err := c1.encrypt([]byte("secret"), valueAAD("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(1), encrypted[0])
+	assert.NotContains(t, string(encrypted), "secret")
+
+	decrypted, err := c1.decrypt(encrypted, valueAAD("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret"), decrypted)
+
+	// Values written before a key rotation can still be decrypted
+	c2, err := newValueCipher(&Options{EncryptionKeys: []string{key1, key2}})
+	assert.NoError(t, err)
+
+	decrypted, err = c2.decrypt(encrypted, valueAAD("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret"), decrypted)
+
+	encrypted2, err := c2.encrypt([]byte("secret"), valueAAD("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(2), encrypted2[0])
+
+	_, err = c1.decrypt(encrypted2, valueAAD("key"))
+	assert.Error(t, err)
+
+	// Values moved to another key or hash field are rejected
+	_, err = c2.decrypt(encrypted, valueAAD("other"))
+	assert.Error(t, err)
+
+	_, err = c2.decrypt(encrypted, fieldAAD("key", ""))
+	assert.Error(t, err)
+
+	encryptedField, err := c2.encrypt([]byte("secret"), fieldAAD("key", "a"))
+	assert.NoError(t, err)
+
+	_, err = c2.decrypt(encryptedField, fieldAAD("key", "b"))
+	assert.Error(t, err)
+
+	_, err = c2.decrypt(encryptedField, fieldAAD("keya", ""))
+	assert.Error(t, err)
+
+	// Modified values are rejected
+	encrypted[len(encrypted)-1] ^= 0xff
+
+	_, err = c2.decrypt(encrypted, valueAAD("key"))
+	assert.Error(t, err)
+
+	_, err = c2.decrypt([]byte{1, 2, 3}, valueAAD("key"))
+	assert.Error(t, err)
+}
+
+func TestPipelineEncryption(t *testing.T) {
+	options := DefaultOptions()
+	options.EncryptionKeys = []string{base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))}
+
+	service := NewServiceWithOptions("redis", options)
+
+	var err error
+	service.cipher, err = newValueCipher(options)
+	assert.NoError(t, err)
+
+	pipeline := service.Pipeline().(*Pipeline)
+	pipeline.Set("key1", []byte("secret1"))
+	pipeline.SetPX("key2", []byte("secret2"), 1000)
+	pipeline.HSet("key3", "field", []byte("secret3"))
+
+	assert.Equal(t, 3, pipeline.Len())
+
+	expected := []struct {
+		arg int
+		aad []byte
+	}{
+		{1, valueAAD("key1")},
+		{1, valueAAD("key2")},
+		{2, fieldAAD("key3", "field")},
+	}
+
+	for i, e := range expected {
+		command := pipeline.commands[i]
+		assert.NoError(t, command.err)
+
+		encrypted := command.args[e.arg].([]byte)
+		assert.NotContains(t, string(encrypted), "secret")
+
+		decrypted, err := service.cipher.decrypt(encrypted, e.aad)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret"+strconv.Itoa(i+1), string(decrypted))
+	}
+}
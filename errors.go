@@ -0,0 +1,133 @@
+package gousuredis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// BusyError is returned if redis is busy running a script and can't serve the command
+type BusyError struct {
+	Message string
+}
+
+func (e *BusyError) Error() string {
+	return e.Message
+}
+
+// IsBusy checks if the error was caused by redis being busy running a script
+func IsBusy(err error) bool {
+	var busyErr *BusyError
+
+	return errors.As(err, &busyErr)
+}
+
+// AuthError is returned if the authentication against redis failed or is missing
+type AuthError struct {
+	Message string
+}
+
+func (e *AuthError) Error() string {
+	return e.Message
+}
+
+// IsAuthError checks if the error was caused by missing or invalid credentials
+func IsAuthError(err error) bool {
+	var authErr *AuthError
+
+	return errors.As(err, &authErr)
+}
+
+// authErrorPrefixes are the prefixes of redis errors caused by missing or invalid credentials
+var authErrorPrefixes = []string{
+	"NOAUTH ",
+	"WRONGPASS ",
+	"NOPERM ",
+	"ERR invalid password",
+	"ERR AUTH ",
+	"ERR Client sent AUTH, but no password is set",
+}
+
+// mapError converts known redis server errors to typed errors
+func mapError(err error) error {
+	redisErr, ok := err.(redis.Error)
+	if !ok {
+		return err
+	}
+
+	if strings.HasPrefix(string(redisErr), "BUSY ") {
+		return &BusyError{Message: string(redisErr)}
+	}
+
+	for _, prefix := range authErrorPrefixes {
+		if strings.HasPrefix(string(redisErr), prefix) {
+			return &AuthError{Message: string(redisErr)}
+		}
+	}
+
+	return err
+}
+
+// connErrorMessages are the messages of redigo errors caused by closed connections or pools
+var connErrorMessages = []string{
+	"redigo: closed",
+	"redigo: connection closed",
+	"redigo: get on closed pool",
+}
+
+// IsNotFound checks if the error was caused by a missing key, field or value (ErrNil)
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNil)
+}
+
+// IsTimeout checks if the error was caused by a timeout, either of the connection or of the context
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsConnError checks if the error was caused by a failed, closed or unavailable connection
+// (including timeouts), so the command can be retried on another connection
+func IsConnError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if IsTimeout(err) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, redis.ErrPoolExhausted) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	for _, message := range connErrorMessages {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsServerError checks if the error was returned by the redis server (e.g. WRONGTYPE, BUSY or NOAUTH)
+func IsServerError(err error) bool {
+	var redisErr redis.Error
+
+	return errors.As(err, &redisErr) || IsBusy(err) || IsAuthError(err)
+}
@@ -0,0 +1,65 @@
+package gousuredis
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapErrorBusy(t *testing.T) {
+	err := mapError(redis.Error("BUSY Redis is busy running a script. You can only call SCRIPT KILL or SHUTDOWN NOSCRIPT."))
+
+	assert.True(t, IsBusy(err))
+	assert.True(t, IsBusy(fmt.Errorf("wrapped: %w", err)))
+}
+
+func TestMapErrorOther(t *testing.T) {
+	err := mapError(redis.Error("ERR unknown command"))
+
+	assert.False(t, IsBusy(err))
+	assert.Equal(t, redis.Error("ERR unknown command"), err)
+	assert.Nil(t, mapError(nil))
+}
+
+func TestMapErrorAuth(t *testing.T) {
+	assert.True(t, IsAuthError(mapError(redis.Error("NOAUTH Authentication required."))))
+	assert.True(t, IsAuthError(mapError(redis.Error("WRONGPASS invalid username-password pair or user is disabled."))))
+	assert.False(t, IsAuthError(mapError(redis.Error("ERR unknown command"))))
+}
+
+func TestErrorClassification(t *testing.T) {
+	notFound := fmt.Errorf("wrapped: %w", ErrNil)
+	timeout := &net.OpError{Op: "read", Net: "tcp", Err: &timeoutError{}}
+	connErr := fmt.Errorf("can't connect to redis: %w", &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")})
+	serverErr := redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+	assert.True(t, IsNotFound(notFound))
+	assert.False(t, IsNotFound(serverErr))
+
+	assert.True(t, IsTimeout(timeout))
+	assert.True(t, IsTimeout(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.False(t, IsTimeout(connErr))
+
+	assert.True(t, IsConnError(connErr))
+	assert.True(t, IsConnError(timeout))
+	assert.True(t, IsConnError(io.EOF))
+	assert.True(t, IsConnError(fmt.Errorf("redigo: connection closed")))
+	assert.False(t, IsConnError(serverErr))
+	assert.False(t, IsConnError(notFound))
+	assert.False(t, IsConnError(nil))
+
+	assert.True(t, IsServerError(serverErr))
+	assert.True(t, IsServerError(mapError(redis.Error("NOAUTH Authentication required."))))
+	assert.False(t, IsServerError(connErr))
+}
+
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "i/o timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
@@ -0,0 +1,316 @@
+package gousuredis
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/indece-official/go-gousu"
+)
+
+const (
+	eventBusResubscribeBackoff    = 1 * time.Second
+	eventBusResubscribeMaxBackoff = 30 * time.Second
+)
+
+// eventHandler handles the raw data of a message, decoding it for a typed handler
+type eventHandler func(data []byte) error
+
+// IEventBus defines the interface of EventBus
+type IEventBus interface {
+	OnError(fn func(channel string, err error))
+	Close() error
+}
+
+// EventBus dispatches messages of channels to registered handlers via Handle
+//
+// Handlers run concurrently on up to maxWorkers goroutines, so messages can be handled
+// out of order unless maxWorkers is 1. If all workers are busy, receiving further messages
+// is paused. Decoding errors, handler errors and panics of handlers are passed to the error
+// handler set via OnError, by default they are logged.
+//
+// Without redis_subscribe_reconnect a failed subscription is passed to the error handler too,
+// after which all channels are subscribed again, doubling the backoff between failed attempts
+// up to 30 seconds.
+type EventBus struct {
+	service  IService
+	log      *gousu.Log
+	workers  chan struct{}
+	inFlight sync.WaitGroup
+
+	mutex        sync.Mutex
+	handlers     map[string][]eventHandler
+	subscription ISubscription
+	received     chan struct{}
+	running      bool
+	onError      func(channel string, err error)
+	closed       bool
+	// closing is closed by Close to abort resubscribing
+	closing chan struct{}
+}
+
+var _ (IEventBus) = (*EventBus)(nil)
+
+// OnError replaces the default error handler, which logs errors
+func (b *EventBus) OnError(fn func(channel string, err error)) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.onError = fn
+}
+
+func (b *EventBus) handleError(channel string, err error) {
+	b.mutex.Lock()
+	onError := b.onError
+	b.mutex.Unlock()
+
+	if onError != nil {
+		onError(channel, err)
+
+		return
+	}
+
+	b.log.Warnf("Error handling event on channel %s: %s", channel, err)
+}
+
+// register adds a handler and subscribes to the channel if it's the first handler of it
+func (b *EventBus) register(channel string, handler eventHandler) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return fmt.Errorf("event bus closed")
+	}
+
+	_, subscribed := b.handlers[channel]
+	b.handlers[channel] = append(b.handlers[channel], handler)
+
+	if subscribed {
+		return nil
+	}
+
+	if b.subscription != nil {
+		return b.subscription.Subscribe(channel)
+	}
+
+	if b.running {
+		// The failed subscription is resubscribed with all channels by run
+		return nil
+	}
+
+	messages, subscription, err := b.service.Subscribe(b.channels())
+	if err != nil {
+		delete(b.handlers, channel)
+
+		return fmt.Errorf("can't subscribe to %s: %w", channel, err)
+	}
+
+	b.subscription = subscription
+	b.received = make(chan struct{})
+	b.running = true
+
+	go b.run(messages, b.received)
+
+	return nil
+}
+
+// channels returns all channels with registered handlers, must be called with the mutex locked
+func (b *EventBus) channels() []string {
+	channels := make([]string, 0, len(b.handlers))
+	for channel := range b.handlers {
+		channels = append(channels, channel)
+	}
+
+	return channels
+}
+
+// run dispatches the messages of the subscription, resubscribing if it failed
+func (b *EventBus) run(messages chan Message, received chan struct{}) {
+	defer close(received)
+
+	for {
+		failed := b.receive(messages)
+
+		b.mutex.Lock()
+		b.subscription = nil
+		// Without error the subscription was closed, e.g. because the service got stopped,
+		// so the next registered handler subscribes again
+		b.running = failed && !b.closed
+		running := b.running
+		b.mutex.Unlock()
+
+		if !running {
+			return
+		}
+
+		messages = b.resubscribe()
+		if messages == nil {
+			return
+		}
+	}
+}
+
+// resubscribe subscribes to all channels until it succeeds, returns nil if the bus
+// got closed in the meantime
+func (b *EventBus) resubscribe() chan Message {
+	backoff := eventBusResubscribeBackoff
+
+	for {
+		select {
+		case <-b.closing:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		b.mutex.Lock()
+
+		if b.closed {
+			b.running = false
+			b.mutex.Unlock()
+
+			return nil
+		}
+
+		messages, subscription, err := b.service.Subscribe(b.channels())
+		if err == nil {
+			b.subscription = subscription
+			b.mutex.Unlock()
+
+			return messages
+		}
+
+		b.mutex.Unlock()
+
+		b.handleError("", fmt.Errorf("can't resubscribe: %w", err))
+
+		backoff *= 2
+		if backoff > eventBusResubscribeMaxBackoff {
+			backoff = eventBusResubscribeMaxBackoff
+		}
+	}
+}
+
+// receive dispatches messages until the subscription ends, returns true if it failed
+func (b *EventBus) receive(messages chan Message) bool {
+	failed := false
+
+	for message := range messages {
+		if message.IsReconnect() {
+			continue
+		}
+
+		if message.IsError() {
+			b.handleError(message.Channel, message.Error)
+			failed = true
+
+			continue
+		}
+
+		b.mutex.Lock()
+		handlers := b.handlers[message.Channel]
+		b.mutex.Unlock()
+
+		for _, handler := range handlers {
+			b.dispatch(message.Channel, message.Data, handler)
+		}
+	}
+
+	return failed
+}
+
+// dispatch runs a handler as soon as a worker is available
+func (b *EventBus) dispatch(channel string, data []byte, handler eventHandler) {
+	b.workers <- struct{}{}
+	b.inFlight.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				b.handleError(channel, fmt.Errorf("handler panicked: %v", r))
+			}
+
+			<-b.workers
+			b.inFlight.Done()
+		}()
+
+		err := handler(data)
+		if err != nil {
+			b.handleError(channel, err)
+		}
+	}()
+}
+
+// Close unsubscribes from all channels and waits for the running handlers to finish
+func (b *EventBus) Close() error {
+	b.mutex.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.closing)
+	}
+	subscription := b.subscription
+	received := b.received
+	b.mutex.Unlock()
+
+	var err error
+
+	if subscription != nil {
+		err = subscription.Close()
+	}
+
+	if received != nil {
+		<-received
+	}
+
+	b.inFlight.Wait()
+
+	return err
+}
+
+// Handle registers a handler for events of type T on a channel, decoded via codec (JSON if nil)
+//
+// Multiple handlers can be registered for the same channel, each of them receives all events.
+func Handle[T any](bus *EventBus, channel string, codec Codec[T], handler func(event T) error) error {
+	if codec == nil {
+		codec = JSONCodec[T]{}
+	}
+
+	return bus.register(channel, func(data []byte) error {
+		event, err := codec.Unmarshal(data)
+		if err != nil {
+			return fmt.Errorf("can't unmarshal event: %s", err)
+		}
+
+		return handler(event)
+	})
+}
+
+// PublishEvent encodes an event of type T via codec (JSON if nil) and publishes it on a channel
+//
+// Returns the number of receivers
+func PublishEvent[T any](bus *EventBus, channel string, event T, codec Codec[T]) (int, error) {
+	if codec == nil {
+		codec = JSONCodec[T]{}
+	}
+
+	data, err := codec.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("can't marshal event: %s", err)
+	}
+
+	return bus.service.Publish(channel, data)
+}
+
+// NewEventBus creates a new event bus running up to maxWorkers handlers concurrently
+func NewEventBus(service IService, maxWorkers int) *EventBus {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+
+	return &EventBus{
+		service:  service,
+		log:      gousu.GetLogger("service.redis.eventbus"),
+		workers:  make(chan struct{}, maxWorkers),
+		handlers: map[string][]eventHandler{},
+		closing:  make(chan struct{}),
+	}
+}
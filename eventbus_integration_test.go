@@ -0,0 +1,65 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventBusTestEvent struct {
+	ID int `json:"id"`
+}
+
+func TestEventBusResubscribesAfterConnectionKilled(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.SubscribeReconnect = false
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	bus := gousuredis.NewEventBus(service, 1)
+	defer bus.Close()
+
+	errs := make(chan error, 10)
+	bus.OnError(func(channel string, err error) {
+		errs <- err
+	})
+
+	received := make(chan int, 10)
+	require.NoError(t, gousuredis.Handle(bus, "orders", nil, func(event eventBusTestEvent) error {
+		received <- event.ID
+
+		return nil
+	}))
+
+	_, err := service.Do("CLIENT", "KILL", "TYPE", "pubsub")
+	require.NoError(t, err)
+
+	select {
+	case err := <-errs:
+		assert.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("failed subscription not reported")
+	}
+
+	// Delivery resumes once the bus resubscribed, without further calls of Handle
+	timeout := time.After(5 * time.Second)
+
+	for {
+		_, err := gousuredis.PublishEvent(bus, "orders", eventBusTestEvent{ID: 1}, nil)
+		require.NoError(t, err)
+
+		select {
+		case id := <-received:
+			assert.Equal(t, 1, id)
+
+			return
+		case <-timeout:
+			t.Fatal("delivery didn't resume")
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
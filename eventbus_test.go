@@ -0,0 +1,168 @@
+package gousuredis
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type eventBusTestSubscription struct {
+	channels []interface{}
+	messages chan Message
+	close    sync.Once
+}
+
+func (s *eventBusTestSubscription) Subscribe(channel ...interface{}) error {
+	s.channels = append(s.channels, channel...)
+
+	return nil
+}
+
+func (s *eventBusTestSubscription) Unsubscribe(channel ...interface{}) error {
+	return nil
+}
+
+func (s *eventBusTestSubscription) Close() error {
+	s.close.Do(func() {
+		close(s.messages)
+	})
+
+	return nil
+}
+
+type eventBusTestEvent struct {
+	ID int `json:"id"`
+}
+
+func TestEventBus(t *testing.T) {
+	subscription := &eventBusTestSubscription{messages: make(chan Message, 10)}
+
+	mock := NewMockService()
+	mock.SubscribeFunc = func(channels []string) (chan Message, ISubscription, error) {
+		return subscription.messages, subscription, nil
+	}
+
+	bus := NewEventBus(mock, 2)
+
+	mutex := sync.Mutex{}
+	received := []int{}
+	errs := []string{}
+
+	bus.OnError(func(channel string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		errs = append(errs, channel+": "+err.Error())
+	})
+
+	assert.NoError(t, Handle(bus, "orders", nil, func(event eventBusTestEvent) error {
+		if event.ID == 3 {
+			panic("boom")
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		received = append(received, event.ID)
+
+		return nil
+	}))
+	assert.NoError(t, Handle(bus, "users", nil, func(event eventBusTestEvent) error {
+		return nil
+	}))
+
+	assert.Equal(t, 1, mock.SubscribeFuncCalled)
+	assert.Equal(t, []interface{}{"users"}, subscription.channels)
+
+	subscription.messages <- Message{Channel: "orders", Data: []byte(`{"id":1}`)}
+	subscription.messages <- Message{Channel: "orders", Data: []byte(`{"id":2}`)}
+	subscription.messages <- Message{Channel: "orders", Data: []byte(`{"id":3}`)}
+	subscription.messages <- Message{Channel: "orders", Data: []byte(`invalid`)}
+
+	assert.NoError(t, bus.Close())
+
+	assert.ElementsMatch(t, []int{1, 2}, received)
+	assert.Len(t, errs, 2)
+
+	_, err := PublishEvent(bus, "orders", eventBusTestEvent{ID: 4}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []MockPublishCall{{Channel: "orders", Data: []byte(`{"id":4}`)}}, mock.PublishCalls)
+}
+
+func TestEventBusResubscribe(t *testing.T) {
+	subscriptions := make(chan *eventBusTestSubscription, 2)
+	subscribed := make(chan []string, 2)
+
+	mock := NewMockService()
+	mock.SubscribeFunc = func(channels []string) (chan Message, ISubscription, error) {
+		subscription := &eventBusTestSubscription{messages: make(chan Message, 10)}
+		subscriptions <- subscription
+		subscribed <- channels
+
+		return subscription.messages, subscription, nil
+	}
+
+	bus := NewEventBus(mock, 1)
+
+	errs := make(chan error, 1)
+	bus.OnError(func(channel string, err error) {
+		errs <- err
+	})
+
+	received := make(chan int, 1)
+	handler := func(event eventBusTestEvent) error {
+		received <- event.ID
+
+		return nil
+	}
+
+	assert.NoError(t, Handle(bus, "orders", nil, handler))
+	assert.Equal(t, []string{"orders"}, <-subscribed)
+
+	// the subscription fails without redis_subscribe_reconnect
+	subscription := <-subscriptions
+	subscription.messages <- Message{Error: errors.New("connection reset")}
+	subscription.Close()
+
+	assert.EqualError(t, <-errs, "connection reset")
+
+	// the bus resubscribes without further calls of Handle
+	select {
+	case channels := <-subscribed:
+		assert.Equal(t, []string{"orders"}, channels)
+	case <-time.After(3 * eventBusResubscribeBackoff):
+		t.Fatal("not resubscribed")
+	}
+
+	subscription = <-subscriptions
+	subscription.messages <- Message{Channel: "orders", Data: []byte(`{"id":1}`)}
+
+	assert.Equal(t, 1, <-received)
+
+	assert.NoError(t, bus.Close())
+}
+
+func TestEventBusCloseWhileResubscribing(t *testing.T) {
+	subscription := &eventBusTestSubscription{messages: make(chan Message, 10)}
+
+	mock := NewMockService()
+	mock.SubscribeFunc = func(channels []string) (chan Message, ISubscription, error) {
+		return subscription.messages, subscription, nil
+	}
+
+	bus := NewEventBus(mock, 1)
+	bus.OnError(func(channel string, err error) {})
+
+	assert.NoError(t, Handle(bus, "orders", nil, func(event eventBusTestEvent) error {
+		return nil
+	}))
+
+	subscription.messages <- Message{Error: errors.New("connection reset")}
+	subscription.Close()
+
+	assert.NoError(t, bus.Close())
+	assert.Equal(t, 1, mock.SubscribeFuncCalled)
+}
@@ -1,6 +1,6 @@
-module github.com/indece-official/go-gousu-redis
+module github.com/indece-official/go-gousu-redis/v2
 
-go 1.17
+go 1.18
 
 require (
 	github.com/go-redsync/redsync/v4 v4.4.2
@@ -8,6 +8,7 @@ require (
 	github.com/indece-official/go-gousu v1.2.0
 	github.com/mna/redisc v1.3.2
 	github.com/namsral/flag v1.7.4-pre
+	github.com/stretchr/testify v1.7.0
 )
 
 require (
@@ -17,7 +18,6 @@ require (
 	github.com/hashicorp/errwrap v1.0.0 // indirect
 	github.com/hashicorp/go-multierror v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.7.0 // indirect
 	gopkg.in/guregu/null.v4 v4.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
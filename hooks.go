@@ -0,0 +1,82 @@
+package gousuredis
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/indece-official/go-gousu"
+)
+
+// Hook is called around every command sent by the service, e.g. for logging, metrics or auditing
+//
+// Hooks are called in the order they were added before a command and in reverse order after it.
+// The args are passed without key prefix and must not be modified. AfterCommand of commands
+// queued via Send (e.g. in pipelines and transactions) is called when their reply is received,
+// so their duration includes waiting for the replies of the commands sent before.
+type Hook interface {
+	// BeforeCommand is called before a command is sent, the returned context is passed to
+	// the following hooks and AfterCommand
+	BeforeCommand(ctx context.Context, commandName string, args []interface{}) context.Context
+	// AfterCommand is called with the duration and error of a command
+	AfterCommand(ctx context.Context, commandName string, args []interface{}, duration time.Duration, err error)
+}
+
+// AddHook registers a hook called around every command
+func (s *Service) AddHook(hook Hook) {
+	s.hooksMutex.Lock()
+	defer s.hooksMutex.Unlock()
+
+	hooks := make([]Hook, 0, len(s.hooks)+1)
+	hooks = append(hooks, s.hooks...)
+
+	s.hooks = append(hooks, hook)
+}
+
+// initHooks registers the hooks configured in the options
+func (s *Service) initHooks() {
+	if s.options.Tracer != nil {
+		s.AddHook(&tracingHook{tracer: s.options.Tracer})
+	}
+
+	if s.options.SlowThreshold > 0 {
+		s.AddHook(&slowLogHook{
+			log:       s.log,
+			threshold: s.options.SlowThreshold,
+		})
+	}
+}
+
+func (s *Service) getHooks() []Hook {
+	s.hooksMutex.RLock()
+	defer s.hooksMutex.RUnlock()
+
+	return s.hooks
+}
+
+// slowLogHook logs commands taking longer than the threshold
+type slowLogHook struct {
+	log       *gousu.Log
+	threshold time.Duration
+}
+
+var _ (Hook) = (*slowLogHook)(nil)
+
+func (h *slowLogHook) BeforeCommand(ctx context.Context, commandName string, args []interface{}) context.Context {
+	return ctx
+}
+
+func (h *slowLogHook) AfterCommand(ctx context.Context, commandName string, args []interface{}, duration time.Duration, err error) {
+	if duration < h.threshold || blockingCommands[strings.ToUpper(commandName)] {
+		return
+	}
+
+	key := commandKey(commandName, args)
+	if key == "" {
+		h.log.Warnf("Slow redis command %s took %s", strings.ToUpper(commandName), duration)
+
+		return
+	}
+
+	h.log.Warnf("Slow redis command %s %s took %s", strings.ToUpper(commandName), key, duration)
+}
@@ -0,0 +1,112 @@
+package gousuredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+type testHook struct {
+	name  string
+	calls *[]string
+}
+
+func (h *testHook) BeforeCommand(ctx context.Context, commandName string, args []interface{}) context.Context {
+	*h.calls = append(*h.calls, fmt.Sprintf("%s before %s %v", h.name, commandName, args))
+
+	return ctx
+}
+
+func (h *testHook) AfterCommand(ctx context.Context, commandName string, args []interface{}, duration time.Duration, err error) {
+	*h.calls = append(*h.calls, fmt.Sprintf("%s after %s %v", h.name, commandName, err))
+}
+
+func TestHooks(t *testing.T) {
+	calls := []string{}
+
+	service := NewServiceWithOptions("redis", DefaultOptions())
+	service.AddHook(&testHook{name: "hook1", calls: &calls})
+	service.AddHook(&testHook{name: "hook2", calls: &calls})
+
+	finish := service.startCommand("GET", []interface{}{"key1"})
+	finish(errors.New("failed"))
+
+	assert.Equal(t, []string{
+		"hook1 before GET [key1]",
+		"hook2 before GET [key1]",
+		"hook2 after GET failed",
+		"hook1 after GET failed",
+	}, calls)
+}
+
+type pipelineConn struct {
+	fakeConn
+	replies []interface{}
+}
+
+func (c *pipelineConn) Send(commandName string, args ...interface{}) error {
+	if commandName == "GET" {
+		c.replies = append(c.replies, redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	} else {
+		c.replies = append(c.replies, "OK")
+	}
+
+	return nil
+}
+
+func (c *pipelineConn) Flush() error {
+	return nil
+}
+
+func (c *pipelineConn) Receive() (interface{}, error) {
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+
+	if err, ok := reply.(redis.Error); ok {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func TestHooksPipelined(t *testing.T) {
+	calls := []string{}
+
+	service := NewServiceWithOptions("redis", DefaultOptions())
+	service.AddHook(&testHook{name: "hook", calls: &calls})
+
+	conn := &serviceConn{service: service, delegate: &pipelineConn{}}
+
+	assert.NoError(t, conn.Send("SET", "key1", "value"))
+	assert.NoError(t, conn.Send("GET", "key2"))
+	assert.NoError(t, conn.Send("DEL", "key3"))
+	assert.NoError(t, conn.Flush())
+
+	assert.Equal(t, []string{
+		"hook before SET [key1 value]",
+		"hook before GET [key2]",
+		"hook before DEL [key3]",
+	}, calls)
+
+	calls = []string{}
+
+	_, err := conn.Receive()
+	assert.NoError(t, err)
+	_, err = conn.Receive()
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"hook after SET <nil>",
+		"hook after GET WRONGTYPE Operation against a key holding the wrong kind of value",
+	}, calls)
+
+	calls = []string{}
+
+	assert.NoError(t, conn.Close())
+	assert.Equal(t, []string{"hook after DEL " + errReplyDiscarded.Error()}, calls)
+}
@@ -0,0 +1,240 @@
+package gousuredis
+
+import (
+	"strconv"
+	"strings"
+)
+
+// keySpec defines the positions of the key arguments of a command
+//
+// last is relative to the end of the arguments: -1 is the last argument,
+// -2 the second last etc.
+type keySpec struct {
+	first int
+	last  int
+	step  int
+}
+
+var (
+	keySpecFirst       = keySpec{first: 0, last: 0, step: 1}
+	keySpecAll         = keySpec{first: 0, last: -1, step: 1}
+	keySpecAllButLast  = keySpec{first: 0, last: -2, step: 1}
+	keySpecFirstTwo    = keySpec{first: 0, last: 1, step: 1}
+	keySpecSecond      = keySpec{first: 1, last: 1, step: 1}
+	keySpecAllButFirst = keySpec{first: 1, last: -1, step: 1}
+	keySpecPairs       = keySpec{first: 0, last: -1, step: 2}
+)
+
+// keySpecs contains the key positions of all commands which are prefixed
+//
+// Arguments of commands not contained are passed through unchanged.
+var keySpecs = map[string]keySpec{}
+
+func init() {
+	for _, commandName := range []string{
+		"GET", "SET", "SETNX", "SETEX", "PSETEX", "GETSET", "GETDEL", "GETEX",
+		"APPEND", "STRLEN", "GETRANGE", "SETRANGE",
+		"INCR", "DECR", "INCRBY", "DECRBY", "INCRBYFLOAT",
+		"EXPIRE", "PEXPIRE", "EXPIREAT", "PEXPIREAT", "TTL", "PTTL", "PERSIST",
+		"TYPE", "DUMP", "RESTORE",
+		"SETBIT", "GETBIT", "BITCOUNT", "BITPOS", "BITFIELD", "PFADD",
+		"GEOADD", "GEOPOS", "GEODIST", "GEOHASH", "GEOSEARCH", "GEORADIUS", "GEORADIUSBYMEMBER",
+		"LPUSH", "RPUSH", "LPOP", "RPOP", "LRANGE", "LREM", "LINDEX", "LLEN", "LSET", "LTRIM", "LINSERT",
+		"HGET", "HSET", "HSETNX", "HMGET", "HMSET", "HGETALL", "HDEL", "HLEN", "HKEYS", "HVALS",
+		"HINCRBY", "HINCRBYFLOAT", "HSCAN", "HEXISTS",
+		"SADD", "SREM", "SMEMBERS", "SISMEMBER", "SCARD", "SPOP", "SRANDMEMBER", "SSCAN",
+		"ZADD", "ZRANGE", "ZRANGEBYSCORE", "ZREVRANGE", "ZREM", "ZINCRBY", "ZSCORE", "ZRANK", "ZREVRANK",
+		"ZCARD", "ZPOPMIN", "ZPOPMAX", "ZSCAN", "ZCOUNT", "ZREMRANGEBYSCORE", "ZREMRANGEBYRANK",
+		"XADD", "XRANGE", "XREVRANGE", "XLEN", "XACK", "XPENDING", "XCLAIM", "XAUTOCLAIM", "XDEL", "XTRIM",
+		"BF.RESERVE", "BF.ADD", "BF.MADD", "BF.EXISTS", "BF.MEXISTS",
+		"CF.RESERVE", "CF.ADD", "CF.ADDNX", "CF.EXISTS", "CF.DEL", "CF.COUNT",
+	} {
+		keySpecs[commandName] = keySpecFirst
+	}
+
+	for _, commandName := range []string{
+		"DEL", "UNLINK", "EXISTS", "TOUCH", "MGET", "WATCH",
+		"SINTER", "SUNION", "SDIFF", "SINTERSTORE", "SUNIONSTORE", "SDIFFSTORE",
+		"PFCOUNT", "PFMERGE",
+	} {
+		keySpecs[commandName] = keySpecAll
+	}
+
+	for _, commandName := range []string{"BLPOP", "BRPOP", "BZPOPMIN", "BZPOPMAX"} {
+		keySpecs[commandName] = keySpecAllButLast
+	}
+
+	for _, commandName := range []string{"RENAME", "RENAMENX", "COPY", "LMOVE", "BLMOVE", "RPOPLPUSH", "BRPOPLPUSH", "SMOVE"} {
+		keySpecs[commandName] = keySpecFirstTwo
+	}
+
+	for _, commandName := range []string{"XGROUP", "XINFO", "OBJECT"} {
+		keySpecs[commandName] = keySpecSecond
+	}
+
+	keySpecs["BITOP"] = keySpecAllButFirst
+	keySpecs["MSET"] = keySpecPairs
+	keySpecs["MSETNX"] = keySpecPairs
+}
+
+// commandKey returns the first key of a command (unprefixed) or "" if the command has no known key
+func commandKey(commandName string, args []interface{}) string {
+	spec, ok := keySpecs[strings.ToUpper(commandName)]
+	if !ok || spec.first >= len(args) {
+		return ""
+	}
+
+	return argString(args[spec.first])
+}
+
+func prefixKey(prefix string, arg interface{}) interface{} {
+	switch key := arg.(type) {
+	case string:
+		return prefix + key
+	case []byte:
+		return append([]byte(prefix), key...)
+	default:
+		return arg
+	}
+}
+
+// prefixArgs returns a copy of the arguments of a command with all keys prefixed
+func prefixArgs(prefix string, commandName string, args []interface{}) []interface{} {
+	if prefix == "" || len(args) == 0 {
+		return args
+	}
+
+	commandName = strings.ToUpper(commandName)
+
+	prefixed := make([]interface{}, len(args))
+	copy(prefixed, args)
+
+	switch commandName {
+	case "SCAN":
+		return prefixScanArgs(prefix, prefixed)
+	case "EVAL", "EVALSHA":
+		// script, numkeys, keys..., args...
+		if len(prefixed) < 2 {
+			return prefixed
+		}
+
+		numKeys, err := strconv.Atoi(argString(prefixed[1]))
+		if err != nil {
+			return prefixed
+		}
+
+		for i := 2; i < 2+numKeys && i < len(prefixed); i++ {
+			prefixed[i] = prefixKey(prefix, prefixed[i])
+		}
+
+		return prefixed
+	case "XREAD", "XREADGROUP":
+		// ... STREAMS key1 key2 ... id1 id2 ...
+		for i, arg := range prefixed {
+			if strings.ToUpper(argString(arg)) != "STREAMS" {
+				continue
+			}
+
+			streams := prefixed[i+1:]
+			for j := 0; j < len(streams)/2; j++ {
+				streams[j] = prefixKey(prefix, streams[j])
+			}
+
+			break
+		}
+
+		return prefixed
+	case "SORT", "SORT_RO":
+		// key [BY pattern] [LIMIT offset count] [GET pattern ...] [ASC|DESC] [ALPHA] [STORE destination]
+		prefixed[0] = prefixKey(prefix, prefixed[0])
+
+		for i := 1; i < len(prefixed)-1; i++ {
+			switch strings.ToUpper(argString(prefixed[i])) {
+			case "BY":
+				if strings.ToLower(argString(prefixed[i+1])) != "nosort" {
+					prefixed[i+1] = prefixKey(prefix, prefixed[i+1])
+				}
+			case "GET":
+				if argString(prefixed[i+1]) != "#" {
+					prefixed[i+1] = prefixKey(prefix, prefixed[i+1])
+				}
+			case "STORE":
+				prefixed[i+1] = prefixKey(prefix, prefixed[i+1])
+			case "LIMIT":
+				i += 2
+
+				continue
+			default:
+				continue
+			}
+
+			i++
+		}
+
+		return prefixed
+	case "MEMORY":
+		if len(prefixed) >= 2 && strings.ToUpper(argString(prefixed[0])) == "USAGE" {
+			prefixed[1] = prefixKey(prefix, prefixed[1])
+		}
+
+		return prefixed
+	}
+
+	spec, ok := keySpecs[commandName]
+	if !ok {
+		return prefixed
+	}
+
+	last := spec.last
+	if last < 0 {
+		last = len(prefixed) + last
+	}
+
+	for i := spec.first; i <= last && i < len(prefixed); i += spec.step {
+		prefixed[i] = prefixKey(prefix, prefixed[i])
+	}
+
+	return prefixed
+}
+
+// prefixScanArgs prefixes the MATCH pattern of SCAN or adds one matching all prefixed keys
+func prefixScanArgs(prefix string, args []interface{}) []interface{} {
+	for i := 1; i < len(args)-1; i++ {
+		if strings.ToUpper(argString(args[i])) == "MATCH" {
+			args[i+1] = escapePattern(prefix) + argString(args[i+1])
+
+			return args
+		}
+	}
+
+	return append(args, "MATCH", escapePattern(prefix)+"*")
+}
+
+func argString(arg interface{}) string {
+	switch value := arg.(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	case int:
+		return strconv.Itoa(value)
+	default:
+		return ""
+	}
+}
+
+// stripKeyPrefix removes the key prefix from a key returned by redis
+func (s *Service) stripKeyPrefix(key string) string {
+	return strings.TrimPrefix(key, s.options.KeyPrefix)
+}
+
+// prefixKeys returns a copy of the keys with the key prefix, e.g. to bind a cluster
+// connection to the slot of the keys
+func (s *Service) prefixKeys(keys []string) []string {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = s.options.KeyPrefix + key
+	}
+
+	return prefixed
+}
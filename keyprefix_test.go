@@ -0,0 +1,21 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixArgs(t *testing.T) {
+	assert.Equal(t, []interface{}{"app:key", []byte("value")}, prefixArgs("app:", "set", []interface{}{"key", []byte("value")}))
+	assert.Equal(t, []interface{}{"app:key1", "app:key2", 5}, prefixArgs("app:", "BLPOP", []interface{}{"key1", "key2", 5}))
+	assert.Equal(t, []interface{}{"app:key1", "value1", "app:key2", "value2"}, prefixArgs("app:", "MSET", []interface{}{"key1", "value1", "key2", "value2"}))
+	assert.Equal(t, []interface{}{"script", 1, "app:key", "arg"}, prefixArgs("app:", "EVAL", []interface{}{"script", 1, "key", "arg"}))
+	assert.Equal(t, []interface{}{"GROUP", "g", "c", "STREAMS", "app:stream", ">"}, prefixArgs("app:", "XREADGROUP", []interface{}{"GROUP", "g", "c", "STREAMS", "stream", ">"}))
+	assert.Equal(t, []interface{}{0, "MATCH", "app:user:*"}, prefixArgs("app:", "SCAN", []interface{}{0, "MATCH", "user:*"}))
+	assert.Equal(t, []interface{}{0, "MATCH", "app\\*:*"}, prefixArgs("app*:", "SCAN", []interface{}{0}))
+	assert.Equal(t, []interface{}{"channel", "data"}, prefixArgs("app:", "PUBLISH", []interface{}{"channel", "data"}))
+	assert.Equal(t, []interface{}{"app:ids", "BY", "app:weight_*", "LIMIT", 0, 10, "GET", "#", "GET", "app:obj_*->name", "ALPHA"}, prefixArgs("app:", "SORT", []interface{}{"ids", "BY", "weight_*", "LIMIT", 0, 10, "GET", "#", "GET", "obj_*->name", "ALPHA"}))
+	assert.Equal(t, []interface{}{"app:ids", "BY", "nosort", "STORE", "app:dest"}, prefixArgs("app:", "SORT", []interface{}{"ids", "BY", "nosort", "STORE", "dest"}))
+	assert.Equal(t, []interface{}{"key"}, prefixArgs("", "GET", []interface{}{"key"}))
+}
@@ -0,0 +1,12 @@
+package gousuredis_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testutil.Run(m))
+}
@@ -0,0 +1,106 @@
+package gousuredis
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// rateLimiterScript implements a sliding window log in a sorted set using the server time
+//
+// KEYS[1] = key, ARGV[1] = window in ms, ARGV[2] = limit, ARGV[3] = random member suffix
+var rateLimiterScript = redis.NewScript(1, `
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, now .. ':' .. ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+
+local reset = now + window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window
+end
+
+redis.call('PEXPIRE', KEYS[1], window)
+
+return {allowed, limit - count, reset}
+`)
+
+// RateLimitResult is the result of RateLimiter.Allow(...)
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// ResetAt is the time when the oldest request leaves the window
+	ResetAt time.Time
+}
+
+// IRateLimiter defines the interface of RateLimiter
+type IRateLimiter interface {
+	Allow(identifier string) (*RateLimitResult, error)
+}
+
+// RateLimiter limits the number of requests per identifier within a sliding window
+type RateLimiter struct {
+	service *Service
+	key     string
+	limit   int
+	window  time.Duration
+}
+
+var _ (IRateLimiter) = (*RateLimiter)(nil)
+
+// Allow checks if a request is allowed for the identifier (e.g. an user id or ip) and records it if so
+//
+// Rejected requests are not recorded and don't extend the window.
+func (r *RateLimiter) Allow(identifier string) (*RateLimitResult, error) {
+	conn, err := r.service.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	result, err := redis.Int64s(rateLimiterScript.Do(
+		conn,
+		fmt.Sprintf("%s:%s", r.key, identifier),
+		r.window.Milliseconds(),
+		r.limit,
+		rand.Int63(),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) != 3 {
+		return nil, fmt.Errorf("malformed rate limiter result: %v", result)
+	}
+
+	return &RateLimitResult{
+		Allowed:   result[0] == 1,
+		Remaining: int(result[1]),
+		ResetAt:   time.Unix(0, result[2]*int64(time.Millisecond)),
+	}, nil
+}
+
+// NewRateLimiter creates a new rate limiter allowing limit requests per window
+//
+// The requests are tracked in sorted sets stored at key:identifier.
+func NewRateLimiter(service *Service, key string, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		service: service,
+		key:     key,
+		limit:   limit,
+		window:  window,
+	}
+}
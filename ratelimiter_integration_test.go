@@ -0,0 +1,45 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	limiter := gousuredis.NewRateLimiter(service, "ratelimit", 2, time.Second)
+
+	start := time.Now()
+
+	for i, expected := range []gousuredis.RateLimitResult{
+		{Allowed: true, Remaining: 1},
+		{Allowed: true, Remaining: 0},
+		{Allowed: false, Remaining: 0},
+	} {
+		result, err := limiter.Allow("user1")
+		require.NoError(t, err)
+
+		assert.Equal(t, expected.Allowed, result.Allowed, "request %d", i+1)
+		assert.Equal(t, expected.Remaining, result.Remaining, "request %d", i+1)
+		assert.WithinDuration(t, start.Add(time.Second), result.ResetAt, 500*time.Millisecond)
+	}
+
+	// identifiers are limited separately
+	result, err := limiter.Allow("user2")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// rejected requests don't extend the window
+	time.Sleep(1100 * time.Millisecond)
+
+	result, err = limiter.Allow("user1")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
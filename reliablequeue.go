@@ -0,0 +1,285 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// reliableQueueNackScript moves a message from the processing list back to the ready list
+//
+// KEYS[1] = processing list, KEYS[2] = ready list, ARGV[1] = message
+var reliableQueueNackScript = redis.NewScript(2, `
+local removed = redis.call('LREM', KEYS[1], 1, ARGV[1])
+if removed > 0 then
+	redis.call('RPUSH', KEYS[2], ARGV[1])
+end
+return removed
+`)
+
+// reliableQueueRequeueScript moves all messages of a consumer without heartbeat back to the ready list
+//
+// KEYS[1] = heartbeat, KEYS[2] = processing list, KEYS[3] = ready list, KEYS[4] = consumers set,
+// ARGV[1] = consumer name
+var reliableQueueRequeueScript = redis.NewScript(4, `
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return -1
+end
+local count = 0
+while redis.call('RPOPLPUSH', KEYS[2], KEYS[3]) do
+	count = count + 1
+end
+redis.call('SREM', KEYS[4], ARGV[1])
+return count
+`)
+
+// reliableQueueDefaultVisibilityTimeout is used if no visibility timeout is passed to NewReliableQueue
+const reliableQueueDefaultVisibilityTimeout = 30 * time.Second
+
+// IReliableQueue defines the interface of ReliableQueue
+type IReliableQueue interface {
+	Push(data []byte) error
+	Pop(timeout time.Duration) ([]byte, error)
+	Ack(data []byte) error
+	Nack(data []byte) error
+	Touch() error
+	RequeueStale() (int, error)
+	Close() error
+}
+
+// ReliableQueue is a list based queue where popped messages are kept in a
+// per-consumer processing list until they are acknowledged
+//
+// Each consumer keeps a heartbeat alive, which is refreshed on every Pop, Ack,
+// Nack and Touch. Messages of consumers whose heartbeat expired after the visibility
+// timeout are moved back to the queue by the other consumers. In cluster mode the
+// name must contain a hash tag (e.g. "{jobs}"), so all keys are in the same slot.
+type ReliableQueue struct {
+	service           *Service
+	name              string
+	consumerName      string
+	visibilityTimeout time.Duration
+	stop              chan struct{}
+}
+
+var _ (IReliableQueue) = (*ReliableQueue)(nil)
+
+func (q *ReliableQueue) readyKey() string {
+	return q.name
+}
+
+func (q *ReliableQueue) consumersKey() string {
+	return fmt.Sprintf("%s:consumers", q.name)
+}
+
+func (q *ReliableQueue) processingKey(consumerName string) string {
+	return fmt.Sprintf("%s:processing:%s", q.name, consumerName)
+}
+
+func (q *ReliableQueue) heartbeatKey(consumerName string) string {
+	return fmt.Sprintf("%s:heartbeat:%s", q.name, consumerName)
+}
+
+// Push adds a message to the queue
+func (q *ReliableQueue) Push(data []byte) error {
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("LPUSH", q.readyKey(), data)
+
+	return err
+}
+
+// Pop waits for the next message (blocking with timeout) and moves it to the processing list of the consumer
+//
+// Returns ErrNil if no message was available within the timeout. The timeout should be
+// shorter than the visibility timeout, as the heartbeat is only refreshed before waiting.
+func (q *ReliableQueue) Pop(timeout time.Duration) ([]byte, error) {
+	err := q.Touch()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := q.service.openBlockingConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bytes(redis.DoWithTimeout(
+		conn,
+		timeout+blockReadTimeoutMargin,
+		"BRPOPLPUSH",
+		q.readyKey(),
+		q.processingKey(q.consumerName),
+		blockTimeoutArg(timeout),
+	))
+}
+
+// Ack removes a processed message from the processing list
+func (q *ReliableQueue) Ack(data []byte) error {
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	removed, err := redis.Int(conn.Do("LREM", q.processingKey(q.consumerName), 1, data))
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return fmt.Errorf("message not found in processing list")
+	}
+
+	return q.touch(conn)
+}
+
+// Nack moves a message from the processing list back to the queue, where it is the next message to be popped
+func (q *ReliableQueue) Nack(data []byte) error {
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	removed, err := redis.Int(reliableQueueNackScript.Do(conn, q.processingKey(q.consumerName), q.readyKey(), data))
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return fmt.Errorf("message not found in processing list")
+	}
+
+	return q.touch(conn)
+}
+
+// Touch refreshes the heartbeat of the consumer, must be called regularly while
+// processing messages taking longer than the visibility timeout
+func (q *ReliableQueue) Touch() error {
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return q.touch(conn)
+}
+
+func (q *ReliableQueue) touch(conn redis.Conn) error {
+	_, err := conn.Do("SET", q.heartbeatKey(q.consumerName), 1, "PX", q.visibilityTimeout.Milliseconds())
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Do("SADD", q.consumersKey(), q.consumerName)
+
+	return err
+}
+
+// RequeueStale moves all messages of consumers with expired heartbeat back to the queue
+//
+// Returns the number of requeued messages
+func (q *ReliableQueue) RequeueStale() (int, error) {
+	conn, err := q.service.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	consumerNames, err := redis.Strings(conn.Do("SMEMBERS", q.consumersKey()))
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+
+	for _, consumerName := range consumerNames {
+		count, err := redis.Int(reliableQueueRequeueScript.Do(
+			conn,
+			q.heartbeatKey(consumerName),
+			q.processingKey(consumerName),
+			q.readyKey(),
+			q.consumersKey(),
+			consumerName,
+		))
+		if err != nil {
+			return total, err
+		}
+
+		if count > 0 {
+			total += count
+		}
+	}
+
+	return total, nil
+}
+
+// Close stops requeueing stale messages in the background
+//
+// Messages still in the processing list of the consumer are requeued by other
+// consumers after the visibility timeout.
+func (q *ReliableQueue) Close() error {
+	select {
+	case <-q.stop:
+		return fmt.Errorf("already closed")
+	default:
+	}
+
+	close(q.stop)
+
+	return nil
+}
+
+func (q *ReliableQueue) runRequeue() {
+	ticker := time.NewTicker(q.visibilityTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-q.service.done:
+			return
+		case <-ticker.C:
+		}
+
+		count, err := q.RequeueStale()
+		if err != nil {
+			q.service.log.Warnf("Requeueing stale messages of queue %s failed: %s", q.name, err)
+			continue
+		}
+
+		if count > 0 {
+			q.service.log.Infof("Requeued %d stale messages of queue %s", count, q.name)
+		}
+	}
+}
+
+// NewReliableQueue creates a new reliable queue for a consumer and starts
+// requeueing stale messages of other consumers in the background
+//
+// A visibilityTimeout below 1 millisecond is replaced by 30 seconds.
+func NewReliableQueue(service *Service, name string, consumerName string, visibilityTimeout time.Duration) *ReliableQueue {
+	if visibilityTimeout < time.Millisecond {
+		visibilityTimeout = reliableQueueDefaultVisibilityTimeout
+	}
+
+	queue := &ReliableQueue{
+		service:           service.withoutContext(),
+		name:              name,
+		consumerName:      consumerName,
+		visibilityTimeout: visibilityTimeout,
+		stop:              make(chan struct{}),
+	}
+
+	go queue.runRequeue()
+
+	return queue
+}
@@ -0,0 +1,67 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReliableQueue(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	queue := gousuredis.NewReliableQueue(service, "jobs", "worker1", 500*time.Millisecond)
+
+	require.NoError(t, queue.Push([]byte("a")))
+	require.NoError(t, queue.Push([]byte("b")))
+
+	// messages are popped in order
+	data, err := queue.Pop(100 * time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	// nacked messages are popped next
+	require.NoError(t, queue.Nack(data))
+
+	data, err = queue.Pop(100 * time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	require.NoError(t, queue.Ack(data))
+	assert.Error(t, queue.Ack(data))
+
+	data, err = queue.Pop(100 * time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(data))
+
+	_, err = queue.Pop(100 * time.Millisecond)
+	assert.Equal(t, gousuredis.ErrNil, err)
+
+	// the unacknowledged message is requeued after the heartbeat of the closed consumer expired
+	require.NoError(t, queue.Close())
+
+	queue2 := gousuredis.NewReliableQueue(service, "jobs", "worker2", time.Minute)
+	defer queue2.Close()
+
+	count, err := queue2.RequeueStale()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	time.Sleep(600 * time.Millisecond)
+
+	count, err = queue2.RequeueStale()
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	data, err = queue2.Pop(100 * time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(data))
+
+	// consumers with heartbeat keep their messages
+	count, err = queue.RequeueStale()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
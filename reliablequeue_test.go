@@ -0,0 +1,23 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReliableQueueVisibilityTimeout(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	queue := NewReliableQueue(service, "jobs", "worker1", 0)
+	defer queue.Close()
+
+	assert.Equal(t, reliableQueueDefaultVisibilityTimeout, queue.visibilityTimeout)
+	assert.Equal(t, "jobs:processing:worker1", queue.processingKey("worker1"))
+
+	queue2 := NewReliableQueue(service, "jobs", "worker2", time.Minute)
+	defer queue2.Close()
+
+	assert.Equal(t, time.Minute, queue2.visibilityTimeout)
+}
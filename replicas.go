@@ -0,0 +1,154 @@
+package gousuredis
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+)
+
+// ReadPreference defines where read-only commands are sent to
+type ReadPreference string
+
+const (
+	// ReadPreferenceMaster sends all commands to the master (default)
+	ReadPreferenceMaster ReadPreference = "master"
+	// ReadPreferenceReplica sends read-only commands to the replicas only
+	ReadPreferenceReplica ReadPreference = "replica"
+	// ReadPreferenceReplicaPreferred sends read-only commands to the replicas and falls
+	// back to the master if no replica is available
+	ReadPreferenceReplicaPreferred ReadPreference = "replica_preferred"
+)
+
+// validateReadPreference checks the read preference against the configured replicas
+func (s *Service) validateReadPreference() error {
+	switch s.options.ReadPreference {
+	case "", ReadPreferenceMaster, ReadPreferenceReplicaPreferred:
+		return nil
+	case ReadPreferenceReplica:
+		if !s.options.ClusterMode && len(s.options.ReplicaAddrs) == 0 {
+			return fmt.Errorf("read preference %s requires replica addresses or cluster mode", ReadPreferenceReplica)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("invalid read preference '%s'", s.options.ReadPreference)
+	}
+}
+
+// createReplicaPools creates a pool for each replica address (not used in cluster mode,
+// where the replicas are discovered via the cluster)
+func (s *Service) createReplicaPools(opts ...redis.DialOption) error {
+	if s.options.ClusterMode || !s.readsFromReplicas() {
+		return nil
+	}
+
+	s.replicaPools = make([]*redis.Pool, len(s.options.ReplicaAddrs))
+
+	for i, addr := range s.options.ReplicaAddrs {
+		pool, err := s.createPool(addr, opts...)
+		if err != nil {
+			return fmt.Errorf("can't create pool for replica %s: %s", addr, err)
+		}
+
+		s.replicaPools[i] = pool
+	}
+
+	return nil
+}
+
+// readsFromReplicas checks if read-only commands are sent to replicas
+func (s *Service) readsFromReplicas() bool {
+	switch s.options.ReadPreference {
+	case ReadPreferenceReplica, ReadPreferenceReplicaPreferred:
+		return s.options.ClusterMode || len(s.options.ReplicaAddrs) > 0
+	default:
+		return false
+	}
+}
+
+// openReadConn opens a connection for read-only commands, which is connected to a
+// replica depending on redis_read_preference
+//
+// Replicas are replicated asynchronously, so reads may return stale data.
+func (s *Service) openReadConn() (redis.Conn, error) {
+	if !s.readsFromReplicas() {
+		return s.openConn(true)
+	}
+
+	delegate, err := s.openReadDelegate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceConn{service: s, delegate: delegate, useRetry: true, readOnly: true}, nil
+}
+
+// openPinnedReadConn opens a connection for read-only commands, which is never replaced
+// when retrying, so cursors of SSCAN, ZSCAN and HSCAN are continued on the same replica
+func (s *Service) openPinnedReadConn() (redis.Conn, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, err
+	}
+
+	conn.(*serviceConn).pinned = true
+
+	return conn, nil
+}
+
+// openReadDelegate opens the underlying connection of a serviceConn for read-only commands
+func (s *Service) openReadDelegate() (redis.Conn, error) {
+	err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cluster != nil {
+		conn := s.cluster.Get()
+
+		// the cluster falls back to the master if a slot has no replica
+		err = redisc.ReadOnlyConn(conn)
+		if err != nil {
+			conn.Close()
+
+			return nil, fmt.Errorf("can't mark connection read-only: %s", err)
+		}
+
+		rc, err := redisc.RetryConn(conn, 3, 100*time.Millisecond)
+		if err != nil {
+			return nil, fmt.Errorf("retry failed: %s", err)
+		}
+
+		return rc, nil
+	}
+
+	// round robin over all replicas, skipping unavailable ones
+	next := int(atomic.AddUint32(&s.replicaNext, 1))
+
+	var lastErr error
+
+	for i := range s.replicaPools {
+		pool := s.replicaPools[(next+i)%len(s.replicaPools)]
+
+		conn, err := pool.GetContext(s.ctx)
+		if err == nil {
+			return conn, nil
+		}
+
+		lastErr = err
+	}
+
+	if s.options.ReadPreference == ReadPreferenceReplicaPreferred {
+		return s.openDelegate(true)
+	}
+
+	return nil, fmt.Errorf("no replica available: %w", lastErr)
+}
@@ -0,0 +1,77 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPreference(t *testing.T) {
+	options := DefaultOptions()
+
+	service := NewServiceWithOptions("redis", options)
+	assert.NoError(t, service.validateReadPreference())
+	assert.False(t, service.readsFromReplicas())
+
+	options.ReadPreference = ReadPreferenceReplica
+	assert.Error(t, service.validateReadPreference())
+
+	options.ReplicaAddrs = []string{"replica1:6379", "replica2:6379"}
+	assert.NoError(t, service.validateReadPreference())
+	assert.True(t, service.readsFromReplicas())
+
+	options.ReadPreference = ReadPreferenceMaster
+	assert.False(t, service.readsFromReplicas())
+
+	options.ReadPreference = "secondary"
+	assert.Error(t, service.validateReadPreference())
+}
+
+type replicaScanConn struct {
+	fakeConn
+	name  string
+	calls *[]string
+}
+
+func (c *replicaScanConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	if commandName != "SSCAN" {
+		return nil, nil
+	}
+
+	cursor := args[1].(int)
+	*c.calls = append(*c.calls, c.name)
+
+	if cursor == 0 {
+		return []interface{}{[]byte("5"), []interface{}{[]byte("a")}}, nil
+	}
+
+	return []interface{}{[]byte("0"), []interface{}{[]byte("b")}}, nil
+}
+
+func TestScanAllPinsReplica(t *testing.T) {
+	options := DefaultOptions()
+	options.ReadPreference = ReadPreferenceReplica
+	options.ReplicaAddrs = []string{"replica1:6379", "replica2:6379"}
+
+	service := NewServiceWithOptions("redis", options)
+
+	calls := []string{}
+	for _, name := range options.ReplicaAddrs {
+		name := name
+
+		service.replicaPools = append(service.replicaPools, &redis.Pool{
+			Dial: func() (redis.Conn, error) {
+				return &replicaScanConn{name: name, calls: &calls}, nil
+			},
+		})
+	}
+
+	members, err := service.SScanAll("key", "")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, members)
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, calls[0], calls[1])
+}
@@ -0,0 +1,638 @@
+package gousuredis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// PushMessage is an out-of-band message sent by redis to a RESP3 connection, e.g. an
+// "invalidate" message of client-side caching (CLIENT TRACKING)
+type PushMessage struct {
+	// Kind is the first element of the message, e.g. "invalidate"
+	Kind string
+	// Data contains the remaining elements, typed like replies of redis.Conn.Do
+	Data []interface{}
+}
+
+// OnPush registers a callback which is called for every push message received on a
+// connection negotiated with redis_resp3
+//
+// Pub/sub messages of subscriptions are delivered to their subscriptions instead. Push
+// messages are read along with the next reply of their connection, so a connection
+// tracking keys via CLIENT TRACKING must be kept in use (e.g. via WithConn) or redirect
+// its invalidations to a subscription. Callbacks are called sequentially and should not block.
+func (s *Service) OnPush(fn func(msg PushMessage)) {
+	s.pushMutex.Lock()
+	defer s.pushMutex.Unlock()
+
+	s.pushCallbacks = append(s.pushCallbacks, fn)
+}
+
+func (s *Service) dispatchPush(msg PushMessage) {
+	s.pushMutex.Lock()
+	callbacks := s.pushCallbacks
+	s.pushMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback(msg)
+	}
+}
+
+// resp3DialOption returns the dial option negotiating RESP3 on all connections, which
+// also performs the TLS handshake, as it must happen below the protocol translation
+func (s *Service) resp3DialOption() (redis.DialOption, error) {
+	var tlsConfig *tls.Config
+	var err error
+
+	if s.options.TLS {
+		tlsConfig, err = s.loadTLSConfig()
+		if err != nil {
+			return redis.DialOption{}, fmt.Errorf("can't load tls config: %s", err)
+		}
+	}
+
+	username := s.options.Username
+	password := s.options.Password
+
+	if s.options.URL != "" {
+		parsedURL, err := url.Parse(s.options.URL)
+		if err != nil {
+			return redis.DialOption{}, fmt.Errorf("invalid redis url: %s", err)
+		}
+
+		if parsedURL.Scheme == "rediss" {
+			return redis.DialOption{}, fmt.Errorf("redis_resp3 doesn't support rediss urls, use redis_tls instead")
+		}
+
+		// same precedence as redis.DialURL
+		if parsedURL.User != nil {
+			if urlPassword, hasPassword := parsedURL.User.Password(); hasPassword {
+				username = parsedURL.User.Username()
+				password = urlPassword
+			} else if parsedURL.User.Username() != "" {
+				username = ""
+				password = parsedURL.User.Username()
+			}
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   s.options.ConnectTimeout,
+		KeepAlive: 5 * time.Minute,
+	}
+
+	return redis.DialContextFunc(func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		netConn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.options.ConnectTimeout > 0 {
+			netConn.SetDeadline(time.Now().Add(s.options.ConnectTimeout))
+		}
+
+		if tlsConfig != nil {
+			config := tlsConfig.Clone()
+			if config.ServerName == "" {
+				config.ServerName, _, _ = net.SplitHostPort(addr)
+			}
+
+			tlsConn := tls.Client(netConn, config)
+
+			err = tlsConn.HandshakeContext(ctx)
+			if err != nil {
+				netConn.Close()
+
+				return nil, err
+			}
+
+			netConn = tlsConn
+		}
+
+		conn := newRESP3Conn(netConn, s.dispatchPush)
+
+		err = conn.hello(username, password)
+		if err != nil {
+			netConn.Close()
+
+			return nil, fmt.Errorf("can't negotiate resp3: %w", err)
+		}
+
+		netConn.SetDeadline(time.Time{})
+
+		return conn, nil
+	}), nil
+}
+
+// resp3Shape describes how a RESP3 reply differs from the RESP2 reply of its command
+type resp3Shape int
+
+const (
+	resp3ShapeDefault resp3Shape = iota
+	// resp3ShapeStreams replies are maps of streams instead of lists of [stream, events] pairs
+	resp3ShapeStreams
+	// resp3ShapePairs replies are lists of [member, score] pairs instead of flat lists
+	resp3ShapePairs
+	// resp3ShapeScript replies contain lua booleans as booleans instead of nil and 1
+	resp3ShapeScript
+	// resp3ShapePing replies aren't pong messages on subscribed connections
+	resp3ShapePing
+)
+
+var resp3Shapes = map[string]resp3Shape{
+	"XREAD":            resp3ShapeStreams,
+	"XREADGROUP":       resp3ShapeStreams,
+	"ZRANGE":           resp3ShapePairs,
+	"ZRANGEBYSCORE":    resp3ShapePairs,
+	"ZREVRANGE":        resp3ShapePairs,
+	"ZREVRANGEBYSCORE": resp3ShapePairs,
+	"ZPOPMIN":          resp3ShapePairs,
+	"ZPOPMAX":          resp3ShapePairs,
+	"ZRANDMEMBER":      resp3ShapePairs,
+	"ZUNION":           resp3ShapePairs,
+	"ZINTER":           resp3ShapePairs,
+	"ZDIFF":            resp3ShapePairs,
+	"HRANDFIELD":       resp3ShapePairs,
+	"EVAL":             resp3ShapeScript,
+	"EVALSHA":          resp3ShapeScript,
+	"EVAL_RO":          resp3ShapeScript,
+	"EVALSHA_RO":       resp3ShapeScript,
+	"FCALL":            resp3ShapeScript,
+	"FCALL_RO":         resp3ShapeScript,
+	"PING":             resp3ShapePing,
+}
+
+// resp3PubSubCommands are answered by push messages instead of replies
+var resp3PubSubCommands = map[string]bool{
+	"SUBSCRIBE":    true,
+	"PSUBSCRIBE":   true,
+	"SSUBSCRIBE":   true,
+	"UNSUBSCRIBE":  true,
+	"PUNSUBSCRIBE": true,
+	"SUNSUBSCRIBE": true,
+}
+
+// resp3PubSubKinds are push messages passed to the pub/sub connection like in RESP2
+var resp3PubSubKinds = map[string]bool{
+	"message":      true,
+	"pmessage":     true,
+	"smessage":     true,
+	"subscribe":    true,
+	"psubscribe":   true,
+	"ssubscribe":   true,
+	"unsubscribe":  true,
+	"punsubscribe": true,
+	"sunsubscribe": true,
+}
+
+// resp3Map is a RESP3 map as flat list of keys and values
+type resp3Map []interface{}
+
+// resp3Push is a RESP3 push message
+type resp3Push []interface{}
+
+// resp3PendingReply is a reply expected for a sent command
+type resp3PendingReply struct {
+	shape resp3Shape
+	// tx contains the shapes of the commands queued in a transaction for EXEC
+	tx []resp3Shape
+}
+
+// resp3Conn translates a RESP3 connection to RESP2 for redigo
+//
+// Replies are converted to the shape of their RESP2 counterpart, so all commands keep
+// working unchanged. Therefore the names of written commands are tracked, as some
+// commands reply differently in RESP3 (e.g. XREAD, ZRANGE WITHSCORES). Push messages
+// are passed to onPush, except pub/sub messages on subscribed connections.
+type resp3Conn struct {
+	net.Conn
+
+	onPush     func(msg PushMessage)
+	br         *bufio.Reader
+	translated bytes.Buffer
+	subscribed bool
+
+	// command tracking state of the written bytes
+	line       []byte
+	argsLeft   int
+	bulkLeft   int
+	commandArg []byte
+	commandSet bool
+	inTx       bool
+	tx         []resp3Shape
+	pending    []resp3PendingReply
+}
+
+func newRESP3Conn(netConn net.Conn, onPush func(msg PushMessage)) *resp3Conn {
+	return &resp3Conn{
+		Conn:   netConn,
+		onPush: onPush,
+		br:     bufio.NewReader(netConn),
+	}
+}
+
+// hello switches the connection to RESP3, authenticating it if a password is set
+func (c *resp3Conn) hello(username string, password string) error {
+	args := []string{"HELLO", "3"}
+	if password != "" {
+		if username == "" {
+			username = "default"
+		}
+
+		args = append(args, "AUTH", username, password)
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	_, err := c.Conn.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	reply, err := c.readValue()
+	if err != nil {
+		return err
+	}
+
+	if replyErr, ok := reply.(redis.Error); ok {
+		return replyErr
+	}
+
+	return nil
+}
+
+// Write tracks the written commands and sends them
+func (c *resp3Conn) Write(p []byte) (int, error) {
+	c.track(p)
+
+	return c.Conn.Write(p)
+}
+
+// Read returns the translated replies
+func (c *resp3Conn) Read(p []byte) (int, error) {
+	for c.translated.Len() == 0 {
+		err := c.translateNext()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return c.translated.Read(p)
+}
+
+// track parses the commands written by redigo (arrays of bulk strings) incrementally
+func (c *resp3Conn) track(p []byte) {
+	for len(p) > 0 {
+		if c.bulkLeft > 0 {
+			n := len(p)
+			if n > c.bulkLeft {
+				n = c.bulkLeft
+			}
+
+			if !c.commandSet {
+				c.commandArg = append(c.commandArg, p[:n]...)
+			}
+
+			c.bulkLeft -= n
+			p = p[n:]
+
+			if c.bulkLeft == 0 {
+				c.trackArg()
+			}
+
+			continue
+		}
+
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			c.line = append(c.line, p...)
+
+			return
+		}
+
+		line := append(c.line, p[:i+1]...)
+		c.line = c.line[:0]
+		p = p[i+1:]
+
+		if len(line) < 3 {
+			continue
+		}
+
+		n, err := strconv.Atoi(string(line[1 : len(line)-2]))
+		if err != nil {
+			continue
+		}
+
+		switch line[0] {
+		case '*':
+			c.argsLeft = n
+			c.commandArg = c.commandArg[:0]
+			c.commandSet = false
+		case '$':
+			// including the trailing \r\n
+			c.bulkLeft = n + 2
+		}
+	}
+}
+
+func (c *resp3Conn) trackArg() {
+	if !c.commandSet {
+		c.commandSet = true
+		c.commandArg = bytes.TrimSuffix(c.commandArg, []byte("\r\n"))
+	}
+
+	c.argsLeft--
+	if c.argsLeft > 0 {
+		return
+	}
+
+	commandName := strings.ToUpper(string(c.commandArg))
+	shape := resp3Shapes[commandName]
+
+	switch {
+	case resp3PubSubCommands[commandName]:
+		return
+	case commandName == "MULTI":
+		c.inTx = true
+		c.tx = nil
+	case commandName == "EXEC":
+		c.pending = append(c.pending, resp3PendingReply{tx: c.tx})
+		c.inTx = false
+		c.tx = nil
+
+		return
+	case commandName == "DISCARD":
+		c.inTx = false
+		c.tx = nil
+	case c.inTx:
+		c.tx = append(c.tx, shape)
+		shape = resp3ShapeDefault
+	}
+
+	c.pending = append(c.pending, resp3PendingReply{shape: shape})
+}
+
+// translateNext reads the next value and writes its RESP2 translation to the buffer,
+// push messages not belonging to a subscription are passed to onPush instead
+func (c *resp3Conn) translateNext() error {
+	value, err := c.readValue()
+	if err != nil {
+		return err
+	}
+
+	if push, ok := value.(resp3Push); ok {
+		msg := PushMessage{}
+		data := resp3Normalize([]interface{}(push)).([]interface{})
+		if len(data) > 0 {
+			msg.Kind, _ = redis.String(data[0], nil)
+			msg.Data = data[1:]
+		}
+
+		if !resp3PubSubKinds[msg.Kind] || (!c.subscribed && strings.HasSuffix(msg.Kind, "message")) {
+			if c.onPush != nil {
+				c.onPush(msg)
+			}
+
+			return nil
+		}
+
+		if len(msg.Data) >= 2 && strings.HasSuffix(msg.Kind, "subscribe") {
+			count, _ := redis.Int(msg.Data[len(msg.Data)-1], nil)
+			c.subscribed = count > 0
+		}
+
+		resp3Encode(&c.translated, data)
+
+		return nil
+	}
+
+	pending := resp3PendingReply{}
+	if len(c.pending) > 0 {
+		pending = c.pending[0]
+		c.pending = c.pending[1:]
+	}
+
+	if pending.tx != nil {
+		if replies, ok := value.([]interface{}); ok {
+			for i := range replies {
+				if i < len(pending.tx) {
+					replies[i] = c.reshape(replies[i], pending.tx[i])
+				}
+			}
+		}
+	} else {
+		value = c.reshape(value, pending.shape)
+	}
+
+	resp3Encode(&c.translated, resp3Normalize(value))
+
+	return nil
+}
+
+// reshape converts a reply to the shape of its RESP2 counterpart
+func (c *resp3Conn) reshape(value interface{}, shape resp3Shape) interface{} {
+	switch shape {
+	case resp3ShapeStreams:
+		if streams, ok := value.(resp3Map); ok {
+			pairs := make([]interface{}, 0, len(streams)/2)
+			for i := 0; i+1 < len(streams); i += 2 {
+				pairs = append(pairs, []interface{}{streams[i], streams[i+1]})
+			}
+
+			return pairs
+		}
+	case resp3ShapePairs:
+		if items, ok := value.([]interface{}); ok {
+			flat := make([]interface{}, 0, len(items)*2)
+			for _, item := range items {
+				pair, ok := item.([]interface{})
+				if !ok || len(pair) != 2 {
+					return value
+				}
+
+				flat = append(flat, pair...)
+			}
+
+			return flat
+		}
+	case resp3ShapeScript:
+		return resp3ScriptReply(value)
+	case resp3ShapePing:
+		if !c.subscribed {
+			return value
+		}
+
+		switch data := value.(type) {
+		case string:
+			return []interface{}{[]byte("pong"), []byte{}}
+		case []byte:
+			return []interface{}{[]byte("pong"), data}
+		}
+	}
+
+	return value
+}
+
+// resp3ScriptReply converts lua false to nil and true to 1 like RESP2
+func resp3ScriptReply(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+
+		return int64(1)
+	case []interface{}:
+		for i := range v {
+			v[i] = resp3ScriptReply(v[i])
+		}
+	}
+
+	return value
+}
+
+// resp3Normalize converts maps to flat lists and booleans to integers like RESP2
+func resp3Normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return int64(1)
+		}
+
+		return int64(0)
+	case resp3Map:
+		return resp3Normalize([]interface{}(v))
+	case []interface{}:
+		for i := range v {
+			v[i] = resp3Normalize(v[i])
+		}
+	}
+
+	return value
+}
+
+// resp3Encode writes a normalized value in RESP2
+func resp3Encode(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("$-1\r\n")
+	case string:
+		buf.WriteString("+" + v + "\r\n")
+	case redis.Error:
+		buf.WriteString("-" + strings.NewReplacer("\r", " ", "\n", " ").Replace(string(v)) + "\r\n")
+	case int64:
+		buf.WriteString(":" + strconv.FormatInt(v, 10) + "\r\n")
+	case []byte:
+		buf.WriteString("$" + strconv.Itoa(len(v)) + "\r\n")
+		buf.Write(v)
+		buf.WriteString("\r\n")
+	case []interface{}:
+		buf.WriteString("*" + strconv.Itoa(len(v)) + "\r\n")
+		for _, item := range v {
+			resp3Encode(buf, item)
+		}
+	}
+}
+
+// readValue reads a RESP3 value, attributes are skipped
+func (c *resp3Conn) readValue() (interface{}, error) {
+	line, err := c.br.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed resp3 line: %q", line)
+	}
+
+	kind := line[0]
+	payload := string(line[1 : len(line)-2])
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return redis.Error(payload), nil
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '_':
+		return nil, nil
+	case '#':
+		return payload == "t", nil
+	case ',', '(':
+		// doubles and big numbers are bulk strings in RESP2
+		return []byte(payload), nil
+	case '$', '!', '=':
+		n, err := strconv.Atoi(payload)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+
+		data := make([]byte, n+2)
+
+		_, err = io.ReadFull(c.br, data)
+		if err != nil {
+			return nil, err
+		}
+
+		data = data[:n]
+
+		switch kind {
+		case '!':
+			return redis.Error(data), nil
+		case '=':
+			// strip the format, e.g. "txt:"
+			if len(data) >= 4 {
+				data = data[4:]
+			}
+		}
+
+		return data, nil
+	case '*', '~', '%', '>', '|':
+		n, err := strconv.Atoi(payload)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+
+		if kind == '%' || kind == '|' {
+			n *= 2
+		}
+
+		items := make([]interface{}, n)
+		for i := range items {
+			items[i], err = c.readValue()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		switch kind {
+		case '%':
+			return resp3Map(items), nil
+		case '>':
+			return resp3Push(items), nil
+		case '|':
+			return c.readValue()
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("unexpected resp3 type %q", kind)
+}
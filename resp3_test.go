@@ -0,0 +1,172 @@
+package gousuredis
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNetConn replies with a fixed response and records the written commands
+type fakeNetConn struct {
+	net.Conn
+
+	response *strings.Reader
+	written  bytes.Buffer
+}
+
+func (c *fakeNetConn) Read(p []byte) (int, error) {
+	return c.response.Read(p)
+}
+
+func (c *fakeNetConn) Write(p []byte) (int, error) {
+	return c.written.Write(p)
+}
+
+func (c *fakeNetConn) Close() error {
+	return nil
+}
+
+func (c *fakeNetConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func newFakeRESP3Conn(response string, onPush func(msg PushMessage)) (redis.Conn, *fakeNetConn) {
+	netConn := &fakeNetConn{response: strings.NewReader(response)}
+
+	return redis.NewConn(newRESP3Conn(netConn, onPush), 0, 0), netConn
+}
+
+func TestRESP3Hello(t *testing.T) {
+	netConn := &fakeNetConn{response: strings.NewReader("%1\r\n+proto\r\n:3\r\n")}
+
+	err := newRESP3Conn(netConn, nil).hello("", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "*5\r\n$5\r\nHELLO\r\n$1\r\n3\r\n$4\r\nAUTH\r\n$7\r\ndefault\r\n$6\r\nsecret\r\n", netConn.written.String())
+
+	netConn = &fakeNetConn{response: strings.NewReader("-ERR unknown command 'HELLO'\r\n")}
+
+	err = newRESP3Conn(netConn, nil).hello("", "")
+	assert.EqualError(t, err, "ERR unknown command 'HELLO'")
+}
+
+func TestRESP3Types(t *testing.T) {
+	conn, _ := newFakeRESP3Conn(
+		"%2\r\n$1\r\na\r\n$1\r\n1\r\n$1\r\nb\r\n$1\r\n2\r\n"+
+			",1.5\r\n"+
+			"_\r\n"+
+			"#t\r\n"+
+			"=8\r\ntxt:info\r\n"+
+			"|1\r\n+key-popularity\r\n%0\r\n(12345678901234567890\r\n"+
+			"!9\r\nERR boom!\r\n",
+		nil,
+	)
+
+	hash, err := redis.StringMap(conn.Do("HGETALL", "key"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, hash)
+
+	score, err := redis.Float64(conn.Do("ZSCORE", "key", "member"))
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, score)
+
+	_, err = redis.Bytes(conn.Do("GET", "key"))
+	assert.Equal(t, redis.ErrNil, err)
+
+	flag, err := redis.Int(conn.Do("CLIENT", "NO-EVICT", "ON"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, flag)
+
+	info, err := redis.String(conn.Do("INFO"))
+	require.NoError(t, err)
+	assert.Equal(t, "info", info)
+
+	big, err := redis.String(conn.Do("GET", "big"))
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901234567890", big)
+
+	_, err = conn.Do("BOOM")
+	assert.EqualError(t, err, "ERR boom!")
+}
+
+func TestRESP3Shapes(t *testing.T) {
+	conn, _ := newFakeRESP3Conn(
+		"%1\r\n$6\r\nstream\r\n*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\nf\r\n$1\r\nv\r\n"+
+			"*2\r\n*2\r\n$1\r\na\r\n,1\r\n*2\r\n$1\r\nb\r\n,2\r\n"+
+			"#f\r\n"+
+			"+OK\r\n+QUEUED\r\n*1\r\n*1\r\n*2\r\n$1\r\na\r\n,1\r\n",
+		nil,
+	)
+
+	result, err := redis.Values(conn.Do("XREAD", "STREAMS", "stream", "0"))
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	events, err := redis.Values(result[0], nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("stream"), events[0])
+
+	members, err := zMembersReply(conn.Do("ZRANGE", "key", 0, -1, "WITHSCORES"))
+	require.NoError(t, err)
+	assert.Equal(t, []ZMember{{Member: []byte("a"), Score: 1}, {Member: []byte("b"), Score: 2}}, members)
+
+	reply, err := conn.Do("EVAL", "return false", 0)
+	require.NoError(t, err)
+	assert.Nil(t, reply)
+
+	conn.Send("MULTI")
+	conn.Send("ZPOPMIN", "key", 1)
+	replies, err := redis.Values(conn.Do("EXEC"))
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+
+	members, err = zMembersReply(replies[0], nil)
+	require.NoError(t, err)
+	assert.Equal(t, []ZMember{{Member: []byte("a"), Score: 1}}, members)
+}
+
+func TestRESP3Push(t *testing.T) {
+	pushed := []PushMessage{}
+
+	conn, _ := newFakeRESP3Conn(
+		">2\r\n$10\r\ninvalidate\r\n*1\r\n$3\r\nkey\r\n$5\r\nvalue\r\n",
+		func(msg PushMessage) {
+			pushed = append(pushed, msg)
+		},
+	)
+
+	value, err := redis.String(conn.Do("GET", "key"))
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	require.Len(t, pushed, 1)
+	assert.Equal(t, "invalidate", pushed[0].Kind)
+	assert.Equal(t, []interface{}{[]interface{}{[]byte("key")}}, pushed[0].Data)
+}
+
+func TestRESP3PubSub(t *testing.T) {
+	conn, _ := newFakeRESP3Conn(
+		">3\r\n$9\r\nsubscribe\r\n$7\r\nchannel\r\n:1\r\n"+
+			">3\r\n$7\r\nmessage\r\n$7\r\nchannel\r\n$4\r\ndata\r\n"+
+			"$0\r\n\r\n",
+		nil,
+	)
+
+	psc := redis.PubSubConn{Conn: conn}
+
+	err := psc.Subscribe("channel")
+	require.NoError(t, err)
+
+	assert.Equal(t, redis.Subscription{Kind: "subscribe", Channel: "channel", Count: 1}, psc.Receive())
+	assert.Equal(t, redis.Message{Channel: "channel", Data: []byte("data")}, psc.Receive())
+
+	err = psc.Ping("")
+	require.NoError(t, err)
+
+	assert.Equal(t, redis.Pong{Data: ""}, psc.Receive())
+}
@@ -0,0 +1,132 @@
+package gousuredis
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// retryableCommands can be sent again without changing the result if the first attempt
+// failed after reaching the server
+//
+// Writes replying with counts (e.g. DEL, SADD, HSET) are not retried, as a retry after a
+// successful first attempt would return a wrong count.
+var retryableCommands = map[string]bool{}
+
+// statefulCommands pin the connection, as following commands depend on them
+var statefulCommands = map[string]bool{
+	"WATCH": true, "MULTI": true, "SELECT": true, "READONLY": true,
+	"SUBSCRIBE": true, "PSUBSCRIBE": true, "SSUBSCRIBE": true, "MONITOR": true,
+}
+
+func init() {
+	for _, commandName := range []string{
+		"PING", "INFO", "DBSIZE", "TIME", "PUBSUB", "MEMORY",
+		"GET", "MGET", "STRLEN", "GETRANGE", "GETBIT", "BITCOUNT", "BITPOS",
+		"EXISTS", "TYPE", "TTL", "PTTL", "SCAN", "DUMP", "OBJECT",
+		"HGET", "HMGET", "HGETALL", "HKEYS", "HVALS", "HLEN", "HEXISTS", "HSCAN",
+		"LRANGE", "LINDEX", "LLEN",
+		"SMEMBERS", "SISMEMBER", "SCARD", "SRANDMEMBER", "SINTER", "SUNION", "SDIFF", "SSCAN",
+		"ZRANGE", "ZRANGEBYSCORE", "ZREVRANGE", "ZSCORE", "ZRANK", "ZREVRANK", "ZCARD", "ZCOUNT", "ZSCAN",
+		"XRANGE", "XREVRANGE", "XLEN", "XPENDING", "XINFO",
+		"PFCOUNT", "GEOPOS", "GEODIST", "GEOHASH", "GEOSEARCH",
+		"SET", "MSET", "HMSET", "EXPIRE", "PEXPIRE", "EXPIREAT", "PEXPIREAT",
+	} {
+		retryableCommands[commandName] = true
+	}
+}
+
+// isRetryable checks if a command can be retried
+//
+// SET is not retryable with NX, XX or GET and EXPIRE not with NX, XX, GT or LT, as a retry
+// after a successful first attempt would return a different result.
+func isRetryable(commandName string, args []interface{}) bool {
+	commandName = strings.ToUpper(commandName)
+
+	if !retryableCommands[commandName] {
+		return false
+	}
+
+	switch commandName {
+	case "SET":
+		for i := 2; i < len(args); i++ {
+			switch strings.ToUpper(argString(args[i])) {
+			case "NX", "XX", "GET":
+				return false
+			}
+		}
+	case "EXPIRE", "PEXPIRE", "EXPIREAT", "PEXPIREAT":
+		for i := 2; i < len(args); i++ {
+			switch strings.ToUpper(argString(args[i])) {
+			case "NX", "XX", "GT", "LT":
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// retryBackoff returns the time to wait before the attempt (starting at 1 for the first retry)
+func (s *Service) retryBackoff(attempt int) time.Duration {
+	backoff := s.options.RetryBackoff << uint(attempt-1)
+	if backoff > s.options.RetryMaxBackoff || backoff <= 0 {
+		backoff = s.options.RetryMaxBackoff
+	}
+
+	jitter := s.options.RetryJitter
+	if jitter > 1 {
+		jitter = 1
+	}
+
+	if jitter > 0 {
+		backoff -= time.Duration(rand.Float64() * jitter * float64(backoff))
+	}
+
+	return backoff
+}
+
+// retry runs the command and retries it on a new connection if it failed due to a
+// connection error, as configured via redis_retry_max_attempts
+func (c *serviceConn) retry(commandName string, args []interface{}, fn func() (interface{}, error)) (interface{}, error) {
+	if statefulCommands[strings.ToUpper(commandName)] {
+		c.pinned = true
+	}
+
+	reply, err := fn()
+
+	for attempt := 1; attempt < c.service.options.RetryMaxAttempts; attempt++ {
+		if err == nil || c.pinned || !IsConnError(err) || !isRetryable(commandName, args) {
+			break
+		}
+
+		select {
+		case <-c.service.ctx.Done():
+			return reply, err
+		case <-time.After(c.service.retryBackoff(attempt)):
+		}
+
+		var delegate redis.Conn
+		var openErr error
+
+		if c.readOnly {
+			delegate, openErr = c.service.openReadDelegate()
+		} else {
+			delegate, openErr = c.service.openDelegate(c.useRetry)
+		}
+		if openErr != nil {
+			err = openErr
+
+			continue
+		}
+
+		c.delegate.Close()
+		c.delegate = delegate
+
+		reply, err = fn()
+	}
+
+	return reply, err
+}
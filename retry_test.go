@@ -0,0 +1,42 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryable("get", []interface{}{"key"}))
+	assert.True(t, isRetryable("SET", []interface{}{"key", "value", "PX", 100}))
+	assert.False(t, isRetryable("SET", []interface{}{"key", "value", "NX", "PX", 100}))
+	assert.False(t, isRetryable("INCR", []interface{}{"key"}))
+	assert.False(t, isRetryable("LPUSH", []interface{}{"key", "value"}))
+	assert.False(t, isRetryable("DEL", []interface{}{"key"}))
+	assert.False(t, isRetryable("HSET", []interface{}{"key", "field", "value"}))
+	assert.True(t, isRetryable("EXPIRE", []interface{}{"key", 10}))
+	assert.False(t, isRetryable("EXPIRE", []interface{}{"key", 10, "nx"}))
+	assert.False(t, isRetryable("PEXPIREAT", []interface{}{"key", 1000, "GT"}))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	options := DefaultOptions()
+	options.RetryBackoff = 100 * time.Millisecond
+	options.RetryMaxBackoff = 300 * time.Millisecond
+	options.RetryJitter = 0
+
+	service := NewServiceWithOptions("redis", options)
+
+	assert.Equal(t, 100*time.Millisecond, service.retryBackoff(1))
+	assert.Equal(t, 200*time.Millisecond, service.retryBackoff(2))
+	assert.Equal(t, 300*time.Millisecond, service.retryBackoff(3))
+	assert.Equal(t, 300*time.Millisecond, service.retryBackoff(100))
+
+	options.RetryJitter = 0.5
+
+	for i := 0; i < 10; i++ {
+		backoff := service.retryBackoff(1)
+		assert.True(t, backoff > 50*time.Millisecond && backoff <= 100*time.Millisecond)
+	}
+}
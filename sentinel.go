@@ -0,0 +1,89 @@
+package gousuredis
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// resolveSentinelMaster asks the sentinels for the address of the current master
+func resolveSentinelMaster(addrs []string, masterName string, password string) (string, error) {
+	var lastErr error
+
+	dialOpts := []redis.DialOption{
+		redis.DialConnectTimeout(1 * time.Second),
+		redis.DialReadTimeout(1 * time.Second),
+		redis.DialWriteTimeout(1 * time.Second),
+	}
+
+	if password != "" {
+		dialOpts = append(dialOpts, redis.DialPassword(password))
+	}
+
+	for _, addr := range addrs {
+		conn, err := redis.Dial("tcp", addr, dialOpts...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		result, err := redis.Strings(conn.Do("SENTINEL", "get-master-addr-by-name", masterName))
+		conn.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if len(result) != 2 {
+			lastErr = fmt.Errorf("invalid master address %v", result)
+			continue
+		}
+
+		return net.JoinHostPort(result[0], result[1]), nil
+	}
+
+	return "", fmt.Errorf("can't resolve master '%s' via sentinels: %s", masterName, lastErr)
+}
+
+// createSentinelPool creates a pool which resolves the current master on every dial
+// and drops connections to instances which are no longer master after a failover
+func (s *Service) createSentinelPool(addrs []string, masterName string, opts ...redis.DialOption) (*redis.Pool, error) {
+	pool := s.newPool("", opts...)
+
+	pool.Dial = func() (redis.Conn, error) {
+		addr, err := resolveSentinelMaster(addrs, masterName, s.options.SentinelPassword)
+		if err != nil {
+			return nil, err
+		}
+
+		return redis.Dial("tcp", addr, opts...)
+	}
+
+	pool.TestOnBorrow = func(c redis.Conn, t time.Time) error {
+		role, err := redis.Values(c.Do("ROLE"))
+		if err != nil {
+			return err
+		}
+
+		if len(role) < 1 {
+			return fmt.Errorf("invalid role reply")
+		}
+
+		name, err := redis.String(role[0], nil)
+		if err != nil {
+			return err
+		}
+
+		if name != "master" {
+			return fmt.Errorf("instance is no longer master but %s", name)
+		}
+
+		return nil
+	}
+
+	s.watchPool(masterName, pool)
+
+	return pool, nil
+}
@@ -1,7 +1,12 @@
 package gousuredis
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-redsync/redsync/v4"
@@ -9,26 +14,34 @@ import (
 	"github.com/gomodule/redigo/redis"
 	"github.com/indece-official/go-gousu"
 	"github.com/mna/redisc"
-	"github.com/namsral/flag"
 )
 
 // ServiceName defines the name of redis service used for dependency injection
 const ServiceName = "redis"
 
-var (
-	redisHost        = flag.String("redis_host", "127.0.0.1", "Redis host")
-	redisPort        = flag.Int("redis_port", 6379, "Redis port")
-	redisUsername    = flag.String("redis_username", "", "Redis username")
-	redisPassword    = flag.String("redis_password", "", "Redis password")
-	redisMaxIdle     = flag.Int("redis_max_idle", 3, "Redis maximum idle connections")
-	redisMaxActive   = flag.Int("redis_max_active", 50, "Redis maximum active connections")
-	redisIdleTimeout = flag.Int("redis_idle_timeout", 240, "Redis idle connection timeout")
-	redisClusterMode = flag.Bool("redis_cluster", false, "Redis cluster mode")
-)
-
 // ErrNil is the error returned if no matching data was found
 var ErrNil = redis.ErrNil
 
+const (
+	// blockSliceDuration is the maximum time a single blocking command waits
+	// before the context gets checked again
+	blockSliceDuration = 1 * time.Second
+	// blockReadTimeoutMargin is added to the timeout of blocking commands to
+	// get the read timeout of the connection
+	blockReadTimeoutMargin = 1 * time.Second
+)
+
+// blockTimeoutArg formats a duration as timeout in seconds for blocking commands
+//
+// Durations are rounded up to at least one millisecond, as 0 would block forever
+func blockTimeoutArg(timeout time.Duration) string {
+	if timeout < time.Millisecond {
+		timeout = time.Millisecond
+	}
+
+	return strconv.FormatFloat(timeout.Seconds(), 'f', 3, 64)
+}
+
 // IService defines the interface of the redis service
 type IService interface {
 	gousu.IService
@@ -39,7 +52,7 @@ type IService interface {
 	Set(key string, data []byte) error
 	SetNXPX(key string, data []byte, timeoutMS int) error
 	SetPX(key string, data []byte, timeoutMS int) error
-	Del(key string) error
+	Del(keys ...string) error
 	Exists(key string) (bool, error)
 	Scan(pattern string, cursor int) (int, []string, error)
 	RPush(key string, data []byte) (int, error)
@@ -58,32 +71,281 @@ type IService interface {
 	LIndex(key string, position int) ([]byte, error)
 	LLen(key string) (int, error)
 	Subscribe(channels []string) (chan Message, ISubscription, error)
-	Publish(channel string, data []byte) error
+	Publish(channel string, data []byte) (int, error)
 	XAdd(key string, data map[string]string) (string, error)
 	XGroupCreate(groupName string, key string, offset XGroupCreateOffset, mkStream bool, ignoreBusy bool) error
 	XReadGroup(groupName string, consumerName string, key string, timeout time.Duration, streamID XReadGroupStreamID) (*XEvent, error)
 	XAck(groupName string, key string, id string) (int, error)
+	OnExpire(pattern string, fn func(key string))
+	ExistsMulti(keys ...string) (int, error)
+	WatchKey(ctx context.Context, key string) (<-chan KeyEvent, error)
+	MonitorQueue(config QueueMonitorConfig) error
+	GetQueueDepths() []QueueDepth
+	ScriptKill() error
+	BLPopCtx(ctx context.Context, key string, timeout time.Duration) ([]byte, error)
+	HGetAllMulti(keys []string) (map[string]map[string][]byte, error)
+	ZAdd(key string, score float64, member []byte) (int, error)
+	ZRange(key string, start int, stop int) ([][]byte, error)
+	ZRangeByScore(key string, min string, max string) ([]ZMember, error)
+	ZRem(key string, member []byte) (int, error)
+	ZIncrBy(key string, increment float64, member []byte) (float64, error)
+	ZScore(key string, member []byte) (float64, error)
+	ZRank(key string, member []byte) (int, error)
+	ZCard(key string) (int, error)
+	ZPopMin(key string, count int) ([]ZMember, error)
+	SAdd(key string, members ...[]byte) (int, error)
+	SRem(key string, members ...[]byte) (int, error)
+	SMembers(key string) ([][]byte, error)
+	SIsMember(key string, member []byte) (bool, error)
+	SCard(key string) (int, error)
+	SPop(key string, count int) ([][]byte, error)
+	SRandMember(key string, count int) ([][]byte, error)
+	SInter(keys ...string) ([][]byte, error)
+	SUnion(keys ...string) ([][]byte, error)
+	SDiff(keys ...string) ([][]byte, error)
+	XRange(key string, start string, end string, count int) ([]*XEvent, error)
+	XRead(key string, timeout time.Duration, lastID string, count int) ([]*XEvent, error)
+	XLen(key string) (int, error)
+	ConsumeGroup(groupName string, consumerName string, key string) (chan XMessage, IConsumerGroup, error)
+	WithContext(ctx context.Context) IService
+	Pipeline() IPipeline
+	Tx(watchKeys []string, fn func(tx ITx) error) ([]interface{}, error)
+	Cached(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error)
+	GetJSON(key string, dest interface{}) error
+	SetJSON(key string, v interface{}) error
+	SetJSONPX(key string, v interface{}, timeoutMS int) error
+	SetJSONNXPX(key string, v interface{}, timeoutMS int) error
+	HGetJSON(key string, field string, dest interface{}) error
+	HSetJSON(key string, field string, v interface{}) error
+	SSubscribe(channels []string) (chan Message, ISubscription, error)
+	SPublish(channel string, data []byte) (int, error)
+	PubSubChannels(pattern string) ([]string, error)
+	PubSubNumSub(channels ...string) (map[string]int, error)
+	HGetAll(key string) (map[string][]byte, error)
+	HMGet(key string, fields ...string) ([][]byte, error)
+	HMSet(key string, values map[string][]byte) error
+	HSetNX(key string, field string, data []byte) (bool, error)
+	HIncrBy(key string, field string, increment int) (int, error)
+	HIncrByFloat(key string, field string, increment float64) (float64, error)
+	Incr(key string) (int, error)
+	Decr(key string) (int, error)
+	IncrBy(key string, increment int) (int, error)
+	DecrBy(key string, decrement int) (int, error)
+	IncrByFloat(key string, increment float64) (float64, error)
+	Expire(key string, seconds int) (bool, error)
+	PExpire(key string, timeoutMS int) (bool, error)
+	ExpireAt(key string, at time.Time) (bool, error)
+	TTL(key string) (int, error)
+	PTTL(key string) (int, error)
+	Persist(key string) (bool, error)
+	Type(key string) (string, error)
+	ObjectEncoding(key string) (string, error)
+	ObjectIdleTime(key string) (int, error)
+	ObjectFreq(key string) (int, error)
+	MGet(keys ...string) ([][]byte, error)
+	MSet(values map[string][]byte) error
+	MSetNX(values map[string][]byte) (bool, error)
+	GetDel(key string) ([]byte, error)
+	GetEx(key string, timeoutMS int) ([]byte, error)
+	GetSet(key string, data []byte) ([]byte, error)
+	Append(key string, data []byte) (int, error)
+	StrLen(key string) (int, error)
+	GetRange(key string, start int, end int) ([]byte, error)
+	SetRange(key string, offset int, data []byte) (int, error)
+	SetBit(key string, offset int, value bool) (bool, error)
+	GetBit(key string, offset int) (bool, error)
+	BitCount(key string, start int, end int) (int, error)
+	BitPos(key string, value bool, start int) (int, error)
+	BitOp(operation BitOperation, destKey string, keys ...string) (int, error)
+	BitField(key string, args ...interface{}) ([]int64, error)
+	PFAdd(key string, elements ...[]byte) (bool, error)
+	PFCount(keys ...string) (int, error)
+	PFMerge(destKey string, sourceKeys ...string) error
+	GeoAdd(key string, locations ...GeoLocation) (int, error)
+	GeoPos(key string, members ...string) ([]*GeoPosition, error)
+	GeoDist(key string, member1 string, member2 string, unit GeoUnit) (float64, error)
+	GeoSearch(key string, query GeoSearchQuery) ([]GeoSearchResult, error)
+	GeoRadius(key string, longitude float64, latitude float64, radius float64, unit GeoUnit) ([]GeoSearchResult, error)
+	LTrim(key string, start int, stop int) error
+	LSet(key string, index int, data []byte) error
+	LInsert(key string, before bool, pivot []byte, data []byte) (int, error)
+	LMove(source string, destination string, from ListDirection, to ListDirection) ([]byte, error)
+	BLMove(source string, destination string, from ListDirection, to ListDirection, timeout time.Duration) ([]byte, error)
+	BLPopMulti(keys []string, timeout time.Duration) (string, []byte, error)
+	BRPop(key string, timeout int) ([]byte, error)
+	BRPopMulti(keys []string, timeout time.Duration) (string, []byte, error)
+	Unlink(keys ...string) (int, error)
+	DelPattern(pattern string) (int, error)
+	ScanAll(pattern string, options ScanOptions, fn func(keys []string) error) error
+	HScanMatch(key string, cursor int, match string, count int) (int, map[string][]byte, error)
+	HScanAll(key string, match string) (map[string][]byte, error)
+	SScan(key string, cursor int, match string, count int) (int, [][]byte, error)
+	SScanAll(key string, match string) ([][]byte, error)
+	ZScan(key string, cursor int, match string, count int) (int, []ZMember, error)
+	ZScanAll(key string, match string) ([]ZMember, error)
+	Rename(key string, newKey string) error
+	RenameNX(key string, newKey string) (bool, error)
+	Copy(source string, destination string, replace bool) (bool, error)
+	CopyToDB(source string, destination string, db int, replace bool) (bool, error)
+	Dump(key string) ([]byte, error)
+	Restore(key string, ttlMS int, payload []byte, replace bool) error
+	Sort(key string, options SortOptions) ([][]byte, error)
+	DBSize() (int, error)
+	FlushDB() error
+	FlushAll() error
+	Info(section ...string) (*Info, error)
+	ClientList() ([]ClientInfo, error)
+	ClientKill(filter ClientKillFilter) (int, error)
+	Stats() Stats
+	AddHook(hook Hook)
+	HealthDetails() (*HealthDetails, error)
+	Ready() bool
+	Wait(numReplicas int, timeoutMS int) (int, error)
+	SetWithReplication(key string, data []byte, numReplicas int, timeoutMS int) (int, error)
+	SetPXWithReplication(key string, data []byte, expireMS int, numReplicas int, timeoutMS int) (int, error)
+	BloomAvailable() (bool, error)
+	BFReserve(key string, errorRate float64, capacity int) error
+	BFAdd(key string, item []byte) (bool, error)
+	BFMAdd(key string, items ...[]byte) ([]bool, error)
+	BFExists(key string, item []byte) (bool, error)
+	BFMExists(key string, items ...[]byte) ([]bool, error)
+	CFReserve(key string, capacity int) error
+	CFAdd(key string, item []byte) error
+	CFAddNX(key string, item []byte) (bool, error)
+	CFExists(key string, item []byte) (bool, error)
+	CFDel(key string, item []byte) (bool, error)
+	CFCount(key string, item []byte) (int, error)
+	WithConn(fn func(conn redis.Conn) error) error
+	Do(commandName string, args ...interface{}) (interface{}, error)
+	DoCtx(ctx context.Context, commandName string, args ...interface{}) (interface{}, error)
+	HSetMulti(key string, values map[string][]byte) (int, error)
+	HSetStruct(key string, v interface{}) error
+	HGetAllStruct(key string, dest interface{}) error
+	XPending(groupName string, key string, start string, end string, count int, minIdle time.Duration) ([]XPendingEntry, error)
+	XClaim(groupName string, key string, consumerName string, minIdle time.Duration, ids ...string) ([]*XEvent, error)
+	XAutoClaim(groupName string, key string, consumerName string, minIdle time.Duration, start string, count int) (string, []*XEvent, error)
+	OnPush(fn func(msg PushMessage))
+	OnConnect(fn func(addr string))
+	OnDisconnect(fn func(addr string, err error))
+	OnReconnect(fn func(addr string))
+	MemoryUsage(key string, samples int) (int64, error)
+	MemoryStats() (*MemoryStats, error)
 }
 
 // Service provides a service for basic redis client functionality
 //
 // Used flags:
-//   * redis_host Hostname of redis service
-//   * redis_port Port of redis service
+//   - redis_url URL of redis service, overrides host, port and credentials
+//   - redis_host Hostname of redis service
+//   - redis_port Port of redis service
+//   - redis_username Username for redis ACL authentication
+//   - redis_password Password for redis authentication
+//   - redis_db Index of the logical database (not supported in cluster mode)
+//   - redis_dns_discovery Resolves redis_host via DNS (a or srv) and re-resolves it on dial failures
+//   - redis_start_background Connects in the background if redis is unreachable on Start
+//   - redis_max_conn_lifetime Closes connections after the lifetime in seconds
+//   - redis_conn_recycle_interval Pings idle connections periodically and replaces dead ones
+//   - redis_cluster Enables cluster mode
+//   - redis_cluster_nodes Seed nodes of the redis cluster
+//   - redis_sentinel_addrs Addresses of the redis sentinels
+//   - redis_sentinel_master Name of the master monitored by the sentinels
+//   - redis_tls Enables TLS, configured via the redis_tls_* flags
+//   - redis_key_prefix Prefix prepended to all keys
+//   - redis_client_name Name of all connections shown by CLIENT LIST
+//   - redis_resp3 Negotiates RESP3 on all connections, see OnPush
+//   - redis_replica_addrs Addresses of read replicas
+//   - redis_read_preference Sends read-only commands to replicas (master, replica or replica_preferred)
+//   - redis_retry_max_attempts Retries idempotent commands failing due to connection errors
+//   - redis_circuit_breaker_threshold Fails commands fast after repeated connection errors
+//   - redis_health_max_latency_ms Marks the service unhealthy if the PING latency is exceeded,
+//     see HealthDetails for further thresholds
+//   - redis_stop_timeout_ms Time Stop waits for subscriptions and consumers to finish
+//   - redis_stream_reclaim_min_idle_ms Redelivers pending stream events of crashed consumers
+//   - redis_allow_flush Enables FlushDB and FlushAll
+//   - redis_slow_threshold_ms Logs commands taking longer than the threshold
+//   - redis_subscribe_reconnect Enables automatic reconnects of subscriptions
+//   - redis_encryption_keys Keys for the encryption of stored values
 type Service struct {
+	*serviceState
+
+	ctx context.Context
+}
+
+// serviceState is shared between a Service and all its context-bound copies
+type serviceState struct {
+	// busySince and the counters are accessed atomically and must stay 64-bit aligned
+	busySince    int64
+	commandCount uint64
+	errorCount   uint64
+	// rejectedConnections is the last value seen by HealthDetails
+	rejectedConnections int64
+	replicaNext         uint32
+	ready               uint32
+	started             uint32
+	bloomSupport        uint32
+
+	name          string
+	flags         *flagSet
+	options       *Options
 	log           *gousu.Log
 	pool          *redis.Pool
+	replicaPools  []*redis.Pool
 	cluster       *redisc.Cluster
 	redsyncClient *redsync.Redsync
+	done          chan struct{}
+	stopOnce      sync.Once
+	latencies     *latencyTracker
+	cipher        *valueCipher
+	breaker       *circuitBreaker
+
+	expireMutex     sync.Mutex
+	expireCallbacks []expireCallback
+	expireListening bool
+
+	queueMonitorMutex   sync.Mutex
+	monitoredQueues     []*monitoredQueue
+	queueMonitorRunning bool
+
+	cacheGroup cacheGroup
+
+	connEventsMutex     sync.Mutex
+	connectCallbacks    []func(addr string)
+	disconnectCallbacks []func(addr string, err error)
+	reconnectCallbacks  []func(addr string)
+
+	pushMutex     sync.Mutex
+	pushCallbacks []func(msg PushMessage)
+
+	hooksMutex sync.RWMutex
+	hooks      []Hook
+
+	poolsMutex sync.Mutex
+	pools      map[string]*redis.Pool
+
+	workersMutex sync.Mutex
+	workersGroup sync.WaitGroup
+	workers      map[worker]struct{}
 }
 
 var _ IService = (*Service)(nil)
 
 func (s *Service) createPool(addr string, opts ...redis.DialOption) (*redis.Pool, error) {
-	return &redis.Pool{
-		MaxIdle:     *redisMaxIdle,
-		MaxActive:   *redisMaxActive,
-		IdleTimeout: time.Duration(*redisIdleTimeout) * time.Second,
+	pool := s.newPool(addr, opts...)
+
+	s.watchPool(addr, pool)
+
+	return pool, nil
+}
+
+// newPool creates a pool dialing addr, callers replacing Dial or TestOnBorrow must
+// call watchPool afterwards
+func (s *Service) newPool(addr string, opts ...redis.DialOption) *redis.Pool {
+	pool := &redis.Pool{
+		MaxIdle:         s.options.MaxIdle,
+		MaxActive:       s.options.MaxActive,
+		IdleTimeout:     s.options.IdleTimeout,
+		Wait:            s.options.Wait,
+		MaxConnLifetime: s.options.MaxConnLifetime,
 		Dial: func() (redis.Conn, error) {
 			return redis.Dial("tcp", addr, opts...)
 		},
@@ -91,12 +353,106 @@ func (s *Service) createPool(addr string, opts ...redis.DialOption) (*redis.Pool
 			_, err := c.Do("PING")
 			return err
 		},
-	}, nil
+	}
+
+	s.trackPool(addr, pool)
+
+	return pool
+}
+
+// WithContext returns a copy of the service which uses the context for all commands
+//
+// Commands fail if the context is done before they are sent, a deadline of the
+// context is used as read timeout. The copy shares the connections and all other
+// state with the service.
+func (s *Service) WithContext(ctx context.Context) IService {
+	return &Service{
+		serviceState: s.serviceState,
+		ctx:          ctx,
+	}
+}
+
+// withoutContext returns a copy of the service not bound to any context,
+// used for background workers outliving the current context
+func (s *Service) withoutContext() *Service {
+	return &Service{
+		serviceState: s.serviceState,
+		ctx:          context.Background(),
+	}
+}
+
+// clusterNodes returns the seed nodes of the redis cluster
+func (s *Service) clusterNodes() []string {
+	if len(s.options.ClusterNodes) > 0 {
+		return s.options.ClusterNodes
+	}
+
+	return []string{fmt.Sprintf("%s:%d", s.options.Host, s.options.Port)}
+}
+
+// eachNode calls fn with a connection to each master node in cluster mode or
+// with a single connection else
+func (s *Service) eachNode(fn func(conn redis.Conn) error) error {
+	if s.cluster == nil {
+		conn, err := s.openConn(false)
+		if err != nil {
+			return fmt.Errorf("can't connect to redis: %w", err)
+		}
+		defer conn.Close()
+
+		return fn(conn)
+	}
+
+	err := s.ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	err = s.cluster.Refresh()
+	if err != nil {
+		return fmt.Errorf("can't load cluster nodes: %s", err)
+	}
+
+	return s.cluster.EachNode(false, func(addr string, conn redis.Conn) error {
+		return fn(&serviceConn{service: s, delegate: conn, pinned: true})
+	})
+}
+
+// createURLPool creates a pool dialing a redis url
+func (s *Service) createURLPool(rawURL string, opts ...redis.DialOption) (*redis.Pool, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %s", err)
+	}
+
+	pool := s.newPool("", opts...)
+
+	pool.Dial = func() (redis.Conn, error) {
+		return redis.DialURL(rawURL, opts...)
+	}
+
+	s.watchPool(parsedURL.Host, pool)
+
+	return pool, nil
+}
+
+// redactURL removes the password from an url for logging
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "<invalid url>"
+	}
+
+	if _, hasPassword := parsedURL.User.Password(); hasPassword {
+		parsedURL.User = url.UserPassword(parsedURL.User.Username(), "xxxxx")
+	}
+
+	return parsedURL.String()
 }
 
-// Name returns the name of redis service from ServiceName
+// Name returns the name of redis service (ServiceName if created via NewService)
 func (s *Service) Name() string {
-	return ServiceName
+	return s.name
 }
 
 // Start connects to the redis pool
@@ -104,32 +460,114 @@ func (s *Service) Start() error {
 	var err error
 	var redsyncPool redsyncredis.Pool
 
+	if s.options == nil {
+		s.options = s.flags.options()
+	}
+
 	dialOpts := []redis.DialOption{}
 
-	dialOpts = append(dialOpts, redis.DialConnectTimeout(5*time.Second))
+	dialOpts = append(
+		dialOpts,
+		redis.DialConnectTimeout(s.options.ConnectTimeout),
+		redis.DialReadTimeout(s.options.ReadTimeout),
+		redis.DialWriteTimeout(s.options.WriteTimeout),
+	)
+
+	if s.options.DB != 0 {
+		if s.options.ClusterMode {
+			return fmt.Errorf("redis cluster only supports database 0")
+		}
+
+		dialOpts = append(dialOpts, redis.DialDatabase(s.options.DB))
+	}
+
+	clientName := s.clientName()
+	if clientName != "" {
+		dialOpts = append(dialOpts, redis.DialClientName(clientName))
+	}
+
+	if s.options.Username != "" {
+		dialOpts = append(dialOpts, redis.DialUsername(s.options.Username))
+	}
+
+	if s.options.Password != "" {
+		dialOpts = append(dialOpts, redis.DialPassword(s.options.Password))
+	}
+
+	if s.options.TLS {
+		tlsConfig, err := s.loadTLSConfig()
+		if err != nil {
+			return fmt.Errorf("can't load tls config: %s", err)
+		}
+
+		dialOpts = append(
+			dialOpts,
+			redis.DialUseTLS(true),
+			redis.DialTLSConfig(tlsConfig),
+			redis.DialTLSSkipVerify(s.options.TLSSkipVerify),
+		)
+	}
+
+	if s.options.RESP3 {
+		resp3DialOpt, err := s.resp3DialOption()
+		if err != nil {
+			return err
+		}
 
-	if *redisUsername != "" {
-		dialOpts = append(dialOpts, redis.DialUsername(*redisUsername))
+		// the tls handshake is done by the resp3 dial option
+		dialOpts = append(dialOpts, resp3DialOpt, redis.DialUseTLS(false))
 	}
 
-	if *redisPassword != "" {
-		dialOpts = append(dialOpts, redis.DialPassword(*redisPassword))
+	s.initHooks()
+
+	s.cipher, err = newValueCipher(s.options)
+	if err != nil {
+		return fmt.Errorf("can't load encryption keys: %s", err)
 	}
 
-	if *redisClusterMode {
-		s.log.Infof("Connecting to redis cluster on %s:%d ...", *redisHost, *redisPort)
+	if s.options.ClusterMode {
+		startupNodes := s.clusterNodes()
+
+		s.log.Infof("Connecting to redis cluster on %s ...", strings.Join(startupNodes, ", "))
 
 		s.cluster = &redisc.Cluster{
-			StartupNodes: []string{fmt.Sprintf("%s:%d", *redisHost, *redisPort)},
+			StartupNodes: startupNodes,
 			DialOptions:  dialOpts,
 			CreatePool:   s.createPool,
 		}
 
 		redsyncPool = newRedsyncPoolFromCluster(s.cluster)
+	} else if len(s.options.SentinelAddrs) > 0 {
+		s.log.Infof("Connecting to redis master %s via sentinels %s ...", s.options.SentinelMaster, strings.Join(s.options.SentinelAddrs, ", "))
+
+		s.pool, err = s.createSentinelPool(s.options.SentinelAddrs, s.options.SentinelMaster, dialOpts...)
+		if err != nil {
+			return err
+		}
+
+		redsyncPool = newRedsyncPoolFromPool(s.pool)
+	} else if s.options.URL != "" {
+		s.log.Infof("Connecting to redis on %s ...", redactURL(s.options.URL))
+
+		s.pool, err = s.createURLPool(s.options.URL, dialOpts...)
+		if err != nil {
+			return err
+		}
+
+		redsyncPool = newRedsyncPoolFromPool(s.pool)
+	} else if s.options.DNSDiscovery != DNSDiscoveryNone {
+		s.log.Infof("Connecting to redis on %s discovered via dns ...", s.options.Host)
+
+		s.pool, err = s.createDiscoveryPool(dialOpts...)
+		if err != nil {
+			return err
+		}
+
+		redsyncPool = newRedsyncPoolFromPool(s.pool)
 	} else {
-		s.log.Infof("Connecting to redis on %s:%d ...", *redisHost, *redisPort)
+		s.log.Infof("Connecting to redis on %s:%d ...", s.options.Host, s.options.Port)
 
-		s.pool, err = s.createPool(fmt.Sprintf("%s:%d", *redisHost, *redisPort), dialOpts...)
+		s.pool, err = s.createPool(fmt.Sprintf("%s:%d", s.options.Host, s.options.Port), dialOpts...)
 		if err != nil {
 			return err
 		}
@@ -139,23 +577,92 @@ func (s *Service) Start() error {
 
 	s.redsyncClient = redsync.New(redsyncPool)
 
-	conn, err := s.openConn(true)
+	err = s.validateReadPreference()
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return err
 	}
-	defer conn.Close()
 
-	_, err = conn.Do("PING")
+	err = s.createReplicaPools(dialOpts...)
 	if err != nil {
-		return fmt.Errorf("can't ping redis: %s", err)
+		return err
+	}
+
+	if s.options.CircuitBreakerThreshold > 0 {
+		s.breaker = newCircuitBreaker(
+			s.log,
+			s.options.CircuitBreakerThreshold,
+			s.options.CircuitBreakerOpenDuration,
+			s.options.CircuitBreakerHalfOpenProbes,
+		)
+	}
+
+	if s.options.AdaptiveTimeout {
+		s.latencies = newLatencyTracker(
+			s.options.AdaptiveTimeoutMin,
+			s.options.AdaptiveTimeoutMax,
+			s.options.AdaptiveTimeoutFactor,
+		)
+	}
+
+	if s.options.ConnRecycleInterval > 0 {
+		s.goWorker("conn recycler", s.runConnRecycler)
+	}
+
+	s.setStarted()
+
+	s.startExpireListener()
+	s.startQueueMonitor()
+
+	err = s.ping()
+	if err != nil {
+		if !s.options.StartBackground || IsAuthError(err) {
+			return err
+		}
+
+		s.log.Warnf("Redis not ready, retrying in background: %s", err)
+
+		s.goWorker("background connect", s.connectInBackground)
+
+		return nil
 	}
 
+	s.setReady()
+
 	return nil
 }
 
 func (s *Service) openConn(useRetry bool) (redis.Conn, error) {
+	delegate, err := s.openDelegate(useRetry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceConn{service: s, delegate: delegate, useRetry: useRetry}, nil
+}
+
+// openDelegate opens the underlying connection of a serviceConn
+//
+// Returns ErrCircuitOpen without connecting while the circuit breaker is open.
+func (s *Service) openDelegate(useRetry bool) (redis.Conn, error) {
+	err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
 	if s.cluster == nil {
-		return s.pool.Get(), nil
+		conn, err := s.pool.GetContext(s.ctx)
+		if err != nil {
+			s.breaker.record(err)
+
+			return nil, err
+		}
+
+		return conn, nil
+	}
+
+	err = s.ctx.Err()
+	if err != nil {
+		return nil, err
 	}
 
 	conn := s.cluster.Get()
@@ -173,14 +680,36 @@ func (s *Service) openConn(useRetry bool) (redis.Conn, error) {
 }
 
 // Health checks the health of the Service by pinging the redis database
+//
+// Returns ErrNotReady if the service couldn't connect to redis yet (only with
+// redis_start_background).
+//
+// If any of the redis_health_* thresholds is configured, the health indicators returned
+// by HealthDetails are checked as well.
 func (s *Service) Health() error {
+	if !s.Ready() {
+		err := s.ping()
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrNotReady, err)
+		}
+
+		s.setReady()
+	}
+
+	if s.healthChecksEnabled() {
+		return s.checkHealthThresholds()
+	}
+
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
 	_, err = conn.Do("PING")
+	if IsAuthError(err) {
+		return fmt.Errorf("redis service unhealthy, authentication failed: %s", err)
+	}
 	if err != nil {
 		return fmt.Errorf("redis service unhealthy: %s", err)
 	}
@@ -189,33 +718,70 @@ func (s *Service) Health() error {
 }
 
 // Stop closes all redis pool connections
+//
+// Subscriptions, consumers and background goroutines are stopped first, waiting up to
+// redis_stop_timeout_ms for them to deliver all buffered messages. Calling Stop multiple
+// times or before Start is no error.
 func (s *Service) Stop() error {
-	if s.cluster == nil {
+	var err error
+
+	s.stopOnce.Do(func() {
+		err = s.stop()
+	})
+
+	return err
+}
+
+func (s *Service) stop() error {
+	close(s.done)
+
+	if s.options == nil {
+		return nil
+	}
+
+	s.stopWorkers(s.options.StopTimeout)
+
+	for _, pool := range s.replicaPools {
+		pool.Close()
+	}
+
+	if s.cluster != nil {
+		return s.cluster.Close()
+	}
+
+	if s.pool != nil {
 		return s.pool.Close()
 	}
 
-	return s.cluster.Close()
+	return nil
 }
 
 // Get retrieves a key's value from redis
 func (s *Service) Get(key string) ([]byte, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
-	return redis.Bytes(conn.Do("GET", key))
+	data, err := redis.Bytes(conn.Do("GET", key))
+
+	return s.decryptValue(key, data, err)
 }
 
 // Set stores a key and its value in redis
 func (s *Service) Set(key string, data []byte) error {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
+	data, err = s.encryptValue(key, data)
+	if err != nil {
+		return err
+	}
+
 	_, err = conn.Do("SET", key, data)
 
 	return err
@@ -225,10 +791,15 @@ func (s *Service) Set(key string, data []byte) error {
 func (s *Service) SetNXPX(key string, data []byte, timeoutMS int) error {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
+	data, err = s.encryptValue(key, data)
+	if err != nil {
+		return err
+	}
+
 	_, err = conn.Do("SET", key, data, "NX", "PX", timeoutMS)
 
 	return err
@@ -238,33 +809,32 @@ func (s *Service) SetNXPX(key string, data []byte, timeoutMS int) error {
 func (s *Service) SetPX(key string, data []byte, timeoutMS int) error {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
+	data, err = s.encryptValue(key, data)
+	if err != nil {
+		return err
+	}
+
 	_, err = conn.Do("SET", key, data, "PX", timeoutMS)
 
 	return err
 }
 
-// Del deletes a key from redis
-func (s *Service) Del(key string) error {
-	conn, err := s.openConn(true)
-	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
-	}
-	defer conn.Close()
-
-	_, err = conn.Do("DEL", key)
+// Del deletes one or multiple keys from redis
+func (s *Service) Del(keys ...string) error {
+	_, err := s.deleteKeys("DEL", keys)
 
 	return err
 }
 
 // Exists checks if a key exists in redis
 func (s *Service) Exists(key string) (bool, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return false, fmt.Errorf("can't connect to redis: %s", err)
+		return false, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -276,13 +846,26 @@ func (s *Service) Exists(key string) (bool, error) {
 	return exists >= 1, nil
 }
 
+// ExistsMulti checks how many of the given keys exist in redis
+//
+// Keys are counted multiple times if they are passed multiple times
+func (s *Service) ExistsMulti(keys ...string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("EXISTS", redis.Args{}.AddFlat(keys)...))
+}
+
 // Scan scans all keys for a specific pattern and returns a list of keys
 func (s *Service) Scan(pattern string, cursor int) (int, []string, error) {
 	keys := make([]string, 0)
 
 	conn, err := s.openConn(true)
 	if err != nil {
-		return 0, nil, fmt.Errorf("can't connect to redis: %s", err)
+		return 0, nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -296,6 +879,10 @@ func (s *Service) Scan(pattern string, cursor int) (int, []string, error) {
 		return 0, nil, err
 	}
 
+	for i := range keys {
+		keys[i] = s.stripKeyPrefix(keys[i])
+	}
+
 	return cursor, keys, nil
 }
 
@@ -303,7 +890,7 @@ func (s *Service) Scan(pattern string, cursor int) (int, []string, error) {
 func (s *Service) RPush(key string, data []byte) (int, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return 0, fmt.Errorf("can't connect to redis: %s", err)
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -314,7 +901,7 @@ func (s *Service) RPush(key string, data []byte) (int, error) {
 func (s *Service) LPush(key string, data []byte) (int, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return 0, fmt.Errorf("can't connect to redis: %s", err)
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -323,9 +910,9 @@ func (s *Service) LPush(key string, data []byte) (int, error) {
 
 // LRange loads elements from a list
 func (s *Service) LRange(key string, start int, stop int) ([][]byte, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -336,7 +923,7 @@ func (s *Service) LRange(key string, start int, stop int) ([][]byte, error) {
 func (s *Service) LRem(key string, count int, data []byte) (int, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return 0, fmt.Errorf("can't connect to redis: %s", err)
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -347,7 +934,7 @@ func (s *Service) LRem(key string, count int, data []byte) (int, error) {
 func (s *Service) LPop(key string) ([]byte, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -358,7 +945,7 @@ func (s *Service) LPop(key string) ([]byte, error) {
 func (s *Service) RPop(key string) ([]byte, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -367,13 +954,13 @@ func (s *Service) RPop(key string) ([]byte, error) {
 
 // BLPop waits for a new item in a list (blocking with timeout)
 func (s *Service) BLPop(key string, timeout int) ([]byte, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openBlockingConn()
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
-	result, err := redis.ByteSlices(conn.Do("BLPOP", key, timeout))
+	result, err := redis.ByteSlices(redis.DoWithTimeout(conn, blockReadTimeout(time.Duration(timeout)*time.Second), "BLPOP", key, timeout))
 	if err != nil {
 		return nil, err
 	}
@@ -385,25 +972,83 @@ func (s *Service) BLPop(key string, timeout int) ([]byte, error) {
 	return result[1], err
 }
 
+// BLPopCtx waits for a new item in a list (blocking with timeout, 0 blocks until the context is done)
+//
+// The command is issued in slices of at most blockSliceDuration, so a cancelled context is
+// noticed within that time. The read timeout of the connection is set relative to each slice.
+func (s *Service) BLPopCtx(ctx context.Context, key string, timeout time.Duration) ([]byte, error) {
+	conn, err := s.openBlockingConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err = ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		wait := blockSliceDuration
+		if timeout > 0 {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil, ErrNil
+			}
+
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+
+		if ctxDeadline, ok := ctx.Deadline(); ok && time.Until(ctxDeadline) < wait {
+			wait = time.Until(ctxDeadline)
+		}
+
+		result, err := redis.ByteSlices(redis.DoWithTimeout(conn, wait+blockReadTimeoutMargin, "BLPOP", key, blockTimeoutArg(wait)))
+		if err == ErrNil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result) < 2 || result[0] == nil || result[1] == nil {
+			return nil, ErrNil
+		}
+
+		return result[1], nil
+	}
+}
+
 // HGet retrieves a hash value from redis
 func (s *Service) HGet(key string, field string) ([]byte, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
-	return redis.Bytes(conn.Do("HGET", key, field))
+	data, err := redis.Bytes(conn.Do("HGET", key, field))
+
+	return s.decryptField(key, field, data, err)
 }
 
 // HSet stores a key and its value in a hash in redis
 func (s *Service) HSet(key string, field string, data []byte) error {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
+	data, err = s.encryptField(key, field, data)
+	if err != nil {
+		return err
+	}
+
 	_, err = conn.Do("HSET", key, field, data)
 
 	return err
@@ -411,39 +1056,103 @@ func (s *Service) HSet(key string, field string, data []byte) error {
 
 // HScan scans a hash map and returns a list of field-value-tupples
 func (s *Service) HScan(key string, cursor int) (int, map[string][]byte, error) {
-	arr := make([][]byte, 0)
+	return s.HScanMatch(key, cursor, "", 0)
+}
 
-	conn, err := s.openConn(true)
+// HGetAllMulti loads all fields and values of multiple hashes in one round trip
+//
+// Keys of non-existing hashes are omitted from the result. In cluster mode the
+// hashes are loaded sequentially, as keys can be located on different nodes.
+func (s *Service) HGetAllMulti(keys []string) (map[string]map[string][]byte, error) {
+	result := map[string]map[string][]byte{}
+
+	if s.cluster != nil {
+		conn, err := s.openReadConn()
+		if err != nil {
+			return nil, fmt.Errorf("can't connect to redis: %w", err)
+		}
+		defer conn.Close()
+
+		for _, key := range keys {
+			values, err := hashReply(conn.Do("HGETALL", key))
+			if err != nil {
+				return nil, err
+			}
+
+			values, err = s.decryptHash(key, values, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(values) > 0 {
+				result[key] = values
+			}
+		}
+
+		return result, nil
+	}
+
+	conn, err := s.openReadConn()
 	if err != nil {
-		return 0, nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
-	resp, err := redis.Values(conn.Do("HSCAN", key, cursor))
-	if err != nil {
-		return 0, nil, err
+	for _, key := range keys {
+		err = conn.Send("HGETALL", key)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	_, err = redis.Scan(resp, &cursor, &arr)
+	err = conn.Flush()
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	keyValues := map[string][]byte{}
-	for i := range arr {
-		if i > 0 && i%2 == 1 {
-			keyValues[string(arr[i-1])] = arr[i]
+	for _, key := range keys {
+		values, err := hashReply(conn.Receive())
+		if err != nil {
+			return nil, err
+		}
+
+		values, err = s.decryptHash(key, values, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) > 0 {
+			result[key] = values
 		}
 	}
 
-	return cursor, keyValues, nil
+	return result, nil
+}
+
+// hashReply converts a field-value-list reply to a map
+func hashReply(reply interface{}, err error) (map[string][]byte, error) {
+	arr, err := redis.ByteSlices(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(arr)%2 != 0 {
+		return nil, fmt.Errorf("expected even number of values for hash, got %d", len(arr))
+	}
+
+	values := make(map[string][]byte, len(arr)/2)
+	for i := 0; i < len(arr); i += 2 {
+		values[string(arr[i])] = arr[i+1]
+	}
+
+	return values, nil
 }
 
 // HKeys gets all field names in the hash stored at key
 func (s *Service) HKeys(key string) ([][]byte, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -454,7 +1163,7 @@ func (s *Service) HKeys(key string) ([][]byte, error) {
 func (s *Service) HDel(key string, field string) error {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -465,9 +1174,9 @@ func (s *Service) HDel(key string, field string) error {
 
 // HLen gets the length of the map stored at key
 func (s *Service) HLen(key string) (int, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return 0, fmt.Errorf("can't connect to redis: %s", err)
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -476,9 +1185,9 @@ func (s *Service) HLen(key string) (int, error) {
 
 // LIndex gets the element at index in the list stored at key
 func (s *Service) LIndex(key string, position int) ([]byte, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -487,9 +1196,9 @@ func (s *Service) LIndex(key string, position int) ([]byte, error) {
 
 // LLen gets the length of the list stored at key
 func (s *Service) LLen(key string) (int, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openReadConn()
 	if err != nil {
-		return 0, fmt.Errorf("can't connect to redis: %s", err)
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -501,6 +1210,10 @@ type Message struct {
 	Error   error
 	Channel string
 	Data    []byte
+
+	// Reconnected is set on the message emitted after the subscription was restored
+	// following a connection failure (only with redis_subscribe_reconnect)
+	Reconnected bool
 }
 
 // IsError returns if an error occured
@@ -508,6 +1221,11 @@ func (m *Message) IsError() bool {
 	return m.Error != nil
 }
 
+// IsReconnect returns if the subscription was restored after a connection failure
+func (m *Message) IsReconnect() bool {
+	return m.Reconnected
+}
+
 // ISubscription defines the interface of Subscription
 type ISubscription interface {
 	Subscribe(channel ...interface{}) error
@@ -517,37 +1235,82 @@ type ISubscription interface {
 
 // Subscription is used to track a subscription to a channel via Subscribe(...)
 type Subscription struct {
-	conn *redis.PubSubConn
+	mutex    sync.Mutex
+	conn     *redis.PubSubConn
+	channels map[string]interface{}
+	closed   bool
+	// killed is closed to abort the delivery of messages on Stop
+	killed chan struct{}
+
+	// sharded subscriptions use SSUBSCRIBE / SUNSUBSCRIBE
+	sharded bool
 }
 
 var _ (ISubscription) = (*Subscription)(nil)
 
+func channelName(channel interface{}) string {
+	switch name := channel.(type) {
+	case string:
+		return name
+	case []byte:
+		return string(name)
+	default:
+		return fmt.Sprint(channel)
+	}
+}
+
 // Subscribe subscribes to one or multiple channels
 func (s *Subscription) Subscribe(channel ...interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	if s.conn == nil {
 		return fmt.Errorf("no connection")
 	}
 
-	return s.conn.Subscribe(channel...)
+	for _, ch := range channel {
+		s.channels[channelName(ch)] = ch
+	}
+
+	return pubSubSubscribe(s.conn, s.sharded, channel...)
 }
 
 // Unsubscribe unsubscribes from one or multiple channels
 func (s *Subscription) Unsubscribe(channel ...interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	if s.conn == nil {
 		return fmt.Errorf("no connection")
 	}
 
-	return s.conn.Unsubscribe(channel...)
+	if len(channel) == 0 {
+		s.channels = map[string]interface{}{}
+	}
+
+	for _, ch := range channel {
+		delete(s.channels, channelName(ch))
+	}
+
+	return pubSubUnsubscribe(s.conn, s.sharded, channel...)
 }
 
 // Close unsubscribes from all subscriptions and closes the connection
 func (s *Subscription) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	if s.conn == nil {
 		return fmt.Errorf("no connection")
 	}
 
-	err := s.conn.Unsubscribe()
+	s.closed = true
+
+	err := pubSubUnsubscribe(s.conn, s.sharded)
 	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+
 		return err
 	}
 
@@ -561,99 +1324,320 @@ func (s *Subscription) Close() error {
 	return nil
 }
 
+// drain unsubscribes from all channels, the connection is closed after all buffered messages
+// were delivered
+func (s *Subscription) drain() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn == nil || s.closed {
+		return
+	}
+
+	s.closed = true
+
+	err := pubSubUnsubscribe(s.conn, s.sharded)
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// kill closes the connection and aborts the delivery of messages
+func (s *Subscription) kill() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	select {
+	case <-s.killed:
+	default:
+		close(s.killed)
+	}
+}
+
+// closeConn closes the connection after the subscription ended
+func (s *Subscription) closeConn() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *Subscription) isClosed() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.closed
+}
+
+// reconnect opens a new connection for the subscription and resubscribes to all channels
+func (s *Subscription) reconnect(service *Service) (*redis.PubSubConn, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("subscription closed")
+	}
+
+	channels := make([]string, 0, len(s.channels))
+	for name := range s.channels {
+		channels = append(channels, name)
+	}
+
+	psc, err := service.openPubSubConn(s.sharded, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(channels) > 0 {
+		err = pubSubSubscribe(psc, s.sharded, redis.Args{}.AddFlat(channels)...)
+		if err != nil {
+			psc.Close()
+
+			return nil, err
+		}
+	}
+
+	s.conn = psc
+
+	return psc, nil
+}
+
 // GetPool returns the redis connection pool if not in cluster mode, else nil
 func (s *Service) GetPool() *redis.Pool {
 	return s.pool
 }
 
 // Subscribe subscribes to channels and returns a subscription
+//
+// The channel is closed after the subscription was closed or unsubscribed from all channels
+// or the service was stopped. Stop delivers all messages received before unsubscribing
+// within redis_stop_timeout_ms.
+//
+// If redis_subscribe_reconnect is enabled, a failed connection is redialed and all channels
+// are resubscribed, emitting a message with Reconnected set instead of an error message.
 func (s *Service) Subscribe(channels []string) (chan Message, ISubscription, error) {
-	conn, err := s.openConn(false)
+	return s.subscribe(channels, false)
+}
+
+func (s *Service) subscribe(channels []string, sharded bool) (chan Message, ISubscription, error) {
+	psc, err := s.openPubSubConn(sharded, channels)
 	if err != nil {
-		return nil, nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, nil, err
 	}
 
-	psc := &redis.PubSubConn{Conn: conn}
+	if err := pubSubSubscribe(psc, sharded, redis.Args{}.AddFlat(channels)...); err != nil {
+		psc.Close()
 
-	if err := psc.Subscribe(redis.Args{}.AddFlat(channels)...); err != nil {
 		return nil, nil, err
 	}
 
 	output := make(chan Message, 1)
 
 	subscription := &Subscription{
-		conn: psc,
+		conn:     psc,
+		channels: map[string]interface{}{},
+		sharded:  sharded,
+		killed:   make(chan struct{}),
+	}
+
+	for _, channel := range channels {
+		subscription.channels[channel] = channel
 	}
 
-	// Start a goroutine to receive notifications from the server.
+	done := s.startWorker(subscription)
+
 	go func() {
-		for subscription.conn != nil {
-			switch n := subscription.conn.Receive().(type) {
-			case error:
-				output <- Message{
-					Error: n,
-				}
-				return
-			case redis.Message:
-				output <- Message{
-					Channel: n.Channel,
-					Data:    n.Data,
-				}
-			case redis.Subscription:
-				switch n.Count {
-				case 0:
-					// Return from the goroutine when all channels got unsubscribed
-					return
-				}
+		defer done()
+
+		s.runSubscription(subscription, psc, output)
+	}()
+
+	return output, subscription, nil
+}
+
+// runSubscription receives messages of the subscription, reconnecting if enabled
+func (s *Service) runSubscription(subscription *Subscription, psc *redis.PubSubConn, output chan Message) {
+	defer close(output)
+	defer subscription.closeConn()
+
+	for {
+		err := s.receiveSubscription(subscription, psc, output)
+		if err == nil || subscription.isClosed() {
+			return
+		}
+
+		if !s.options.SubscribeReconnect {
+			select {
+			case output <- Message{Error: err}:
+			case <-subscription.killed:
 			}
+
+			return
+		}
+
+		s.log.Warnf("Subscription failed, reconnecting: %s", err)
+
+		psc = s.redialSubscription(subscription)
+		if psc == nil {
+			return
+		}
+
+		select {
+		case output <- Message{Reconnected: true}:
+		case <-subscription.killed:
+			return
+		}
+	}
+}
+
+// redialSubscription retries to reconnect the subscription until it succeeds, returns nil
+// if the subscription, its context or the service was closed in the meantime
+func (s *Service) redialSubscription(subscription *Subscription) *redis.PubSubConn {
+	for {
+		select {
+		case <-s.done:
+			return nil
+		case <-s.ctx.Done():
+			return nil
+		case <-time.After(1 * time.Second):
 		}
-	}()
+
+		if subscription.isClosed() {
+			return nil
+		}
+
+		psc, err := subscription.reconnect(s)
+		if err != nil {
+			if subscription.isClosed() {
+				return nil
+			}
+
+			s.log.Warnf("Can't resubscribe: %s", err)
+
+			continue
+		}
+
+		return psc
+	}
+}
+
+// receiveSubscription forwards messages until the connection fails or all channels
+// got unsubscribed (returning nil)
+func (s *Service) receiveSubscription(subscription *Subscription, psc *redis.PubSubConn, output chan Message) error {
+	stopped := make(chan struct{})
+	defer close(stopped)
 
 	// Start loop for pinging to check if connection is still alive
 	go func() {
-		ticker := time.NewTicker(5 * time.Second)
+		ticker := time.NewTicker(pubSubPingInterval)
 		defer ticker.Stop()
 
-		for subscription.conn != nil {
-			<-ticker.C
-			// Send ping to test health of connection and server. If
-			// corresponding pong is not received, then receive on the
-			// connection will timeout and the receive goroutine will exit.
-			if err := psc.Ping(""); err != nil {
-				output <- Message{
-					Error: err,
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-ticker.C:
+				// Send ping to test health of connection and server. If
+				// corresponding pong is not received, then receive on the
+				// connection will timeout after pubSubReceiveTimeout and the
+				// receive loop will exit.
+				if err := psc.Ping(""); err != nil {
+					psc.Close()
+					return
 				}
-
-				subscription.Unsubscribe()
 			}
 		}
 	}()
 
-	return output, subscription, nil
+	for {
+		switch n := receivePubSub(psc).(type) {
+		case error:
+			return n
+		case redis.Message:
+			finish := s.startMessage(n.Channel)
+
+			select {
+			case output <- Message{Channel: n.Channel, Data: n.Data}:
+			case <-subscription.killed:
+				finish()
+
+				return nil
+			}
+
+			finish()
+		case redis.Subscription:
+			if n.Count == 0 {
+				// All channels got unsubscribed
+				return nil
+			}
+		}
+	}
 }
 
-// Publish emits a message on a channel
-func (s *Service) Publish(channel string, data []byte) error {
+// Publish emits a message on a channel and returns the number of receivers
+func (s *Service) Publish(channel string, data []byte) (int, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
-	_, err = conn.Do("PUBLISH", channel, data)
-
-	return err
+	return redis.Int(conn.Do("PUBLISH", channel, data))
 }
 
 // NewMutex creates a new redsync mutex
 func (s *Service) NewMutex(name string, options ...redsync.Option) *redsync.Mutex {
-	return s.redsyncClient.NewMutex(name, options...)
+	return s.redsyncClient.NewMutex(s.options.KeyPrefix+name, options...)
+}
+
+func newService(name string, flags *flagSet) *Service {
+	return &Service{
+		serviceState: &serviceState{
+			name:  name,
+			flags: flags,
+			log:   gousu.GetLogger(fmt.Sprintf("service.%s", name)),
+			done:  make(chan struct{}),
+		},
+		ctx: context.Background(),
+	}
 }
 
 // NewService is the ServiceFactory for redis service
 func NewService(ctx gousu.IContext) gousu.IService {
-	return &Service{
-		log: gousu.GetLogger("service.redis"),
+	return newService(ServiceName, defaultFlags)
+}
+
+// NewServiceWithOptions creates a new redis service configured by options instead of flags
+func NewServiceWithOptions(name string, options *Options) *Service {
+	service := newService(name, nil)
+	service.options = options
+
+	return service
+}
+
+// NewServiceFactory creates a ServiceFactory for an additional redis service
+// with its own name and flags
+//
+// All flags of the service are prefixed with flagPrefix (e.g. "cache_" for "cache_redis_host"),
+// so NewServiceFactory must be called before the flags are parsed (e.g. in a package-level var).
+func NewServiceFactory(name string, flagPrefix string) gousu.ServiceFactory {
+	flags := newFlagSet(flagPrefix)
+
+	return func(ctx gousu.IContext) gousu.IService {
+		return newService(name, flags)
 	}
 }
 
@@ -0,0 +1,148 @@
+package gousuredis
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	latencySampleSize     = 256
+	latencyRecalcInterval = 32
+	latencyMaxTimeouts    = 3
+)
+
+// blockingCommands are excluded from adaptive timeouts, as their latency depends on their arguments
+var blockingCommands = map[string]bool{
+	"BLPOP":      true,
+	"BRPOP":      true,
+	"BRPOPLPUSH": true,
+	"BLMOVE":     true,
+	"BZPOPMIN":   true,
+	"BZPOPMAX":   true,
+	"XREAD":      true,
+	"XREADGROUP": true,
+	"WAIT":       true,
+}
+
+type commandLatency struct {
+	samples []time.Duration
+	next    int
+	count   int
+	timeout time.Duration
+	// timeouts is the number of consecutive timeouts
+	timeouts int
+}
+
+// latencyTracker tracks latency percentiles per command and derives read timeouts from them
+type latencyTracker struct {
+	mutex    sync.Mutex
+	min      time.Duration
+	max      time.Duration
+	factor   float64
+	commands map[string]*commandLatency
+}
+
+func newLatencyTracker(min time.Duration, max time.Duration, factor float64) *latencyTracker {
+	return &latencyTracker{
+		min:      min,
+		max:      max,
+		factor:   factor,
+		commands: map[string]*commandLatency{},
+	}
+}
+
+// isTracked checks if the command's latency is tracked
+func (t *latencyTracker) isTracked(commandName string) bool {
+	return !blockingCommands[strings.ToUpper(commandName)]
+}
+
+// Timeout returns the current read timeout for a command
+//
+// Returns the upper bound until enough samples were recorded
+func (t *latencyTracker) Timeout(commandName string) time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	latency, ok := t.commands[strings.ToUpper(commandName)]
+	if !ok || latency.timeout == 0 {
+		return t.max
+	}
+
+	return latency.timeout
+}
+
+// Record adds a latency sample for a command
+func (t *latencyTracker) Record(commandName string, duration time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	latency := t.command(commandName)
+	latency.timeouts = 0
+
+	t.record(latency, duration)
+}
+
+// RecordTimeout adds a command which timed out as sample at its timeout
+//
+// After latencyMaxTimeouts consecutive timeouts the timeout is reset to the upper bound,
+// so commands don't keep failing if the latency increased suddenly.
+func (t *latencyTracker) RecordTimeout(commandName string, timeout time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	latency := t.command(commandName)
+	latency.timeouts++
+
+	t.record(latency, timeout)
+
+	if latency.timeouts >= latencyMaxTimeouts {
+		latency.timeout = t.max
+	}
+}
+
+func (t *latencyTracker) command(commandName string) *commandLatency {
+	commandName = strings.ToUpper(commandName)
+
+	latency, ok := t.commands[commandName]
+	if !ok {
+		latency = &commandLatency{
+			samples: make([]time.Duration, latencySampleSize),
+		}
+		t.commands[commandName] = latency
+	}
+
+	return latency
+}
+
+func (t *latencyTracker) record(latency *commandLatency, duration time.Duration) {
+	latency.samples[latency.next] = duration
+	latency.next = (latency.next + 1) % latencySampleSize
+	latency.count++
+
+	if latency.count%latencyRecalcInterval != 0 {
+		return
+	}
+
+	n := latency.count
+	if n > latencySampleSize {
+		n = latencySampleSize
+	}
+
+	sorted := make([]time.Duration, n)
+	copy(sorted, latency.samples[:n])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	p99 := sorted[(n*99)/100]
+
+	timeout := time.Duration(float64(p99) * t.factor)
+	if timeout < t.min {
+		timeout = t.min
+	}
+	if timeout > t.max {
+		timeout = t.max
+	}
+
+	latency.timeout = timeout
+}
@@ -0,0 +1,57 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	tracker := newLatencyTracker(10*time.Millisecond, 1*time.Second, 3)
+
+	assert.Equal(t, 1*time.Second, tracker.Timeout("GET"))
+
+	for i := 0; i < latencyRecalcInterval; i++ {
+		tracker.Record("get", 20*time.Millisecond)
+	}
+
+	assert.Equal(t, 60*time.Millisecond, tracker.Timeout("GET"))
+	assert.Equal(t, 1*time.Second, tracker.Timeout("SET"))
+
+	for i := 0; i < latencySampleSize; i++ {
+		tracker.Record("GET", 1*time.Millisecond)
+	}
+
+	assert.Equal(t, 10*time.Millisecond, tracker.Timeout("GET"))
+	assert.False(t, tracker.isTracked("blpop"))
+}
+
+func TestLatencyTrackerTimeouts(t *testing.T) {
+	tracker := newLatencyTracker(10*time.Millisecond, 1*time.Second, 3)
+
+	for i := 0; i < latencyRecalcInterval; i++ {
+		tracker.Record("GET", 5*time.Millisecond)
+	}
+
+	assert.Equal(t, 15*time.Millisecond, tracker.Timeout("GET"))
+
+	for i := 1; i < latencyMaxTimeouts; i++ {
+		tracker.RecordTimeout("GET", 15*time.Millisecond)
+	}
+
+	assert.Equal(t, 15*time.Millisecond, tracker.Timeout("GET"))
+
+	// a successful command resets the consecutive timeouts
+	tracker.Record("GET", 5*time.Millisecond)
+
+	for i := 1; i < latencyMaxTimeouts; i++ {
+		tracker.RecordTimeout("GET", 15*time.Millisecond)
+	}
+
+	assert.Equal(t, 15*time.Millisecond, tracker.Timeout("GET"))
+
+	tracker.RecordTimeout("GET", 15*time.Millisecond)
+
+	assert.Equal(t, 1*time.Second, tracker.Timeout("GET"))
+}
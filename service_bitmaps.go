@@ -0,0 +1,118 @@
+package gousuredis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// BitOperation is the operation of BitOp
+type BitOperation string
+
+const (
+	BitOpAnd BitOperation = "AND"
+	BitOpOr  BitOperation = "OR"
+	BitOpXor BitOperation = "XOR"
+	BitOpNot BitOperation = "NOT"
+)
+
+func bitArg(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+// SetBit sets the bit at offset in the value of a key and returns the previous bit
+func (s *Service) SetBit(key string, offset int, value bool) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("SETBIT", key, offset, bitArg(value)))
+}
+
+// GetBit returns the bit at offset in the value of a key
+func (s *Service) GetBit(key string, offset int) (bool, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("GETBIT", key, offset))
+}
+
+// BitCount counts the set bits between the byte offsets start and end (inclusive,
+// use 0 and -1 for the whole value)
+func (s *Service) BitCount(key string, start int, end int) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("BITCOUNT", key, start, end))
+}
+
+// BitPos returns the position of the first bit set to value, starting at the byte offset start
+//
+// Returns -1 if no set bit is found, searching for a clear bit returns the first bit after
+// the value if all bits are set
+func (s *Service) BitPos(key string, value bool, start int) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("BITPOS", key, bitArg(value), start))
+}
+
+// BitOp performs a bitwise operation between keys and stores the result in destKey
+//
+// Returns the length of the stored value. In cluster mode all keys must belong to the
+// same slot (e.g. by using hash tags).
+func (s *Service) BitOp(operation BitOperation, destKey string, keys ...string) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("BITOP", redis.Args{}.Add(string(operation), destKey).AddFlat(keys)...))
+}
+
+// BitField runs the BITFIELD subcommands (e.g. "INCRBY", "u8", 0, 1) on the value of a key
+// and returns their results
+//
+// Results of subcommands failing due to OVERFLOW FAIL are returned as 0
+func (s *Service) BitField(key string, args ...interface{}) ([]int64, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	values, err := redis.Values(conn.Do("BITFIELD", redis.Args{}.Add(key).Add(args...)...))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]int64, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		results[i], err = redis.Int64(value, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
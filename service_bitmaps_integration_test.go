@@ -0,0 +1,116 @@
+package gousuredis_test
+
+import (
+	"testing"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitmaps(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	previous, err := service.SetBit("bits1", 7, true)
+	require.NoError(t, err)
+	assert.False(t, previous)
+
+	previous, err = service.SetBit("bits1", 7, true)
+	require.NoError(t, err)
+	assert.True(t, previous)
+
+	_, err = service.SetBit("bits1", 9, true)
+	require.NoError(t, err)
+
+	bit, err := service.GetBit("bits1", 7)
+	require.NoError(t, err)
+	assert.True(t, bit)
+
+	bit, err = service.GetBit("bits1", 0)
+	require.NoError(t, err)
+	assert.False(t, bit)
+
+	count, err := service.BitCount("bits1", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = service.BitCount("bits1", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	position, err := service.BitPos("bits1", true, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 7, position)
+
+	position, err = service.BitPos("bits1", true, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 9, position)
+
+	position, err = service.BitPos("bits1", false, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, position)
+
+	position, err = service.BitPos("missing", true, 0)
+	require.NoError(t, err)
+	assert.Equal(t, -1, position)
+}
+
+func TestBitOp(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	_, err := service.SetBit("bits1", 7, true)
+	require.NoError(t, err)
+	_, err = service.SetBit("bits1", 9, true)
+	require.NoError(t, err)
+	_, err = service.SetBit("bits2", 7, true)
+	require.NoError(t, err)
+
+	length, err := service.BitOp(gousuredis.BitOpAnd, "and", "bits1", "bits2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	bit, err := service.GetBit("and", 7)
+	require.NoError(t, err)
+	assert.True(t, bit)
+
+	bit, err = service.GetBit("and", 9)
+	require.NoError(t, err)
+	assert.False(t, bit)
+
+	_, err = service.BitOp(gousuredis.BitOpOr, "or", "bits1", "bits2")
+	require.NoError(t, err)
+
+	count, err := service.BitCount("or", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	length, err = service.BitOp(gousuredis.BitOpNot, "not", "bits2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, length)
+
+	count, err = service.BitCount("not", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 7, count)
+}
+
+func TestBitField(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	results, err := service.BitField("field1", "INCRBY", "u8", 0, 200)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{200}, results)
+
+	// Default overflow behavior is WRAP
+	results, err = service.BitField("field1", "INCRBY", "u8", 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{44}, results)
+
+	results, err = service.BitField("field1", "OVERFLOW", "FAIL", "INCRBY", "u8", 0, 250, "GET", "u8", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 44}, results)
+
+	results, err = service.BitField("field1", "SET", "u8", 0, 1, "GET", "u4", 4)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{44, 1}, results)
+}
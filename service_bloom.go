@@ -0,0 +1,176 @@
+package gousuredis
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	bloomUnknown uint32 = iota
+	bloomAvailable
+	bloomMissing
+)
+
+// ErrBloomNotAvailable is returned by the BF.* and CF.* methods if redis doesn't support
+// the RedisBloom commands (e.g. plain redis without the module)
+var ErrBloomNotAvailable = errors.New("redisbloom commands not available")
+
+// BloomAvailable checks if redis supports the RedisBloom commands (BF.* and CF.*)
+//
+// The result is cached, so callers can check it up front to fall back to other data
+// structures on plain redis.
+func (s *Service) BloomAvailable() (bool, error) {
+	switch atomic.LoadUint32(&s.bloomSupport) {
+	case bloomAvailable:
+		return true, nil
+	case bloomMissing:
+		return false, nil
+	}
+
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	// COMMAND INFO also detects the commands if they are built in (redis 8) or MODULE LIST is disabled
+	infos, err := redis.Values(conn.Do("COMMAND", "INFO", "BF.ADD"))
+	if err != nil {
+		return false, err
+	}
+
+	available := len(infos) > 0 && infos[0] != nil
+	if available {
+		atomic.StoreUint32(&s.bloomSupport, bloomAvailable)
+	} else {
+		atomic.StoreUint32(&s.bloomSupport, bloomMissing)
+	}
+
+	return available, nil
+}
+
+// doBloom runs a BF.* or CF.* command if RedisBloom is available
+func (s *Service) doBloom(commandName string, args ...interface{}) (interface{}, error) {
+	available, err := s.BloomAvailable()
+	if err != nil {
+		return nil, err
+	}
+
+	if !available {
+		return nil, ErrBloomNotAvailable
+	}
+
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	reply, err := conn.Do(commandName, args...)
+	if redisErr, ok := err.(redis.Error); ok && strings.HasPrefix(string(redisErr), "ERR unknown command") {
+		// the module was unloaded or a failover switched to a node without it
+		atomic.StoreUint32(&s.bloomSupport, bloomUnknown)
+
+		return nil, fmt.Errorf("%w: %s", ErrBloomNotAvailable, err)
+	}
+
+	return reply, err
+}
+
+// BFReserve creates an empty bloom filter for capacity items with the false positive rate
+// errorRate (e.g. 0.001)
+//
+// Fails if the key already exists. Without BFReserve, BFAdd creates filters with the
+// default capacity and error rate of the module.
+func (s *Service) BFReserve(key string, errorRate float64, capacity int) error {
+	_, err := s.doBloom("BF.RESERVE", key, errorRate, capacity)
+
+	return err
+}
+
+// BFAdd adds an item to a bloom filter
+//
+// Returns true if the item was added, false if it may have existed before
+func (s *Service) BFAdd(key string, item []byte) (bool, error) {
+	return redis.Bool(s.doBloom("BF.ADD", key, item))
+}
+
+// BFMAdd adds multiple items to a bloom filter
+//
+// Returns for each item if it was added
+func (s *Service) BFMAdd(key string, items ...[]byte) ([]bool, error) {
+	return bools(s.doBloom("BF.MADD", redis.Args{}.Add(key).AddFlat(items)...))
+}
+
+// BFExists checks if an item may exist in a bloom filter
+//
+// Returns false if the item definitely doesn't exist
+func (s *Service) BFExists(key string, item []byte) (bool, error) {
+	return redis.Bool(s.doBloom("BF.EXISTS", key, item))
+}
+
+// BFMExists checks if multiple items may exist in a bloom filter
+func (s *Service) BFMExists(key string, items ...[]byte) ([]bool, error) {
+	return bools(s.doBloom("BF.MEXISTS", redis.Args{}.Add(key).AddFlat(items)...))
+}
+
+// CFReserve creates an empty cuckoo filter for capacity items
+//
+// Fails if the key already exists.
+func (s *Service) CFReserve(key string, capacity int) error {
+	_, err := s.doBloom("CF.RESERVE", key, capacity)
+
+	return err
+}
+
+// CFAdd adds an item to a cuckoo filter, an item can be added multiple times
+func (s *Service) CFAdd(key string, item []byte) error {
+	_, err := s.doBloom("CF.ADD", key, item)
+
+	return err
+}
+
+// CFAddNX adds an item to a cuckoo filter if it doesn't exist yet
+//
+// Returns true if the item was added, false if it may have existed before
+func (s *Service) CFAddNX(key string, item []byte) (bool, error) {
+	return redis.Bool(s.doBloom("CF.ADDNX", key, item))
+}
+
+// CFExists checks if an item may exist in a cuckoo filter
+//
+// Returns false if the item definitely doesn't exist
+func (s *Service) CFExists(key string, item []byte) (bool, error) {
+	return redis.Bool(s.doBloom("CF.EXISTS", key, item))
+}
+
+// CFDel deletes one occurrence of an item from a cuckoo filter
+//
+// Returns false if the item wasn't found
+func (s *Service) CFDel(key string, item []byte) (bool, error) {
+	return redis.Bool(s.doBloom("CF.DEL", key, item))
+}
+
+// CFCount returns the (approximated) number of times an item was added to a cuckoo filter
+func (s *Service) CFCount(key string, item []byte) (int, error) {
+	return redis.Int(s.doBloom("CF.COUNT", key, item))
+}
+
+// bools converts an array reply of integers to bools
+func bools(reply interface{}, err error) ([]bool, error) {
+	ints, err := redis.Ints(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(ints))
+	for i, value := range ints {
+		result[i] = value == 1
+	}
+
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomNotAvailable(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+	service.bloomSupport = bloomMissing
+
+	available, err := service.BloomAvailable()
+	assert.NoError(t, err)
+	assert.False(t, available)
+
+	_, err = service.BFAdd("filter", []byte("item"))
+	assert.ErrorIs(t, err, ErrBloomNotAvailable)
+
+	err = service.CFReserve("filter", 1000)
+	assert.ErrorIs(t, err, ErrBloomNotAvailable)
+}
+
+func TestBloomKeyPrefix(t *testing.T) {
+	assert.Equal(t, []interface{}{"app:filter", []byte("item")}, prefixArgs("app:", "BF.ADD", []interface{}{"filter", []byte("item")}))
+	assert.Equal(t, []interface{}{"app:filter", "a", "b"}, prefixArgs("app:", "BF.MEXISTS", []interface{}{"filter", "a", "b"}))
+}
+
+func TestBools(t *testing.T) {
+	result, err := bools([]interface{}{int64(1), int64(0), int64(1)}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, result)
+}
@@ -0,0 +1,162 @@
+package gousuredis
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// cacheLockTimeout is the maximum time a loader can hold the regeneration lock of a key
+	cacheLockTimeout = 10 * time.Second
+	// cacheLockPollInterval is the interval in which callers waiting for another loader check for the value
+	cacheLockPollInterval = 50 * time.Millisecond
+	// cacheTTLJitter is the maximum fraction added to the ttl to spread expirations
+	cacheTTLJitter = 0.1
+)
+
+// cacheUnlockScript deletes the lock only if it is still held by the caller
+var cacheUnlockScript = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type cacheCall struct {
+	wg   sync.WaitGroup
+	data []byte
+	err  error
+}
+
+// cacheGroup deduplicates concurrent loads of the same key within the process
+type cacheGroup struct {
+	mutex sync.Mutex
+	calls map[string]*cacheCall
+}
+
+func (g *cacheGroup) Do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mutex.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*cacheCall{}
+	}
+
+	if call, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		call.wg.Wait()
+
+		return call.data, call.err
+	}
+
+	call := &cacheCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mutex.Unlock()
+
+	call.data, call.err = fn()
+	call.wg.Done()
+
+	g.mutex.Lock()
+	delete(g.calls, key)
+	g.mutex.Unlock()
+
+	return call.data, call.err
+}
+
+// Cached returns the value of the key or loads and stores it via the loader if it doesn't exist (read-through cache)
+//
+// Only one caller regenerates a missing value: concurrent callers in the same process share the
+// result, callers in other processes wait for the value while a lock is held at key:lock. The ttl
+// is extended by a random jitter of up to 10% so entries stored together don't expire together.
+func (s *Service) Cached(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+	data, err := s.Get(key)
+	if err == nil {
+		return data, nil
+	}
+	if err != ErrNil {
+		return nil, err
+	}
+
+	return s.cacheGroup.Do(key, func() ([]byte, error) {
+		return s.loadCached(key, ttl, loader)
+	})
+}
+
+func (s *Service) loadCached(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	lockKey := fmt.Sprintf("%s:lock", key)
+	lockToken := strconv.FormatInt(rand.Int63(), 16)
+	deadline := time.Now().Add(cacheLockTimeout)
+
+	for {
+		reply, err := conn.Do("SET", lockKey, lockToken, "NX", "PX", cacheLockTimeout.Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+
+		if reply != nil {
+			break
+		}
+
+		// Another process is loading the value
+		time.Sleep(cacheLockPollInterval)
+
+		data, err := redis.Bytes(conn.Do("GET", key))
+		data, err = s.decryptValue(key, data, err)
+		if err == nil {
+			return data, nil
+		}
+		if err != ErrNil {
+			return nil, err
+		}
+
+		if time.Now().After(deadline) {
+			// Lock holder seems to be gone, load without lock
+			return s.storeCached(conn, key, ttl, loader)
+		}
+	}
+
+	defer cacheUnlockScript.Do(conn, lockKey, lockToken)
+
+	// The value could have been stored between the first lookup and acquiring the lock
+	data, err := redis.Bytes(conn.Do("GET", key))
+	data, err = s.decryptValue(key, data, err)
+	if err == nil {
+		return data, nil
+	}
+	if err != ErrNil {
+		return nil, err
+	}
+
+	return s.storeCached(conn, key, ttl, loader)
+}
+
+func (s *Service) storeCached(conn redis.Conn, key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+	data, err := loader()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl += time.Duration(rand.Float64() * cacheTTLJitter * float64(ttl))
+
+	encrypted, err := s.encryptValue(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = conn.Do("SET", key, encrypted, "PX", ttl.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
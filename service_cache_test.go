@@ -0,0 +1,39 @@
+package gousuredis
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheGroup(t *testing.T) {
+	group := &cacheGroup{}
+
+	var calls int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			data, err := group.Do("key", func() ([]byte, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(50 * time.Millisecond)
+
+				return []byte("value"), nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value"), data)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,157 @@
+package gousuredis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ClientInfo is a client connection returned by ClientList
+//
+// All attributes are available as strings in Fields.
+type ClientInfo struct {
+	ID    int64
+	Addr  string
+	LAddr string
+	Name  string
+	User  string
+	DB    int
+	// Age is the connection age in seconds
+	Age int64
+	// Idle is the idle time in seconds
+	Idle  int64
+	Flags string
+	// Cmd is the last command run by the client
+	Cmd string
+
+	Fields map[string]string
+}
+
+// ClientKillFilter selects the clients killed by ClientKill, all set fields must match
+type ClientKillFilter struct {
+	ID    int64
+	Addr  string
+	LAddr string
+	User  string
+	// Type is one of "normal", "master", "replica" or "pubsub"
+	Type string
+	// MaxAge kills clients older than MaxAge seconds (redis >= 7.4)
+	MaxAge int64
+}
+
+// parseClientList parses the output of CLIENT LIST
+func parseClientList(text string) []ClientInfo {
+	clients := []ClientInfo{}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := map[string]string{}
+		for _, attribute := range strings.Split(line, " ") {
+			parts := strings.SplitN(attribute, "=", 2)
+			if len(parts) == 2 {
+				fields[parts[0]] = parts[1]
+			}
+		}
+
+		client := ClientInfo{
+			ID:     parseInfoInt(fields, "id"),
+			Addr:   fields["addr"],
+			LAddr:  fields["laddr"],
+			Name:   fields["name"],
+			User:   fields["user"],
+			DB:     int(parseInfoInt(fields, "db")),
+			Age:    parseInfoInt(fields, "age"),
+			Idle:   parseInfoInt(fields, "idle"),
+			Flags:  fields["flags"],
+			Cmd:    fields["cmd"],
+			Fields: fields,
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+// ClientList returns the client connections of the server
+//
+// In cluster mode the clients of all master nodes are returned.
+func (s *Service) ClientList() ([]ClientInfo, error) {
+	clients := []ClientInfo{}
+
+	err := s.eachNode(func(conn redis.Conn) error {
+		text, err := redis.String(conn.Do("CLIENT", "LIST"))
+		if err != nil {
+			return err
+		}
+
+		clients = append(clients, parseClientList(text)...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// ClientKill closes the client connections matching the filter and returns the number of
+// killed clients
+//
+// The connection running the command is never killed. In cluster mode the clients of all
+// master nodes are checked.
+func (s *Service) ClientKill(filter ClientKillFilter) (int, error) {
+	args := redis.Args{"KILL"}
+
+	if filter.ID > 0 {
+		args = args.Add("ID", strconv.FormatInt(filter.ID, 10))
+	}
+
+	if filter.Addr != "" {
+		args = args.Add("ADDR", filter.Addr)
+	}
+
+	if filter.LAddr != "" {
+		args = args.Add("LADDR", filter.LAddr)
+	}
+
+	if filter.User != "" {
+		args = args.Add("USER", filter.User)
+	}
+
+	if filter.Type != "" {
+		args = args.Add("TYPE", filter.Type)
+	}
+
+	if filter.MaxAge > 0 {
+		args = args.Add("MAXAGE", filter.MaxAge)
+	}
+
+	if len(args) == 1 {
+		return 0, fmt.Errorf("empty client kill filter")
+	}
+
+	args = args.Add("SKIPME", "yes")
+
+	killed := 0
+
+	err := s.eachNode(func(conn redis.Conn) error {
+		count, err := redis.Int(conn.Do("CLIENT", args...))
+		if err != nil {
+			return err
+		}
+
+		killed += count
+
+		return nil
+	})
+
+	return killed, err
+}
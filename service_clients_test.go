@@ -0,0 +1,26 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClientList(t *testing.T) {
+	text := "id=3 addr=127.0.0.1:52555 laddr=127.0.0.1:6379 fd=8 name=worker age=120 idle=5 flags=N db=2 cmd=client|list user=default\n" +
+		"id=4 addr=127.0.0.1:52556 laddr=127.0.0.1:6379 fd=9 name= age=3 idle=3 flags=P db=0 cmd=subscribe user=app\n"
+
+	clients := parseClientList(text)
+
+	assert.Len(t, clients, 2)
+	assert.Equal(t, int64(3), clients[0].ID)
+	assert.Equal(t, "127.0.0.1:52555", clients[0].Addr)
+	assert.Equal(t, "worker", clients[0].Name)
+	assert.Equal(t, 2, clients[0].DB)
+	assert.Equal(t, int64(120), clients[0].Age)
+	assert.Equal(t, "client|list", clients[0].Cmd)
+	assert.Equal(t, "", clients[1].Name)
+	assert.Equal(t, "P", clients[1].Flags)
+	assert.Equal(t, "app", clients[1].User)
+	assert.Equal(t, "9", clients[1].Fields["fd"])
+}
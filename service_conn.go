@@ -0,0 +1,298 @@
+package gousuredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// serviceConn wraps all connections opened by the service to map errors
+// and track the server state
+type serviceConn struct {
+	service  *Service
+	delegate redis.Conn
+	useRetry bool
+	readOnly bool
+	// pinned connections carry state (e.g. pipelined commands, transactions, subscriptions
+	// or a bound cluster node) and are never replaced when retrying commands
+	pinned bool
+	// pending contains the hook callbacks of commands sent whose reply wasn't received yet
+	pending []func(err error)
+}
+
+// errReplyDiscarded is passed to hooks of sent commands whose reply was never received
+var errReplyDiscarded = errors.New("connection closed before receiving the reply")
+
+var _ (redis.ConnWithTimeout) = (*serviceConn)(nil)
+
+func (c *serviceConn) handleError(err error) error {
+	if err != nil {
+		atomic.AddUint64(&c.service.errorCount, 1)
+	}
+
+	err = mapError(err)
+
+	c.service.trackBusy(err)
+	c.service.breaker.record(err)
+
+	return err
+}
+
+func (c *serviceConn) Close() error {
+	c.finishPending(len(c.pending), errReplyDiscarded)
+
+	return c.delegate.Close()
+}
+
+// finishPending calls the hooks of the first n sent commands after their replies were received
+func (c *serviceConn) finishPending(n int, err error) {
+	if n > len(c.pending) {
+		n = len(c.pending)
+	}
+
+	for _, finish := range c.pending[:n] {
+		finish(err)
+	}
+
+	c.pending = c.pending[n:]
+}
+
+func (c *serviceConn) Err() error {
+	return c.delegate.Err()
+}
+
+// contextTimeout returns the time left until the deadline of the service's context
+func (c *serviceConn) contextTimeout() (time.Duration, bool, error) {
+	ctx := c.service.ctx
+
+	err := ctx.Err()
+	if err != nil {
+		return 0, false, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false, nil
+	}
+
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return 0, false, context.DeadlineExceeded
+	}
+
+	return timeout, true, nil
+}
+
+func (c *serviceConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	finish := c.service.startCommand(commandName, args)
+
+	reply, err := c.retry(commandName, args, func() (interface{}, error) {
+		return c.do(commandName, args...)
+	})
+
+	// Do reads the replies of all sent commands
+	c.finishPending(len(c.pending), err)
+	finish(err)
+
+	return reply, err
+}
+
+func (c *serviceConn) do(commandName string, args ...interface{}) (interface{}, error) {
+	args = prefixArgs(c.service.options.KeyPrefix, commandName, args)
+
+	ctxTimeout, hasCtxTimeout, err := c.contextTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	latencies := c.service.latencies
+	_, supportsTimeout := c.delegate.(redis.ConnWithTimeout)
+
+	if !supportsTimeout {
+		reply, err := c.delegate.Do(commandName, args...)
+
+		return reply, c.handleError(err)
+	}
+
+	if latencies == nil || !latencies.isTracked(commandName) {
+		if !hasCtxTimeout {
+			reply, err := c.delegate.Do(commandName, args...)
+
+			return reply, c.handleError(err)
+		}
+
+		reply, err := redis.DoWithTimeout(c.delegate, ctxTimeout, commandName, args...)
+
+		return reply, c.handleError(err)
+	}
+
+	timeout := latencies.Timeout(commandName)
+	if hasCtxTimeout && ctxTimeout < timeout {
+		timeout = ctxTimeout
+	}
+
+	start := time.Now()
+
+	reply, err := redis.DoWithTimeout(c.delegate, timeout, commandName, args...)
+	if err == nil {
+		latencies.Record(commandName, time.Since(start))
+	} else if IsTimeout(err) && (!hasCtxTimeout || timeout < ctxTimeout) {
+		// timeouts caused by the context deadline don't tell anything about the latency
+		latencies.RecordTimeout(commandName, timeout)
+	}
+
+	return reply, c.handleError(err)
+}
+
+// DoWithTimeout falls back to Do if the connection doesn't support timeouts (e.g. cluster retry connections)
+func (c *serviceConn) DoWithTimeout(timeout time.Duration, commandName string, args ...interface{}) (interface{}, error) {
+	finish := c.service.startCommand(commandName, args)
+
+	reply, err := c.retry(commandName, args, func() (interface{}, error) {
+		return c.doWithTimeout(timeout, commandName, args...)
+	})
+
+	c.finishPending(len(c.pending), err)
+	finish(err)
+
+	return reply, err
+}
+
+func (c *serviceConn) doWithTimeout(timeout time.Duration, commandName string, args ...interface{}) (interface{}, error) {
+	args = prefixArgs(c.service.options.KeyPrefix, commandName, args)
+
+	ctxTimeout, hasCtxTimeout, err := c.contextTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	if hasCtxTimeout && ctxTimeout < timeout {
+		timeout = ctxTimeout
+	}
+
+	if _, ok := c.delegate.(redis.ConnWithTimeout); !ok {
+		reply, err := c.delegate.Do(commandName, args...)
+
+		return reply, c.handleError(err)
+	}
+
+	reply, err := redis.DoWithTimeout(c.delegate, timeout, commandName, args...)
+
+	return reply, c.handleError(err)
+}
+
+func (c *serviceConn) Send(commandName string, args ...interface{}) error {
+	finish := c.service.startCommand(commandName, args)
+
+	c.pinned = true
+
+	args = prefixArgs(c.service.options.KeyPrefix, commandName, args)
+
+	err := c.handleError(c.delegate.Send(commandName, args...))
+	if err != nil {
+		finish(err)
+
+		return err
+	}
+
+	c.pending = append(c.pending, finish)
+
+	return nil
+}
+
+func (c *serviceConn) Flush() error {
+	return c.handleError(c.delegate.Flush())
+}
+
+func (c *serviceConn) Receive() (interface{}, error) {
+	reply, err := c.delegate.Receive()
+	err = c.handleError(err)
+
+	c.finishPending(1, err)
+
+	return reply, err
+}
+
+func (c *serviceConn) ReceiveWithTimeout(timeout time.Duration) (interface{}, error) {
+	reply, err := redis.ReceiveWithTimeout(c.delegate, timeout)
+	err = c.handleError(err)
+
+	c.finishPending(1, err)
+
+	return reply, err
+}
+
+// countCommand increases the command counter returned by Stats
+func (s *Service) countCommand() {
+	atomic.AddUint64(&s.commandCount, 1)
+}
+
+// startCommand is called before a command is sent and returns a function which must be
+// called with the result of the command
+func (s *Service) startCommand(commandName string, args []interface{}) func(err error) {
+	s.countCommand()
+
+	hooks := s.getHooks()
+	if len(hooks) == 0 {
+		return func(err error) {}
+	}
+
+	start := time.Now()
+	ctx := s.ctx
+
+	for _, hook := range hooks {
+		ctx = hook.BeforeCommand(ctx, commandName, args)
+	}
+
+	return func(err error) {
+		duration := time.Since(start)
+
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i].AfterCommand(ctx, commandName, args, duration, err)
+		}
+	}
+}
+
+// WithConn borrows a connection for running multiple commands and returns it to the pool
+// after fn returned (or panicked)
+//
+// Commands are prefixed, hooked and traced like all other commands, but never retried on
+// another connection, as fn may rely on the state of the connection. A transaction or
+// subscription left open by fn is discarded before the connection is reused. In cluster
+// mode the connection follows redirections to the node serving the keys of each command.
+func (s *Service) WithConn(fn func(conn redis.Conn) error) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	conn.(*serviceConn).pinned = true
+
+	return fn(conn)
+}
+
+// Do sends a command not wrapped by the service yet and returns the raw reply, which can
+// be converted via the redigo helpers (e.g. redis.Int)
+//
+// Keys are prefixed for all commands known to the key prefixing, connection errors are
+// retried for idempotent commands. Blocking commands should be sent via WithConn and
+// redis.DoWithTimeout, as they may exceed redis_read_timeout_ms.
+func (s *Service) Do(commandName string, args ...interface{}) (interface{}, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.Do(commandName, args...)
+}
+
+// DoCtx sends a command like Do using the context (see WithContext)
+func (s *Service) DoCtx(ctx context.Context, commandName string, args ...interface{}) (interface{}, error) {
+	return s.WithContext(ctx).Do(commandName, args...)
+}
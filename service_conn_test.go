@@ -0,0 +1,47 @@
+package gousuredis
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithConnCircuitOpen(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+	service.breaker = newCircuitBreaker(nil, 1, time.Minute, 1)
+	service.breaker.record(io.EOF)
+
+	called := false
+
+	err := service.WithConn(func(conn redis.Conn) error {
+		called = true
+
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+}
+
+func TestDoCircuitOpen(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+	service.breaker = newCircuitBreaker(nil, 1, time.Minute, 1)
+	service.breaker.record(io.EOF)
+
+	_, err := service.Do("OBJECT", "HELP")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+func TestMockServiceDo(t *testing.T) {
+	mock := NewMockService()
+	mock.DoFunc = func(commandName string, args ...interface{}) (interface{}, error) {
+		return int64(3), nil
+	}
+
+	count, err := redis.Int(mock.Do("HSTRLEN", "key", "field"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []MockDoCall{{CommandName: "HSTRLEN", Args: []interface{}{"key", "field"}}}, mock.DoCalls)
+}
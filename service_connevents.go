@@ -0,0 +1,137 @@
+package gousuredis
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// OnConnect registers a callback which is called for every new connection
+//
+// addr is the address of the pool, which is the name of the master for sentinel pools
+// and the hostname for pools discovered via DNS. Connection callbacks are called while
+// dialing or borrowing connections, so they should not block.
+func (s *Service) OnConnect(fn func(addr string)) {
+	s.connEventsMutex.Lock()
+	defer s.connEventsMutex.Unlock()
+
+	s.connectCallbacks = append(s.connectCallbacks, fn)
+}
+
+// OnDisconnect registers a callback which is called with the cause if a pooled connection
+// turned out to be broken when borrowed, or if dialing fails after connections succeeded
+//
+// Failed dials are reported once until a connection succeeds again, see OnConnect.
+func (s *Service) OnDisconnect(fn func(addr string, err error)) {
+	s.connEventsMutex.Lock()
+	defer s.connEventsMutex.Unlock()
+
+	s.disconnectCallbacks = append(s.disconnectCallbacks, fn)
+}
+
+// OnReconnect registers a callback which is called for the first new connection after a
+// disconnect or failed dial, see OnConnect
+func (s *Service) OnReconnect(fn func(addr string)) {
+	s.connEventsMutex.Lock()
+	defer s.connEventsMutex.Unlock()
+
+	s.reconnectCallbacks = append(s.reconnectCallbacks, fn)
+}
+
+// poolWatcher tracks the connectivity of a pool for the connection callbacks
+type poolWatcher struct {
+	service *Service
+	addr    string
+
+	mutex     sync.Mutex
+	connected bool
+	lost      bool
+}
+
+// watchPool wraps Dial and TestOnBorrow of a pool to call the connection callbacks
+func (s *Service) watchPool(addr string, pool *redis.Pool) {
+	watcher := &poolWatcher{
+		service: s,
+		addr:    addr,
+	}
+
+	dial := pool.Dial
+	testOnBorrow := pool.TestOnBorrow
+
+	pool.Dial = func() (redis.Conn, error) {
+		conn, err := dial()
+		if err != nil {
+			watcher.dialFailed(err)
+
+			return nil, err
+		}
+
+		watcher.dialed()
+
+		return conn, nil
+	}
+
+	if testOnBorrow != nil {
+		pool.TestOnBorrow = func(c redis.Conn, t time.Time) error {
+			err := testOnBorrow(c, t)
+			if err != nil {
+				watcher.broken(err)
+			}
+
+			return err
+		}
+	}
+}
+
+func (w *poolWatcher) dialed() {
+	w.mutex.Lock()
+	reconnected := w.lost
+	w.connected = true
+	w.lost = false
+	w.mutex.Unlock()
+
+	w.service.connEventsMutex.Lock()
+	connectCallbacks := w.service.connectCallbacks
+	reconnectCallbacks := w.service.reconnectCallbacks
+	w.service.connEventsMutex.Unlock()
+
+	for _, callback := range connectCallbacks {
+		callback(w.addr)
+	}
+
+	if reconnected {
+		for _, callback := range reconnectCallbacks {
+			callback(w.addr)
+		}
+	}
+}
+
+func (w *poolWatcher) dialFailed(err error) {
+	w.mutex.Lock()
+	disconnected := w.connected && !w.lost
+	w.lost = true
+	w.mutex.Unlock()
+
+	if disconnected {
+		w.service.dispatchDisconnect(w.addr, err)
+	}
+}
+
+func (w *poolWatcher) broken(err error) {
+	w.mutex.Lock()
+	w.lost = true
+	w.mutex.Unlock()
+
+	w.service.dispatchDisconnect(w.addr, err)
+}
+
+func (s *Service) dispatchDisconnect(addr string, err error) {
+	s.connEventsMutex.Lock()
+	callbacks := s.disconnectCallbacks
+	s.connEventsMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback(addr, err)
+	}
+}
@@ -0,0 +1,107 @@
+package gousuredis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchPool(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	events := []string{}
+	service.OnConnect(func(addr string) {
+		events = append(events, "connect "+addr)
+	})
+	service.OnDisconnect(func(addr string, err error) {
+		events = append(events, "disconnect "+addr+": "+err.Error())
+	})
+	service.OnReconnect(func(addr string) {
+		events = append(events, "reconnect "+addr)
+	})
+
+	var dialErr error
+	dialed := []*fakeConn{}
+
+	pool := &redis.Pool{
+		MaxIdle: 1,
+		Dial: func() (redis.Conn, error) {
+			if dialErr != nil {
+				return nil, dialErr
+			}
+
+			conn := &fakeConn{}
+			dialed = append(dialed, conn)
+
+			return conn, nil
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if c.(*fakeConn).dead {
+				return errors.New("connection reset")
+			}
+
+			return nil
+		},
+	}
+
+	service.watchPool("redis:6379", pool)
+
+	conn := pool.Get()
+	require.NoError(t, conn.Err())
+	conn.Close()
+
+	assert.Equal(t, []string{"connect redis:6379"}, events)
+
+	// the broken idle connection is replaced
+	events = []string{}
+	dialed[0].dead = true
+
+	conn = pool.Get()
+	require.NoError(t, conn.Err())
+	conn.Close()
+
+	assert.Equal(t, []string{
+		"disconnect redis:6379: connection reset",
+		"connect redis:6379",
+		"reconnect redis:6379",
+	}, events)
+
+	// failed dials are reported once
+	events = []string{}
+	dialErr = errors.New("connection refused")
+
+	idleConn := pool.Get()
+	require.NoError(t, idleConn.Err())
+
+	for i := 0; i < 2; i++ {
+		conn = pool.Get()
+		assert.EqualError(t, conn.Err(), "connection refused")
+		conn.Close()
+	}
+
+	idleConn.Close()
+
+	assert.Equal(t, []string{"disconnect redis:6379: connection refused"}, events)
+
+	events = []string{}
+	dialErr = nil
+
+	// the idle connection is reused, the second one is dialed
+	conn = pool.Get()
+	require.NoError(t, conn.Err())
+
+	conn2 := pool.Get()
+	require.NoError(t, conn2.Err())
+
+	conn.Close()
+	conn2.Close()
+
+	assert.Equal(t, []string{
+		"connect redis:6379",
+		"reconnect redis:6379",
+	}, events)
+}
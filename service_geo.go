@@ -0,0 +1,223 @@
+package gousuredis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// GeoUnit is the unit of distances and radiuses of geo commands
+type GeoUnit string
+
+const (
+	GeoUnitMeters     GeoUnit = "m"
+	GeoUnitKilometers GeoUnit = "km"
+	GeoUnitMiles      GeoUnit = "mi"
+	GeoUnitFeet       GeoUnit = "ft"
+)
+
+// GeoPosition is a position given by longitude and latitude
+type GeoPosition struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// GeoLocation is a named position stored in a geo set
+type GeoLocation struct {
+	Name      string
+	Longitude float64
+	Latitude  float64
+}
+
+// GeoSearchQuery defines the area searched by GeoSearch
+//
+// The center is either the position of FromMember or FromLongitude/FromLatitude, the area is
+// either a circle with Radius or a box with Width and Height.
+type GeoSearchQuery struct {
+	FromMember    string
+	FromLongitude float64
+	FromLatitude  float64
+
+	Radius float64
+	Width  float64
+	Height float64
+	Unit   GeoUnit
+
+	// Count limits the number of results (0 = unlimited)
+	Count int
+	// Descending sorts the results from the farthest to the nearest
+	Descending bool
+}
+
+// GeoSearchResult is a location found by GeoSearch or GeoRadius
+type GeoSearchResult struct {
+	Name      string
+	Distance  float64
+	Longitude float64
+	Latitude  float64
+}
+
+func geoPositionReply(reply interface{}, err error) (*GeoPosition, error) {
+	if reply == nil && err == nil {
+		return nil, nil
+	}
+
+	coordinates, err := redis.Float64s(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(coordinates) != 2 {
+		return nil, fmt.Errorf("expected 2 coordinates, got %d", len(coordinates))
+	}
+
+	return &GeoPosition{
+		Longitude: coordinates[0],
+		Latitude:  coordinates[1],
+	}, nil
+}
+
+// geoSearchReply converts the reply of a search with WITHDIST and WITHCOORD
+func geoSearchReply(reply interface{}, err error) ([]GeoSearchResult, error) {
+	items, err := redis.Values(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]GeoSearchResult, 0, len(items))
+	for _, item := range items {
+		result := GeoSearchResult{}
+		var coordinates interface{}
+
+		fields, err := redis.Values(item, nil)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse geo search result: %s", err)
+		}
+
+		_, err = redis.Scan(fields, &result.Name, &result.Distance, &coordinates)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse geo search result: %s", err)
+		}
+
+		position, err := geoPositionReply(coordinates, nil)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse geo search result: %s", err)
+		}
+
+		if position != nil {
+			result.Longitude = position.Longitude
+			result.Latitude = position.Latitude
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GeoAdd adds locations to a geo set and returns the number of newly added locations
+func (s *Service) GeoAdd(key string, locations ...GeoLocation) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{}.Add(key)
+	for _, location := range locations {
+		args = args.Add(location.Longitude, location.Latitude, location.Name)
+	}
+
+	return redis.Int(conn.Do("GEOADD", args...))
+}
+
+// GeoPos loads the positions of members of a geo set
+//
+// The positions are returned in the order of the members, missing members are nil
+func (s *Service) GeoPos(key string, members ...string) ([]*GeoPosition, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	items, err := redis.Values(conn.Do("GEOPOS", redis.Args{}.Add(key).AddFlat(members)...))
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*GeoPosition, len(items))
+	for i, item := range items {
+		positions[i], err = geoPositionReply(item, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return positions, nil
+}
+
+// GeoDist returns the distance between two members of a geo set
+//
+// Returns ErrNil if one of the members doesn't exist
+func (s *Service) GeoDist(key string, member1 string, member2 string, unit GeoUnit) (float64, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Float64(conn.Do("GEODIST", key, member1, member2, string(unit)))
+}
+
+// GeoSearch finds the locations of a geo set within a circle or box (redis >= 6.2)
+//
+// The results are sorted by distance to the center
+func (s *Service) GeoSearch(key string, query GeoSearchQuery) ([]GeoSearchResult, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{}.Add(key)
+
+	if query.FromMember != "" {
+		args = args.Add("FROMMEMBER", query.FromMember)
+	} else {
+		args = args.Add("FROMLONLAT", query.FromLongitude, query.FromLatitude)
+	}
+
+	if query.Radius > 0 {
+		args = args.Add("BYRADIUS", query.Radius, string(query.Unit))
+	} else {
+		args = args.Add("BYBOX", query.Width, query.Height, string(query.Unit))
+	}
+
+	if query.Descending {
+		args = args.Add("DESC")
+	} else {
+		args = args.Add("ASC")
+	}
+
+	if query.Count > 0 {
+		args = args.Add("COUNT", query.Count)
+	}
+
+	args = args.Add("WITHDIST", "WITHCOORD")
+
+	return geoSearchReply(conn.Do("GEOSEARCH", args...))
+}
+
+// GeoRadius finds the locations of a geo set within a radius around a position
+//
+// The results are sorted by distance to the center. Use GeoSearch on redis >= 6.2.
+func (s *Service) GeoRadius(key string, longitude float64, latitude float64, radius float64, unit GeoUnit) ([]GeoSearchResult, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return geoSearchReply(conn.Do("GEORADIUS", key, longitude, latitude, radius, string(unit), "WITHDIST", "WITHCOORD", "ASC"))
+}
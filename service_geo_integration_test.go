@@ -0,0 +1,103 @@
+package gousuredis_test
+
+import (
+	"testing"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addGeoLocations(t *testing.T, service *gousuredis.Service) {
+	added, err := service.GeoAdd(
+		"geo1",
+		gousuredis.GeoLocation{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+		gousuredis.GeoLocation{Name: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+}
+
+func TestGeoPosDist(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	addGeoLocations(t, service)
+
+	positions, err := service.GeoPos("geo1", "Palermo", "Missing")
+	require.NoError(t, err)
+	require.Len(t, positions, 2)
+	require.NotNil(t, positions[0])
+	assert.InDelta(t, 13.361389, positions[0].Longitude, 0.0001)
+	assert.InDelta(t, 38.115556, positions[0].Latitude, 0.0001)
+	assert.Nil(t, positions[1])
+
+	distance, err := service.GeoDist("geo1", "Palermo", "Catania", gousuredis.GeoUnitKilometers)
+	require.NoError(t, err)
+	assert.InDelta(t, 166.2742, distance, 0.001)
+
+	_, err = service.GeoDist("geo1", "Palermo", "Missing", gousuredis.GeoUnitKilometers)
+	assert.Equal(t, gousuredis.ErrNil, err)
+}
+
+func TestGeoSearch(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	addGeoLocations(t, service)
+
+	results, err := service.GeoSearch("geo1", gousuredis.GeoSearchQuery{
+		FromMember: "Palermo",
+		Radius:     200,
+		Unit:       gousuredis.GeoUnitKilometers,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "Palermo", results[0].Name)
+	assert.InDelta(t, 0, results[0].Distance, 0.001)
+	assert.Equal(t, "Catania", results[1].Name)
+	assert.InDelta(t, 166.2742, results[1].Distance, 0.001)
+	assert.InDelta(t, 15.087269, results[1].Longitude, 0.0001)
+	assert.InDelta(t, 37.502669, results[1].Latitude, 0.0001)
+
+	results, err = service.GeoSearch("geo1", gousuredis.GeoSearchQuery{
+		FromLongitude: 15,
+		FromLatitude:  37,
+		Width:         400,
+		Height:        400,
+		Unit:          gousuredis.GeoUnitKilometers,
+		Count:         1,
+		Descending:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Palermo", results[0].Name)
+	assert.InDelta(t, 190.4424, results[0].Distance, 0.001)
+
+	results, err = service.GeoSearch("geo1", gousuredis.GeoSearchQuery{
+		FromLongitude: 15,
+		FromLatitude:  37,
+		Radius:        100,
+		Unit:          gousuredis.GeoUnitKilometers,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Catania", results[0].Name)
+}
+
+func TestGeoRadius(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	addGeoLocations(t, service)
+
+	results, err := service.GeoRadius("geo1", 15, 37, 200, gousuredis.GeoUnitKilometers)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "Catania", results[0].Name)
+	assert.InDelta(t, 56.4413, results[0].Distance, 0.001)
+	assert.Equal(t, "Palermo", results[1].Name)
+	assert.InDelta(t, 190.4424, results[1].Distance, 0.001)
+
+	results, err = service.GeoRadius("geo1", 15, 37, 10, gousuredis.GeoUnitKilometers)
+	require.NoError(t, err)
+	assert.Len(t, results, 0)
+}
@@ -0,0 +1,38 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoSearchReply(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{
+			[]byte("Palermo"),
+			[]byte("190.4424"),
+			[]interface{}{[]byte("13.36138933897018433"), []byte("38.11555639549629859")},
+		},
+		[]interface{}{
+			[]byte("Catania"),
+			[]byte("56.4413"),
+			[]interface{}{[]byte("15.08726745843887329"), []byte("37.50266842333162032")},
+		},
+	}
+
+	results, err := geoSearchReply(reply, nil)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Palermo", results[0].Name)
+	assert.InDelta(t, 190.4424, results[0].Distance, 0.0001)
+	assert.InDelta(t, 13.3614, results[0].Longitude, 0.0001)
+	assert.InDelta(t, 38.1156, results[0].Latitude, 0.0001)
+	assert.Equal(t, "Catania", results[1].Name)
+
+	position, err := geoPositionReply(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, position)
+
+	_, err = geoSearchReply([]interface{}{[]byte("invalid")}, nil)
+	assert.Error(t, err)
+}
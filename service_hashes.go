@@ -0,0 +1,313 @@
+package gousuredis
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// HGetAll loads all fields and values of a hash
+//
+// Returns an empty map if the key doesn't exist
+func (s *Service) HGetAll(key string) (map[string][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	values, err := hashReply(conn.Do("HGETALL", key))
+
+	return s.decryptHash(key, values, err)
+}
+
+// HMGet loads the values of multiple fields of a hash
+//
+// The values are returned in the order of the fields, missing fields are nil
+func (s *Service) HMGet(key string, fields ...string) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	values, err := redis.ByteSlices(conn.Do("HMGET", redis.Args{}.Add(key).AddFlat(fields)...))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, data := range values {
+		if data == nil {
+			continue
+		}
+
+		values[i], err = s.decryptField(key, fields[i], data, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// HMSet stores multiple fields and their values in a hash
+func (s *Service) HMSet(key string, values map[string][]byte) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{}.Add(key)
+	for field, data := range values {
+		data, err = s.encryptField(key, field, data)
+		if err != nil {
+			return err
+		}
+
+		args = args.Add(field, data)
+	}
+
+	_, err = conn.Do("HSET", args...)
+
+	return err
+}
+
+// HSetMulti stores multiple fields and their values in a hash via a single HSET
+//
+// Returns the number of fields added (not counting updated fields)
+func (s *Service) HSetMulti(key string, values map[string][]byte) (int, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{}.Add(key)
+	for field, data := range values {
+		data, err = s.encryptField(key, field, data)
+		if err != nil {
+			return 0, err
+		}
+
+		args = args.Add(field, data)
+	}
+
+	return redis.Int(conn.Do("HSET", args...))
+}
+
+// HSetStruct stores the exported fields of a struct in a hash
+//
+// Field names and options are taken from the "redis" tags like redis.Args.AddFlat does
+// (e.g. `redis:"name,omitempty"`), nested structs are not supported.
+func (s *Service) HSetStruct(key string, v interface{}) error {
+	flat := redis.Args{}.AddFlat(v)
+	if len(flat) == 0 {
+		return nil
+	}
+
+	if len(flat)%2 != 0 {
+		return fmt.Errorf("can't flatten %T to hash fields", v)
+	}
+
+	args := redis.Args{}.Add(key)
+	for i := 0; i < len(flat); i += 2 {
+		data, err := s.encryptField(key, string(formatArg(flat[i])), formatArg(flat[i+1]))
+		if err != nil {
+			return err
+		}
+
+		args = args.Add(flat[i], data)
+	}
+
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("HSET", args...)
+
+	return err
+}
+
+// HGetAllStruct loads all fields of a hash into the struct pointed to by dest via
+// redis.ScanStruct, fields unknown to the struct are ignored
+//
+// Returns ErrNil if the key doesn't exist
+func (s *Service) HGetAllStruct(key string, dest interface{}) error {
+	values, err := s.HGetAll(key)
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return ErrNil
+	}
+
+	src := make([]interface{}, 0, len(values)*2)
+	for field, data := range values {
+		src = append(src, []byte(field), data)
+	}
+
+	return redis.ScanStruct(src, dest)
+}
+
+// formatArg formats an argument like redigo does when writing a command, so it can
+// be encrypted
+func formatArg(arg interface{}) []byte {
+	switch value := arg.(type) {
+	case []byte:
+		return value
+	case string:
+		return []byte(value)
+	case int:
+		return strconv.AppendInt(nil, int64(value), 10)
+	case int64:
+		return strconv.AppendInt(nil, value, 10)
+	case float64:
+		return strconv.AppendFloat(nil, value, 'g', -1, 64)
+	case bool:
+		if value {
+			return []byte("1")
+		}
+
+		return []byte("0")
+	case nil:
+		return []byte{}
+	case redis.Argument:
+		return formatArg(value.RedisArg())
+	default:
+		return []byte(fmt.Sprint(value))
+	}
+}
+
+// HSetNX stores a field and its value in a hash if the field does not exist
+//
+// Returns true if the field was set
+func (s *Service) HSetNX(key string, field string, data []byte) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	data, err = s.encryptField(key, field, data)
+	if err != nil {
+		return false, err
+	}
+
+	return redis.Bool(conn.Do("HSETNX", key, field, data))
+}
+
+// HIncrBy increments the integer value of a hash field and returns the new value
+func (s *Service) HIncrBy(key string, field string, increment int) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("HINCRBY", key, field, increment))
+}
+
+// HIncrByFloat increments the float value of a hash field and returns the new value
+func (s *Service) HIncrByFloat(key string, field string, increment float64) (float64, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Float64(conn.Do("HINCRBYFLOAT", key, field, increment))
+}
+
+// collectionScanArgs returns the arguments of HSCAN, SSCAN and ZSCAN
+func collectionScanArgs(key string, cursor int, match string, count int) redis.Args {
+	args := redis.Args{key, cursor}
+
+	if match != "" {
+		args = args.Add("MATCH", match)
+	}
+
+	if count > 0 {
+		args = args.Add("COUNT", count)
+	}
+
+	return args
+}
+
+// HScanMatch scans a hash map for fields matching the glob-style pattern (all if empty)
+// and returns the next cursor and a map of fields and values
+//
+// count is a hint for the number of fields checked per call (0 uses the server default)
+func (s *Service) HScanMatch(key string, cursor int, match string, count int) (int, map[string][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return s.hscan(conn, key, cursor, match, count)
+}
+
+func (s *Service) hscan(conn redis.Conn, key string, cursor int, match string, count int) (int, map[string][]byte, error) {
+	arr := make([][]byte, 0)
+
+	resp, err := redis.Values(conn.Do("HSCAN", collectionScanArgs(key, cursor, match, count)...))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	_, err = redis.Scan(resp, &cursor, &arr)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	keyValues := map[string][]byte{}
+	for i := 1; i < len(arr); i += 2 {
+		keyValues[string(arr[i-1])] = arr[i]
+	}
+
+	keyValues, err = s.decryptHash(key, keyValues, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return cursor, keyValues, nil
+}
+
+// HScanAll loads all fields matching the glob-style pattern (all if empty) of a hash map
+// by iterating HSCAN, so large hashes don't block the server
+func (s *Service) HScanAll(key string, match string) (map[string][]byte, error) {
+	conn, err := s.openPinnedReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	result := map[string][]byte{}
+	cursor := 0
+
+	for {
+		var values map[string][]byte
+
+		cursor, values, err = s.hscan(conn, key, cursor, match, scanBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for field, data := range values {
+			result[field] = data
+		}
+
+		if cursor == 0 {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package gousuredis_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashes(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.HMSet("hash", map[string][]byte{
+		"field1": []byte("value1"),
+		"field2": []byte("value2"),
+	}))
+
+	values, err := service.HGetAll("hash")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"field1": []byte("value1"), "field2": []byte("value2")}, values)
+
+	values, err = service.HGetAll("missing")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	list, err := service.HMGet("hash", "field2", "missing", "field1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value2"), nil, []byte("value1")}, list)
+
+	set, err := service.HSetNX("hash", "field1", []byte("other"))
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	set, err = service.HSetNX("hash", "field3", []byte("value3"))
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	value, err := service.HGet("hash", "field1")
+	require.NoError(t, err)
+	assert.Equal(t, "value1", string(value))
+
+	count, err := service.HIncrBy("hash", "counter", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	count, err = service.HIncrBy("hash", "counter", -2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	float, err := service.HIncrByFloat("hash", "float", 1.5)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, float)
+
+	float, err = service.HIncrByFloat("hash", "float", 0.25)
+	require.NoError(t, err)
+	assert.Equal(t, 1.75, float)
+
+	_, err = service.HIncrBy("hash", "field1", 1)
+	assert.True(t, gousuredis.IsServerError(err))
+}
+
+func TestHashesEncrypted(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.EncryptionKeys = []string{base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))}
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	require.NoError(t, service.HMSet("hash", map[string][]byte{
+		"field1": []byte("value1"),
+		"field2": []byte("value2"),
+	}))
+
+	set, err := service.HSetNX("hash", "field3", []byte("value3"))
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	values, err := service.HGetAll("hash")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"field1": []byte("value1"),
+		"field2": []byte("value2"),
+		"field3": []byte("value3"),
+	}, values)
+
+	list, err := service.HMGet("hash", "field3", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value3"), nil}, list)
+
+	// Values are stored encrypted
+	raw, err := service.Do("HGET", "hash", "field1")
+	require.NoError(t, err)
+	assert.NotEqual(t, "value1", string(raw.([]byte)))
+}
+
+func TestHashScan(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	values := map[string][]byte{}
+	for i := 0; i < 200; i++ {
+		values[fmt.Sprintf("user:%d", i)] = []byte(fmt.Sprintf("value%d", i))
+	}
+	values["other"] = []byte("other")
+
+	require.NoError(t, service.HMSet("hash", values))
+
+	scanned := map[string][]byte{}
+	cursor := 0
+	calls := 0
+
+	for {
+		var batch map[string][]byte
+		var err error
+
+		cursor, batch, err = service.HScanMatch("hash", cursor, "user:*", 10)
+		require.NoError(t, err)
+
+		for field, data := range batch {
+			scanned[field] = data
+		}
+
+		calls++
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	assert.Len(t, scanned, 200)
+	assert.Equal(t, []byte("value42"), scanned["user:42"])
+	assert.NotContains(t, scanned, "other")
+	assert.Greater(t, calls, 1)
+
+	all, err := service.HScanAll("hash", "")
+	require.NoError(t, err)
+	assert.Equal(t, values, all)
+
+	all, err = service.HScanAll("hash", "user:1?")
+	require.NoError(t, err)
+	assert.Len(t, all, 10)
+
+	all, err = service.HScanAll("missing", "")
+	require.NoError(t, err)
+	assert.Len(t, all, 0)
+}
+
+func TestHashScanEncrypted(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.EncryptionKeys = []string{base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))}
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	values := map[string][]byte{
+		"field1": []byte("value1"),
+		"field2": []byte("value2"),
+	}
+
+	require.NoError(t, service.HMSet("hash", values))
+
+	_, scanned, err := service.HScanMatch("hash", 0, "field1", 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"field1": []byte("value1")}, scanned)
+
+	all, err := service.HScanAll("hash", "")
+	require.NoError(t, err)
+	assert.Equal(t, values, all)
+}
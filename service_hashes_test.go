@@ -0,0 +1,39 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+type hashTestStruct struct {
+	Name   string  `redis:"name"`
+	Count  int     `redis:"count"`
+	Score  float64 `redis:"score"`
+	Active bool    `redis:"active"`
+}
+
+func TestFormatArg(t *testing.T) {
+	value := hashTestStruct{Name: "test", Count: 3, Score: 1.5, Active: true}
+
+	flat := redis.Args{}.AddFlat(&value)
+
+	src := []interface{}{}
+	for i := 0; i < len(flat); i += 2 {
+		src = append(src, []byte(flat[i].(string)), formatArg(flat[i+1]))
+	}
+
+	scanned := hashTestStruct{}
+	assert.NoError(t, redis.ScanStruct(src, &scanned))
+	assert.Equal(t, value, scanned)
+
+	assert.Equal(t, []byte("-7"), formatArg(int64(-7)))
+	assert.Equal(t, []byte{}, formatArg(nil))
+}
+
+func TestHSetStructInvalid(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	assert.Error(t, service.HSetStruct("key", "value"))
+}
@@ -0,0 +1,148 @@
+package gousuredis
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// HealthDetails contains the health indicators of redis returned by HealthDetails
+type HealthDetails struct {
+	// Latency is the round trip time of a PING
+	Latency time.Duration
+	// Role is the replication role of the instance ("master" or "slave")
+	Role string
+	// ReplicationLag is the time since the last interaction with the master on a replica
+	// or the highest lag of all connected replicas on a master
+	ReplicationLag time.Duration
+	// MasterLinkDown is set on replicas which lost the connection to their master
+	MasterLinkDown bool
+	UsedMemory     int64
+	// MaxMemory is 0 if no memory limit is configured
+	MaxMemory int64
+	// MemoryUsage is the share (0 - 1) of MaxMemory used, 0 if no memory limit is configured
+	MemoryUsage float64
+	// RejectedConnections is the number of connections rejected due to maxclients since
+	// the previous health check
+	RejectedConnections int64
+	// Problems describes all exceeded thresholds, empty if redis is healthy
+	Problems []string
+}
+
+// Healthy checks if no threshold was exceeded
+func (d *HealthDetails) Healthy() bool {
+	return len(d.Problems) == 0
+}
+
+// healthChecksEnabled checks if any health threshold is configured
+func (s *Service) healthChecksEnabled() bool {
+	return s.options.HealthMaxLatency > 0 ||
+		s.options.HealthMaxReplicationLag > 0 ||
+		s.options.HealthMaxMemoryUsage > 0 ||
+		s.options.HealthFailOnRejectedConnections
+}
+
+// HealthDetails pings redis, loads its health indicators via INFO and checks them against
+// the thresholds configured via the redis_health_* flags
+//
+// In cluster mode the details of a single node are returned.
+func (s *Service) HealthDetails() (*HealthDetails, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+
+	_, err = conn.Do("PING")
+	if err != nil {
+		return nil, err
+	}
+
+	details := &HealthDetails{
+		Latency: time.Since(start),
+	}
+
+	text, err := redis.String(conn.Do("INFO"))
+	if err != nil {
+		return nil, err
+	}
+
+	info := parseInfo(text)
+
+	details.Role = info.Role
+	details.UsedMemory = info.UsedMemory
+	details.MaxMemory = info.MaxMemory
+
+	if info.MaxMemory > 0 {
+		details.MemoryUsage = float64(info.UsedMemory) / float64(info.MaxMemory)
+	}
+
+	if info.Role == "slave" {
+		details.ReplicationLag = time.Duration(info.MasterLastIOSecondsAgo) * time.Second
+		details.MasterLinkDown = info.MasterLinkStatus != "up"
+	}
+
+	for _, replica := range info.Replicas {
+		lag := time.Duration(replica.Lag) * time.Second
+		if lag > details.ReplicationLag {
+			details.ReplicationLag = lag
+		}
+	}
+
+	// the counter is stored incremented by 1, so 0 marks the first check
+	previousRejected := atomic.SwapInt64(&s.rejectedConnections, info.RejectedConnections+1) - 1
+	if previousRejected >= 0 && info.RejectedConnections > previousRejected {
+		details.RejectedConnections = info.RejectedConnections - previousRejected
+	}
+
+	s.checkHealthDetails(details)
+
+	return details, nil
+}
+
+// checkHealthDetails adds a problem for each exceeded threshold
+func (s *Service) checkHealthDetails(details *HealthDetails) {
+	if s.options.HealthMaxLatency > 0 && details.Latency > s.options.HealthMaxLatency {
+		details.Problems = append(details.Problems, fmt.Sprintf("latency %s exceeds %s", details.Latency, s.options.HealthMaxLatency))
+	}
+
+	if s.options.HealthMaxReplicationLag > 0 {
+		if details.MasterLinkDown {
+			details.Problems = append(details.Problems, "link to master down")
+		}
+
+		if details.ReplicationLag > s.options.HealthMaxReplicationLag {
+			details.Problems = append(details.Problems, fmt.Sprintf("replication lag %s exceeds %s", details.ReplicationLag, s.options.HealthMaxReplicationLag))
+		}
+	}
+
+	if s.options.HealthMaxMemoryUsage > 0 && details.MemoryUsage > s.options.HealthMaxMemoryUsage {
+		details.Problems = append(details.Problems, fmt.Sprintf("memory usage %.1f%% exceeds %.1f%%", details.MemoryUsage*100, s.options.HealthMaxMemoryUsage*100))
+	}
+
+	if s.options.HealthFailOnRejectedConnections && details.RejectedConnections > 0 {
+		details.Problems = append(details.Problems, fmt.Sprintf("%d connections rejected", details.RejectedConnections))
+	}
+}
+
+// checkHealthThresholds is called by Health if any threshold is configured
+func (s *Service) checkHealthThresholds() error {
+	details, err := s.HealthDetails()
+	if IsAuthError(err) {
+		return fmt.Errorf("redis service unhealthy, authentication failed: %s", err)
+	}
+	if err != nil {
+		return fmt.Errorf("redis service unhealthy: %w", err)
+	}
+
+	if !details.Healthy() {
+		return fmt.Errorf("redis service unhealthy: %s", strings.Join(details.Problems, ", "))
+	}
+
+	return nil
+}
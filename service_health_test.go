@@ -0,0 +1,47 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHealthDetails(t *testing.T) {
+	options := DefaultOptions()
+
+	service := NewServiceWithOptions("redis", options)
+	assert.False(t, service.healthChecksEnabled())
+
+	options.HealthMaxLatency = 100 * time.Millisecond
+	options.HealthMaxReplicationLag = 10 * time.Second
+	options.HealthMaxMemoryUsage = 0.9
+	options.HealthFailOnRejectedConnections = true
+	assert.True(t, service.healthChecksEnabled())
+
+	details := &HealthDetails{
+		Latency:     10 * time.Millisecond,
+		Role:        "master",
+		MemoryUsage: 0.5,
+	}
+	service.checkHealthDetails(details)
+	assert.True(t, details.Healthy())
+
+	details = &HealthDetails{
+		Latency:             200 * time.Millisecond,
+		Role:                "slave",
+		ReplicationLag:      30 * time.Second,
+		MasterLinkDown:      true,
+		MemoryUsage:         0.95,
+		RejectedConnections: 3,
+	}
+	service.checkHealthDetails(details)
+	assert.False(t, details.Healthy())
+	assert.Equal(t, []string{
+		"latency 200ms exceeds 100ms",
+		"link to master down",
+		"replication lag 30s exceeds 10s",
+		"memory usage 95.0% exceeds 90.0%",
+		"3 connections rejected",
+	}, details.Problems)
+}
@@ -0,0 +1,48 @@
+package gousuredis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// PFAdd adds elements to a HyperLogLog
+//
+// Returns true if the approximated cardinality changed
+func (s *Service) PFAdd(key string, elements ...[]byte) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("PFADD", redis.Args{}.Add(key).AddFlat(elements)...))
+}
+
+// PFCount returns the approximated cardinality of the union of one or multiple HyperLogLogs
+//
+// In cluster mode all keys must belong to the same slot (e.g. by using hash tags).
+func (s *Service) PFCount(keys ...string) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("PFCOUNT", redis.Args{}.AddFlat(keys)...))
+}
+
+// PFMerge merges multiple HyperLogLogs into destKey
+//
+// In cluster mode all keys must belong to the same slot (e.g. by using hash tags).
+func (s *Service) PFMerge(destKey string, sourceKeys ...string) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("PFMERGE", redis.Args{}.Add(destKey).AddFlat(sourceKeys)...)
+
+	return err
+}
@@ -0,0 +1,43 @@
+package gousuredis_test
+
+import (
+	"testing"
+
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	changed, err := service.PFAdd("hll1", []byte("a"), []byte("b"), []byte("c"))
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = service.PFAdd("hll1", []byte("a"))
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	_, err = service.PFAdd("hll2", []byte("c"), []byte("d"))
+	require.NoError(t, err)
+
+	count, err := service.PFCount("hll1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = service.PFCount("hll1", "hll2")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	count, err = service.PFCount("missing")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	err = service.PFMerge("merged", "hll1", "hll2")
+	require.NoError(t, err)
+
+	count, err = service.PFCount("merged")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
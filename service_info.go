@@ -0,0 +1,188 @@
+package gousuredis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// InfoReplica contains the state of a replica connected to a master
+type InfoReplica struct {
+	Addr   string
+	State  string
+	Offset int64
+	Lag    int64
+}
+
+// InfoKeyspace contains the key statistics of a database
+type InfoKeyspace struct {
+	Keys    int64
+	Expires int64
+	AvgTTL  int64
+}
+
+// Info contains the parsed output of the INFO command
+//
+// Fields of sections which were not requested are left empty, all fields are
+// available as strings in Fields.
+type Info struct {
+	Fields map[string]string
+
+	// Server
+	RedisVersion  string
+	RedisMode     string
+	UptimeSeconds int64
+
+	// Clients
+	ConnectedClients int64
+	BlockedClients   int64
+
+	// Memory
+	UsedMemory            int64
+	UsedMemoryRSS         int64
+	UsedMemoryPeak        int64
+	MaxMemory             int64
+	MaxMemoryPolicy       string
+	MemFragmentationRatio float64
+
+	// Stats
+	TotalConnectionsReceived int64
+	TotalCommandsProcessed   int64
+	InstantaneousOpsPerSec   int64
+	RejectedConnections      int64
+	ExpiredKeys              int64
+	EvictedKeys              int64
+	KeyspaceHits             int64
+	KeyspaceMisses           int64
+
+	// Replication
+	Role                   string
+	ConnectedReplicas      int64
+	Replicas               []InfoReplica
+	MasterHost             string
+	MasterLinkStatus       string
+	MasterLastIOSecondsAgo int64
+	MasterReplOffset       int64
+
+	// Keyspace by database name (e.g. "db0")
+	Keyspace map[string]InfoKeyspace
+}
+
+// parseInfoInt parses an integer field, returning 0 for missing or invalid values
+func parseInfoInt(fields map[string]string, name string) int64 {
+	value, _ := strconv.ParseInt(fields[name], 10, 64)
+
+	return value
+}
+
+// parseInfoAttributes parses comma-separated attributes like "keys=1,expires=0"
+func parseInfoAttributes(value string) map[string]string {
+	attributes := map[string]string{}
+
+	for _, attribute := range strings.Split(value, ",") {
+		parts := strings.SplitN(attribute, "=", 2)
+		if len(parts) == 2 {
+			attributes[parts[0]] = parts[1]
+		}
+	}
+
+	return attributes
+}
+
+// parseInfo parses the output of the INFO command
+func parseInfo(text string) *Info {
+	info := &Info{
+		Fields:   map[string]string{},
+		Replicas: []InfoReplica{},
+		Keyspace: map[string]InfoKeyspace{},
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		name, value := parts[0], parts[1]
+		info.Fields[name] = value
+
+		if strings.HasPrefix(name, "db") {
+			attributes := parseInfoAttributes(value)
+
+			info.Keyspace[name] = InfoKeyspace{
+				Keys:    parseInfoInt(attributes, "keys"),
+				Expires: parseInfoInt(attributes, "expires"),
+				AvgTTL:  parseInfoInt(attributes, "avg_ttl"),
+			}
+		} else if strings.HasPrefix(name, "slave") && strings.Contains(value, "=") {
+			attributes := parseInfoAttributes(value)
+
+			info.Replicas = append(info.Replicas, InfoReplica{
+				Addr:   fmt.Sprintf("%s:%s", attributes["ip"], attributes["port"]),
+				State:  attributes["state"],
+				Offset: parseInfoInt(attributes, "offset"),
+				Lag:    parseInfoInt(attributes, "lag"),
+			})
+		}
+	}
+
+	fields := info.Fields
+
+	info.RedisVersion = fields["redis_version"]
+	info.RedisMode = fields["redis_mode"]
+	info.UptimeSeconds = parseInfoInt(fields, "uptime_in_seconds")
+
+	info.ConnectedClients = parseInfoInt(fields, "connected_clients")
+	info.BlockedClients = parseInfoInt(fields, "blocked_clients")
+
+	info.UsedMemory = parseInfoInt(fields, "used_memory")
+	info.UsedMemoryRSS = parseInfoInt(fields, "used_memory_rss")
+	info.UsedMemoryPeak = parseInfoInt(fields, "used_memory_peak")
+	info.MaxMemory = parseInfoInt(fields, "maxmemory")
+	info.MaxMemoryPolicy = fields["maxmemory_policy"]
+	info.MemFragmentationRatio, _ = strconv.ParseFloat(fields["mem_fragmentation_ratio"], 64)
+
+	info.TotalConnectionsReceived = parseInfoInt(fields, "total_connections_received")
+	info.TotalCommandsProcessed = parseInfoInt(fields, "total_commands_processed")
+	info.InstantaneousOpsPerSec = parseInfoInt(fields, "instantaneous_ops_per_sec")
+	info.RejectedConnections = parseInfoInt(fields, "rejected_connections")
+	info.ExpiredKeys = parseInfoInt(fields, "expired_keys")
+	info.EvictedKeys = parseInfoInt(fields, "evicted_keys")
+	info.KeyspaceHits = parseInfoInt(fields, "keyspace_hits")
+	info.KeyspaceMisses = parseInfoInt(fields, "keyspace_misses")
+
+	info.Role = fields["role"]
+	info.ConnectedReplicas = parseInfoInt(fields, "connected_slaves")
+	info.MasterHost = fields["master_host"]
+	info.MasterLinkStatus = fields["master_link_status"]
+	info.MasterLastIOSecondsAgo = parseInfoInt(fields, "master_last_io_seconds_ago")
+	info.MasterReplOffset = parseInfoInt(fields, "master_repl_offset")
+
+	return info
+}
+
+// Info runs the INFO command for the sections (e.g. "memory", "replication", all default
+// sections if empty) and parses the result
+//
+// In cluster mode the info of a single node is returned.
+func (s *Service) Info(section ...string) (*Info, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	text, err := redis.String(conn.Do("INFO", redis.Args{}.AddFlat(section)...))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInfo(text), nil
+}
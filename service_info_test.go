@@ -0,0 +1,41 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInfo(t *testing.T) {
+	text := "# Server\r\n" +
+		"redis_version:7.2.4\r\n" +
+		"uptime_in_seconds:3600\r\n" +
+		"\r\n" +
+		"# Clients\r\n" +
+		"connected_clients:12\r\n" +
+		"\r\n" +
+		"# Memory\r\n" +
+		"used_memory:1048576\r\n" +
+		"mem_fragmentation_ratio:1.25\r\n" +
+		"\r\n" +
+		"# Replication\r\n" +
+		"role:master\r\n" +
+		"connected_slaves:1\r\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=1234,lag=1\r\n" +
+		"\r\n" +
+		"# Keyspace\r\n" +
+		"db0:keys=42,expires=3,avg_ttl=1000\r\n"
+
+	info := parseInfo(text)
+
+	assert.Equal(t, "7.2.4", info.RedisVersion)
+	assert.Equal(t, int64(3600), info.UptimeSeconds)
+	assert.Equal(t, int64(12), info.ConnectedClients)
+	assert.Equal(t, int64(1048576), info.UsedMemory)
+	assert.Equal(t, 1.25, info.MemFragmentationRatio)
+	assert.Equal(t, "master", info.Role)
+	assert.Equal(t, int64(1), info.ConnectedReplicas)
+	assert.Equal(t, []InfoReplica{{Addr: "10.0.0.2:6379", State: "online", Offset: 1234, Lag: 1}}, info.Replicas)
+	assert.Equal(t, InfoKeyspace{Keys: 42, Expires: 3, AvgTTL: 1000}, info.Keyspace["db0"])
+	assert.Equal(t, "1048576", info.Fields["used_memory"])
+}
@@ -0,0 +1,80 @@
+package gousuredis
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetJSON retrieves a key's value from redis and unmarshals it into dest
+//
+// Returns ErrNil if the key doesn't exist
+func (s *Service) GetJSON(key string, dest interface{}) error {
+	data, err := s.Get(key)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, dest)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal json: %s", err)
+	}
+
+	return nil
+}
+
+// SetJSON marshals a value to json and stores it in redis
+func (s *Service) SetJSON(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("can't marshal json: %s", err)
+	}
+
+	return s.Set(key, data)
+}
+
+// SetJSONPX marshals a value to json and stores it with expiration time in redis
+func (s *Service) SetJSONPX(key string, v interface{}, timeoutMS int) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("can't marshal json: %s", err)
+	}
+
+	return s.SetPX(key, data, timeoutMS)
+}
+
+// SetJSONNXPX marshals a value to json and stores it if the key does not exist with expiration time in redis
+func (s *Service) SetJSONNXPX(key string, v interface{}, timeoutMS int) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("can't marshal json: %s", err)
+	}
+
+	return s.SetNXPX(key, data, timeoutMS)
+}
+
+// HGetJSON retrieves a hash value from redis and unmarshals it into dest
+//
+// Returns ErrNil if the key or field doesn't exist
+func (s *Service) HGetJSON(key string, field string, dest interface{}) error {
+	data, err := s.HGet(key, field)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, dest)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal json: %s", err)
+	}
+
+	return nil
+}
+
+// HSetJSON marshals a value to json and stores it in a hash in redis
+func (s *Service) HSetJSON(key string, field string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("can't marshal json: %s", err)
+	}
+
+	return s.HSet(key, field, data)
+}
@@ -0,0 +1,354 @@
+package gousuredis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+)
+
+// notificationDB returns the database of keyspace / keyevent notifications to subscribe to,
+// all databases if redis_db isn't set
+func (s *Service) notificationDB() string {
+	if s.options.DB == 0 {
+		return "*"
+	}
+
+	return strconv.Itoa(s.options.DB)
+}
+
+type expireCallback struct {
+	pattern string
+	fn      func(key string)
+}
+
+// KeyEventType is the name of the command / event that changed a key (e.g. "set", "del", "expired")
+type KeyEventType = string
+
+const (
+	KeyEventSet     KeyEventType = "set"
+	KeyEventDel     KeyEventType = "del"
+	KeyEventExpired KeyEventType = "expired"
+)
+
+// KeyEvent is emitted by WatchKey when the watched key changes
+type KeyEvent struct {
+	Key   string
+	Event KeyEventType
+}
+
+// OnExpire registers a callback which is called for every expired key matching the glob-style pattern
+//
+// The callbacks are driven by keyevent notifications, so the redis server must have them
+// enabled (e.g. notify-keyspace-events "Ex"). The notification subscription is started with
+// the first registered callback (or by Start for callbacks registered before) and resubscribed
+// automatically if the connection fails. Callbacks are called sequentially and should not block.
+//
+// In cluster mode notifications are only published on the node owning the key, but only a
+// single node is subscribed to, so only the keys expiring on that node are reported.
+func (s *Service) OnExpire(pattern string, fn func(key string)) {
+	s.expireMutex.Lock()
+	s.expireCallbacks = append(s.expireCallbacks, expireCallback{
+		pattern: pattern,
+		fn:      fn,
+	})
+	s.expireMutex.Unlock()
+
+	s.startExpireListener()
+}
+
+// startExpireListener subscribes to expired events once the service is started and
+// a callback is registered
+func (s *Service) startExpireListener() {
+	if !s.isStarted() {
+		return
+	}
+
+	s.expireMutex.Lock()
+	defer s.expireMutex.Unlock()
+
+	if s.expireListening || len(s.expireCallbacks) == 0 {
+		return
+	}
+
+	s.expireListening = true
+
+	pattern := fmt.Sprintf("__keyevent@%s__:expired", s.notificationDB())
+
+	s.goWorker("expire listener", func() {
+		s.withoutContext().runPatternListener(pattern, "", nil, nil, func(msg redis.Message) {
+			key := string(msg.Data)
+			if !strings.HasPrefix(key, s.options.KeyPrefix) {
+				return
+			}
+
+			s.dispatchExpired(s.stripKeyPrefix(key))
+		})
+	})
+}
+
+func (s *Service) dispatchExpired(key string) {
+	s.expireMutex.Lock()
+	callbacks := s.expireCallbacks
+	s.expireMutex.Unlock()
+
+	for _, callback := range callbacks {
+		if !matchPattern(callback.pattern, key) {
+			continue
+		}
+
+		callback.fn(key)
+	}
+}
+
+// WatchKey emits an event every time the key is changed, deleted or expires
+//
+// The events are driven by keyspace notifications, so the redis server must have them
+// enabled (e.g. notify-keyspace-events "KA"). The returned channel is closed after the
+// context got cancelled or the service got stopped.
+//
+// In cluster mode notifications are only published on the node owning the key, so the
+// subscription is bound to the node serving the slot of the key.
+func (s *Service) WatchKey(ctx context.Context, key string) (<-chan KeyEvent, error) {
+	pattern := fmt.Sprintf("__keyspace@%s__:%s", s.notificationDB(), escapePattern(s.options.KeyPrefix+key))
+	bindKey := s.options.KeyPrefix + key
+
+	s = s.withoutContext()
+
+	psc, err := s.subscribePattern(pattern, bindKey)
+	if err != nil {
+		return nil, fmt.Errorf("can't subscribe to keyspace notifications: %s", err)
+	}
+
+	output := make(chan KeyEvent, 16)
+
+	s.goWorker("key watcher", func() {
+		defer close(output)
+
+		s.runPatternListener(pattern, bindKey, psc, ctx.Done(), func(msg redis.Message) {
+			select {
+			case output <- KeyEvent{Key: key, Event: string(msg.Data)}:
+			case <-ctx.Done():
+			case <-s.done:
+			}
+		})
+	})
+
+	return output, nil
+}
+
+// escapePattern escapes all glob-style special characters in a key
+func escapePattern(key string) string {
+	var builder strings.Builder
+
+	for _, r := range key {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			builder.WriteRune('\\')
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// matchPattern checks if a key matches a glob-style pattern the same way redis does (e.g. for
+// KEYS and PSUBSCRIBE), so '*' also matches '/' and '[^a-c]' excludes a range
+func matchPattern(pattern string, key string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+
+			if len(pattern) == 1 {
+				return true
+			}
+
+			for i := 0; i <= len(key); i++ {
+				if matchPattern(pattern[1:], key[i:]) {
+					return true
+				}
+			}
+
+			return false
+		case '?':
+			if len(key) == 0 {
+				return false
+			}
+
+			key = key[1:]
+		case '[':
+			if len(key) == 0 {
+				return false
+			}
+
+			pattern = pattern[1:]
+
+			negate := len(pattern) > 0 && pattern[0] == '^'
+			if negate {
+				pattern = pattern[1:]
+			}
+
+			matches := false
+
+			for len(pattern) > 0 && pattern[0] != ']' {
+				switch {
+				case pattern[0] == '\\' && len(pattern) > 1:
+					pattern = pattern[1:]
+					matches = matches || pattern[0] == key[0]
+				case len(pattern) > 2 && pattern[1] == '-':
+					start, end := pattern[0], pattern[2]
+					if start > end {
+						start, end = end, start
+					}
+
+					matches = matches || (key[0] >= start && key[0] <= end)
+					pattern = pattern[2:]
+				default:
+					matches = matches || pattern[0] == key[0]
+				}
+
+				pattern = pattern[1:]
+			}
+
+			if matches == negate {
+				return false
+			}
+
+			key = key[1:]
+
+			// an unterminated class ends the pattern
+			if len(pattern) == 0 {
+				return len(key) == 0
+			}
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+
+			fallthrough
+		default:
+			if len(key) == 0 || pattern[0] != key[0] {
+				return false
+			}
+
+			key = key[1:]
+		}
+
+		pattern = pattern[1:]
+	}
+
+	return len(key) == 0
+}
+
+// subscribePattern subscribes to a pattern, in cluster mode on the node serving the slot
+// of bindKey if set or on a random node otherwise
+func (s *Service) subscribePattern(pattern string, bindKey string) (*redis.PubSubConn, error) {
+	conn, err := s.openConn(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cluster != nil && bindKey != "" {
+		err = redisc.BindConn(conn.(*serviceConn).delegate, bindKey)
+		if err != nil {
+			conn.Close()
+
+			return nil, fmt.Errorf("can't bind connection to key: %s", err)
+		}
+	}
+
+	psc := &redis.PubSubConn{Conn: conn}
+
+	err = psc.PSubscribe(pattern)
+	if err != nil {
+		psc.Close()
+
+		return nil, err
+	}
+
+	return psc, nil
+}
+
+// runPatternListener receives messages for a pattern subscription until either stop or the
+// service is closed, resubscribing automatically if the connection fails
+func (s *Service) runPatternListener(pattern string, bindKey string, psc *redis.PubSubConn, stop <-chan struct{}, handler func(msg redis.Message)) {
+	var err error
+
+	for {
+		if psc == nil {
+			psc, err = s.subscribePattern(pattern, bindKey)
+		}
+
+		if err == nil {
+			err = s.receivePattern(psc, stop, handler)
+			psc = nil
+		}
+
+		select {
+		case <-s.done:
+			return
+		case <-stop:
+			return
+		default:
+		}
+
+		s.log.Warnf("Listening on %s failed, resubscribing: %s", pattern, err)
+
+		select {
+		case <-s.done:
+			return
+		case <-stop:
+			return
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+func (s *Service) receivePattern(psc *redis.PubSubConn, stop <-chan struct{}, handler func(msg redis.Message)) error {
+	defer psc.Close()
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+
+	// Close the connection on shutdown and ping to check if connection is still alive
+	go func() {
+		ticker := time.NewTicker(pubSubPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-stop:
+				psc.Close()
+				return
+			case <-s.done:
+				psc.Close()
+				return
+			case <-ticker.C:
+				if err := psc.Ping(""); err != nil {
+					psc.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		// Receive with pubSubReceiveTimeout, redis_read_timeout_ms may be below the ping interval
+		switch n := psc.ReceiveWithTimeout(pubSubReceiveTimeout).(type) {
+		case error:
+			return n
+		case redis.Message:
+			handler(n)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnExpireIdleBelowReadTimeout(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.ReadTimeout = 1 * time.Second
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	_, err := service.Do("CONFIG", "SET", "notify-keyspace-events", "Ex")
+	require.NoError(t, err)
+
+	expired := make(chan string, 1)
+	service.OnExpire("session:*", func(key string) {
+		expired <- key
+	})
+
+	// Idle for longer than the read timeout and the ping interval
+	time.Sleep(6 * time.Second)
+
+	err = service.SetPX("session:a", []byte("value"), 50)
+	require.NoError(t, err)
+
+	select {
+	case key := <-expired:
+		assert.Equal(t, "session:a", key)
+	case <-time.After(3 * time.Second):
+		t.Fatal("expiry not reported")
+	}
+}
@@ -0,0 +1,83 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/indece-official/go-gousu"
+	"github.com/mna/redisc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPattern(t *testing.T) {
+	for _, test := range []struct {
+		pattern string
+		key     string
+		matches bool
+	}{
+		{"*", "", true},
+		{"session:*", "session:a/b", true},
+		{"session:*:data", "session:a:b:data", true},
+		{"session:*:data", "session:a:b", false},
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-c]llo", "hbllo", true},
+		{"h[c-a]llo", "hbllo", true},
+		{"h[a-c]llo", "hdllo", false},
+		{`h\*llo`, "h*llo", true},
+		{`h\*llo`, "hello", false},
+		{`h[\]]llo`, "h]llo", true},
+		{"h[ab", "ha", true},
+		{"h[ab", "hab", false},
+	} {
+		assert.Equal(t, test.matches, matchPattern(test.pattern, test.key), "%s %s", test.pattern, test.key)
+	}
+}
+
+func TestOnExpireBeforeStart(t *testing.T) {
+	service := NewService(gousu.NewContext()).(*Service)
+
+	expired := []string{}
+	service.OnExpire("session:*", func(key string) {
+		expired = append(expired, key)
+	})
+
+	assert.False(t, service.expireListening)
+
+	service.dispatchExpired("session:a/b")
+	service.dispatchExpired("user:a")
+
+	assert.Equal(t, []string{"session:a/b"}, expired)
+}
+
+func TestSubscribePatternBindsKey(t *testing.T) {
+	options := DefaultOptions()
+	options.KeyPrefix = "app:"
+
+	service := NewServiceWithOptions("redis", options)
+
+	commands := []string{}
+
+	service.cluster = &redisc.Cluster{
+		StartupNodes: []string{"node1:6379", "node2:6379"},
+		CreatePool: func(addr string, opts ...redis.DialOption) (*redis.Pool, error) {
+			return &redis.Pool{
+				Dial: func() (redis.Conn, error) {
+					return &clusterNodeConn{addr: addr, commands: &commands}, nil
+				},
+			}, nil
+		},
+	}
+	require.NoError(t, service.cluster.Refresh())
+
+	psc, err := service.subscribePattern("__keyspace@*__:app:c", "app:c")
+	require.NoError(t, err)
+	defer psc.Close()
+
+	assert.Equal(t, []string{"node2:6379 PSUBSCRIBE"}, commands)
+}
@@ -0,0 +1,406 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Expire sets a timeout in seconds on a key
+//
+// Returns false if the key doesn't exist
+func (s *Service) Expire(key string, seconds int) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("EXPIRE", key, seconds))
+}
+
+// PExpire sets a timeout in milliseconds on a key
+//
+// Returns false if the key doesn't exist
+func (s *Service) PExpire(key string, timeoutMS int) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("PEXPIRE", key, timeoutMS))
+}
+
+// ExpireAt sets the time at which a key expires (with millisecond precision)
+//
+// Returns false if the key doesn't exist
+func (s *Service) ExpireAt(key string, at time.Time) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("PEXPIREAT", key, at.UnixNano()/int64(time.Millisecond)))
+}
+
+// TTL returns the remaining time to live of a key in seconds
+//
+// Returns -2 if the key doesn't exist and -1 if the key has no timeout
+func (s *Service) TTL(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("TTL", key))
+}
+
+// PTTL returns the remaining time to live of a key in milliseconds
+//
+// Returns -2 if the key doesn't exist and -1 if the key has no timeout
+func (s *Service) PTTL(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("PTTL", key))
+}
+
+// Persist removes the timeout of a key
+//
+// Returns false if the key doesn't exist or has no timeout
+func (s *Service) Persist(key string) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("PERSIST", key))
+}
+
+// Type returns the type of the value stored at key ("string", "list", "set", "zset", "hash", "stream")
+//
+// Returns "none" if the key doesn't exist
+func (s *Service) Type(key string) (string, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return "", fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.String(conn.Do("TYPE", key))
+}
+
+// ObjectEncoding returns the internal encoding of the value stored at key (e.g. "listpack", "hashtable")
+//
+// Returns ErrNil if the key doesn't exist
+func (s *Service) ObjectEncoding(key string) (string, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return "", fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.String(conn.Do("OBJECT", "ENCODING", key))
+}
+
+// ObjectIdleTime returns the number of seconds since the key was last accessed
+//
+// Not available if maxmemory-policy is set to an LFU policy. Returns ErrNil if the key doesn't exist
+func (s *Service) ObjectIdleTime(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("OBJECT", "IDLETIME", key))
+}
+
+// ObjectFreq returns the logarithmic access frequency counter of a key
+//
+// Only available if maxmemory-policy is set to an LFU policy. Returns ErrNil if the key doesn't exist
+func (s *Service) ObjectFreq(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("OBJECT", "FREQ", key))
+}
+
+// deleteKeys deletes keys via DEL or UNLINK and returns the number of deleted keys
+//
+// In cluster mode the keys are deleted one by one, as they can be located on different nodes.
+func (s *Service) deleteKeys(commandName string, keys []string) (int, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	if s.cluster == nil {
+		return redis.Int(conn.Do(commandName, redis.Args{}.AddFlat(keys)...))
+	}
+
+	deleted := 0
+
+	for _, key := range keys {
+		count, err := redis.Int(conn.Do(commandName, key))
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += count
+	}
+
+	return deleted, nil
+}
+
+// Unlink deletes keys like Del, but frees the memory of the values in the background
+// so deleting large values doesn't block the server
+//
+// Returns the number of removed keys
+func (s *Service) Unlink(keys ...string) (int, error) {
+	return s.deleteKeys("UNLINK", keys)
+}
+
+// scanBatchSize is the default COUNT hint of SCAN commands iterating the whole keyspace
+const scanBatchSize = 1000
+
+// ScanOptions configures ScanAll
+type ScanOptions struct {
+	// Count is the number of keys checked per SCAN command (default 1000)
+	Count int
+	// Type only returns keys of a type, e.g. "hash" (redis >= 6)
+	Type string
+}
+
+// scanNodes iterates all keys matching the pattern on all nodes and calls fn with the
+// node's connection for each batch of (prefixed) keys
+func (s *Service) scanNodes(pattern string, options ScanOptions, fn func(conn redis.Conn, keys []string) error) error {
+	count := options.Count
+	if count <= 0 {
+		count = scanBatchSize
+	}
+
+	return s.eachNode(func(conn redis.Conn) error {
+		cursor := 0
+
+		for {
+			args := redis.Args{cursor, "MATCH", pattern, "COUNT", count}
+			if options.Type != "" {
+				args = args.Add("TYPE", options.Type)
+			}
+
+			reply, err := redis.Values(conn.Do("SCAN", args...))
+			if err != nil {
+				return err
+			}
+
+			keys := []string{}
+
+			_, err = redis.Scan(reply, &cursor, &keys)
+			if err != nil {
+				return err
+			}
+
+			if len(keys) > 0 {
+				err = fn(conn, keys)
+				if err != nil {
+					return err
+				}
+			}
+
+			if cursor == 0 {
+				return nil
+			}
+		}
+	})
+}
+
+// ScanAll iterates all keys matching the glob-style pattern and calls fn for each batch of keys
+//
+// Iterating stops if fn returns an error, which is returned by ScanAll. In cluster mode all
+// master nodes are scanned. Keys can be returned multiple times if the keyspace changes
+// while iterating.
+func (s *Service) ScanAll(pattern string, options ScanOptions, fn func(keys []string) error) error {
+	return s.scanNodes(pattern, options, func(conn redis.Conn, keys []string) error {
+		for i := range keys {
+			keys[i] = s.stripKeyPrefix(keys[i])
+		}
+
+		return fn(keys)
+	})
+}
+
+// DelPattern deletes all keys matching the glob-style pattern and returns the number of removed keys
+//
+// The keys are iterated via SCAN and deleted in batches via UNLINK, so the server isn't blocked.
+// In cluster mode all master nodes are scanned. Keys created while the deletion is running may
+// not be deleted.
+func (s *Service) DelPattern(pattern string) (int, error) {
+	deleted := 0
+
+	err := s.scanNodes(pattern, ScanOptions{}, func(conn redis.Conn, keys []string) error {
+		count, err := s.unlinkBatch(conn, keys)
+		deleted += count
+
+		return err
+	})
+
+	return deleted, err
+}
+
+// unlinkBatch deletes keys returned by SCAN on the connection
+//
+// In cluster mode the keys are unlinked one by one in a pipeline, as they can belong to
+// different slots of the node.
+func (s *Service) unlinkBatch(conn redis.Conn, keys []string) (int, error) {
+	args := redis.Args{}
+	for _, key := range keys {
+		args = args.Add(s.stripKeyPrefix(key))
+	}
+
+	if s.cluster == nil {
+		return redis.Int(conn.Do("UNLINK", args...))
+	}
+
+	for _, key := range args {
+		err := conn.Send("UNLINK", key)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err := conn.Flush()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+
+	for range args {
+		count, err := redis.Int(conn.Receive())
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += count
+	}
+
+	return deleted, nil
+}
+
+// Rename renames a key, overwriting newKey if it exists
+//
+// In cluster mode both keys must belong to the same slot (e.g. by using hash tags).
+func (s *Service) Rename(key string, newKey string) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("RENAME", key, newKey)
+
+	return err
+}
+
+// RenameNX renames a key if newKey doesn't exist
+//
+// Returns false if newKey already exists. In cluster mode both keys must belong to the same slot.
+func (s *Service) RenameNX(key string, newKey string) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("RENAMENX", key, newKey))
+}
+
+// Copy copies the value of source to destination (redis >= 6.2), overwriting an existing
+// destination only if replace is set
+//
+// Returns false if source doesn't exist or destination exists and replace is not set.
+// In cluster mode both keys must belong to the same slot.
+func (s *Service) Copy(source string, destination string, replace bool) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{source, destination}
+	if replace {
+		args = args.Add("REPLACE")
+	}
+
+	return redis.Bool(conn.Do("COPY", args...))
+}
+
+// CopyToDB copies the value of source to destination in another database (redis >= 6.2),
+// see Copy
+//
+// Not supported in cluster mode, which only has database 0.
+func (s *Service) CopyToDB(source string, destination string, db int, replace bool) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{source, destination, "DB", db}
+	if replace {
+		args = args.Add("REPLACE")
+	}
+
+	return redis.Bool(conn.Do("COPY", args...))
+}
+
+// Dump serializes the value of a key in the redis specific format used by Restore
+//
+// Returns ErrNil if the key doesn't exist
+func (s *Service) Dump(key string) ([]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bytes(conn.Do("DUMP", key))
+}
+
+// Restore creates a key from a value serialized via Dump, with a timeout in milliseconds (0 = none)
+//
+// Fails if the key exists unless replace is set. The payload contains a version and checksum,
+// so it can only be restored on compatible redis versions.
+func (s *Service) Restore(key string, ttlMS int, payload []byte, replace bool) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{key, ttlMS, payload}
+	if replace {
+		args = args.Add("REPLACE")
+	}
+
+	_, err = conn.Do("RESTORE", args...)
+
+	return err
+}
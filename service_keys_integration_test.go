@@ -0,0 +1,411 @@
+package gousuredis_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExistsMulti(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.Set("key1", []byte("value1")))
+	require.NoError(t, service.Set("key2", []byte("value2")))
+
+	count, err := service.ExistsMulti("key1", "key2", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Keys passed multiple times are counted multiple times
+	count, err = service.ExistsMulti("key1", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = service.ExistsMulti("missing")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestExpiration(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.Set("key", []byte("value")))
+
+	ttl, err := service.TTL("key")
+	require.NoError(t, err)
+	assert.Equal(t, -1, ttl)
+
+	ttl, err = service.TTL("missing")
+	require.NoError(t, err)
+	assert.Equal(t, -2, ttl)
+
+	set, err := service.Expire("key", 100)
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	ttl, err = service.TTL("key")
+	require.NoError(t, err)
+	assert.InDelta(t, 100, ttl, 1)
+
+	set, err = service.PExpire("key", 50000)
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	ttl, err = service.PTTL("key")
+	require.NoError(t, err)
+	assert.InDelta(t, 50000, ttl, 1000)
+
+	set, err = service.ExpireAt("key", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	ttl, err = service.TTL("key")
+	require.NoError(t, err)
+	assert.InDelta(t, 3600, ttl, 2)
+
+	persisted, err := service.Persist("key")
+	require.NoError(t, err)
+	assert.True(t, persisted)
+
+	persisted, err = service.Persist("key")
+	require.NoError(t, err)
+	assert.False(t, persisted)
+
+	ttl, err = service.PTTL("key")
+	require.NoError(t, err)
+	assert.Equal(t, -1, ttl)
+
+	set, err = service.Expire("missing", 100)
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	// Keys expire after their timeout
+	set, err = service.PExpire("key", 10)
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	assert.Eventually(t, func() bool {
+		exists, err := service.Exists("key")
+
+		return err == nil && !exists
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTypeAndObject(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.Set("string", []byte("value")))
+	_, err := service.RPush("list", []byte("item"))
+	require.NoError(t, err)
+	require.NoError(t, service.HSet("hash", "field", []byte("value")))
+
+	for key, expected := range map[string]string{
+		"string":  "string",
+		"list":    "list",
+		"hash":    "hash",
+		"missing": "none",
+	} {
+		keyType, err := service.Type(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, keyType, key)
+	}
+
+	_, err = service.Incr("counter")
+	require.NoError(t, err)
+
+	encoding, err := service.ObjectEncoding("counter")
+	require.NoError(t, err)
+	assert.Equal(t, "int", encoding)
+
+	_, err = service.ObjectEncoding("missing")
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+
+	idle, err := service.ObjectIdleTime("string")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, idle, 0)
+
+	// OBJECT FREQ requires a LFU eviction policy
+	policy, err := redis.Strings(service.Do("CONFIG", "GET", "maxmemory-policy"))
+	require.NoError(t, err)
+	require.Len(t, policy, 2)
+
+	if !strings.Contains(policy[1], "lfu") {
+		_, err = service.ObjectFreq("string")
+		assert.True(t, gousuredis.IsServerError(err))
+	}
+
+	_, err = service.Do("CONFIG", "SET", "maxmemory-policy", "allkeys-lfu")
+	require.NoError(t, err)
+	defer service.Do("CONFIG", "SET", "maxmemory-policy", policy[1])
+
+	freq, err := service.ObjectFreq("string")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, freq, 0)
+}
+
+func TestDelUnlink(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	for _, key := range []string{"key1", "key2", "key3", "key4"} {
+		require.NoError(t, service.Set(key, []byte("value")))
+	}
+
+	err := service.Del("key1", "key2", "missing")
+	require.NoError(t, err)
+
+	count, err := service.ExistsMulti("key1", "key2", "key3", "key4")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	err = service.Del()
+	require.NoError(t, err)
+
+	deleted, err := service.Unlink("key3", "key4", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	count, err = service.ExistsMulti("key3", "key4")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	deleted, err = service.Unlink()
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func TestDelPattern(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	for i := 0; i < 2500; i++ {
+		require.NoError(t, service.Set(fmt.Sprintf("session:%d", i), []byte("value")))
+	}
+	require.NoError(t, service.Set("user:1", []byte("value")))
+
+	deleted, err := service.DelPattern("session:*")
+	require.NoError(t, err)
+	assert.Equal(t, 2500, deleted)
+
+	count, err := service.ExistsMulti("session:0", "session:2499", "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	deleted, err = service.DelPattern("session:*")
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func TestDelPatternKeyPrefix(t *testing.T) {
+	unprefixedService := testutil.StartTestService(t)
+
+	options := gousuredis.DefaultOptions()
+	options.KeyPrefix = "app:"
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	require.NoError(t, service.Set("session:1", []byte("value")))
+	require.NoError(t, unprefixedService.Set("session:2", []byte("value")))
+
+	deleted, err := service.DelPattern("session:*")
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	exists, err := unprefixedService.Exists("session:2")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestScanAll(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.KeyPrefix = "app:"
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, service.Set(fmt.Sprintf("item:%d", i), []byte("value")))
+	}
+	require.NoError(t, service.HSet("item:hash", "field", []byte("value")))
+	require.NoError(t, service.Set("other", []byte("value")))
+
+	keys := map[string]bool{}
+	batches := 0
+
+	err := service.ScanAll("item:*", gousuredis.ScanOptions{Count: 10}, func(batch []string) error {
+		batches++
+		for _, key := range batch {
+			keys[key] = true
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, keys, 51)
+	assert.True(t, keys["item:0"])
+	assert.True(t, keys["item:hash"])
+	assert.False(t, keys["other"])
+	assert.Greater(t, batches, 1)
+
+	keys = map[string]bool{}
+
+	err = service.ScanAll("*", gousuredis.ScanOptions{Type: "hash"}, func(batch []string) error {
+		for _, key := range batch {
+			keys[key] = true
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"item:hash": true}, keys)
+
+	errStop := errors.New("stop")
+
+	err = service.ScanAll("item:*", gousuredis.ScanOptions{Count: 10}, func(batch []string) error {
+		return errStop
+	})
+	assert.Equal(t, errStop, err)
+}
+
+func TestRenameCopy(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.Set("{key}1", []byte("value1")))
+	require.NoError(t, service.Set("{key}2", []byte("value2")))
+
+	require.NoError(t, service.Rename("{key}1", "{key}3"))
+
+	exists, err := service.Exists("{key}1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	data, err := service.Get("{key}3")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), data)
+
+	// Rename overwrites the destination
+	require.NoError(t, service.Rename("{key}2", "{key}3"))
+
+	data, err = service.Get("{key}3")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value2"), data)
+
+	err = service.Rename("{key}missing", "{key}4")
+	assert.Error(t, err)
+
+	require.NoError(t, service.Set("{key}1", []byte("value1")))
+
+	renamed, err := service.RenameNX("{key}1", "{key}3")
+	require.NoError(t, err)
+	assert.False(t, renamed)
+
+	renamed, err = service.RenameNX("{key}1", "{key}4")
+	require.NoError(t, err)
+	assert.True(t, renamed)
+
+	copied, err := service.Copy("{key}4", "{key}5", false)
+	require.NoError(t, err)
+	assert.True(t, copied)
+
+	data, err = service.Get("{key}5")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), data)
+
+	copied, err = service.Copy("{key}3", "{key}5", false)
+	require.NoError(t, err)
+	assert.False(t, copied)
+
+	copied, err = service.Copy("{key}3", "{key}5", true)
+	require.NoError(t, err)
+	assert.True(t, copied)
+
+	data, err = service.Get("{key}5")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value2"), data)
+
+	copied, err = service.Copy("{key}missing", "{key}6", true)
+	require.NoError(t, err)
+	assert.False(t, copied)
+}
+
+func TestCopyToDB(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	options := gousuredis.DefaultOptions()
+	options.DB = 1
+
+	otherService := testutil.StartTestServiceWithOptions(t, options)
+
+	require.NoError(t, service.Set("key1", []byte("value1")))
+	require.NoError(t, otherService.Set("key2", []byte("other")))
+
+	copied, err := service.CopyToDB("key1", "key2", 1, false)
+	require.NoError(t, err)
+	assert.False(t, copied)
+
+	copied, err = service.CopyToDB("key1", "key2", 1, true)
+	require.NoError(t, err)
+	assert.True(t, copied)
+
+	data, err := otherService.Get("key2")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), data)
+
+	// The source is kept and the destination isn't created in the source database
+	exists, err := service.Exists("key2")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	data, err = service.Get("key1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), data)
+}
+
+func TestDumpRestore(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.HMSet("hash", map[string][]byte{
+		"field1": []byte("value1"),
+		"field2": []byte("value2"),
+	}))
+
+	payload, err := service.Dump("hash")
+	require.NoError(t, err)
+	assert.NotEmpty(t, payload)
+
+	_, err = service.Dump("missing")
+	assert.Equal(t, gousuredis.ErrNil, err)
+
+	require.NoError(t, service.Restore("restored", 0, payload, false))
+
+	values, err := service.HGetAll("restored")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"field1": []byte("value1"),
+		"field2": []byte("value2"),
+	}, values)
+
+	ttl, err := service.PTTL("restored")
+	require.NoError(t, err)
+	assert.Equal(t, -1, ttl)
+
+	// Restoring an existing key fails unless replace is set
+	err = service.Restore("restored", 0, payload, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BUSYKEY")
+
+	require.NoError(t, service.Restore("restored", 60000, payload, true))
+
+	ttl, err = service.PTTL("restored")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 0)
+	assert.LessOrEqual(t, ttl, 60000)
+
+	err = service.Restore("invalid", 0, []byte("invalid"), false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ListDirection is the end of a list used by LMove and BLMove
+type ListDirection string
+
+const (
+	ListLeft  ListDirection = "LEFT"
+	ListRight ListDirection = "RIGHT"
+)
+
+// blockReadTimeout returns the read timeout for a blocking command with the timeout (0 blocks forever)
+func blockReadTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+
+	return timeout + blockReadTimeoutMargin
+}
+
+// openBlockingConn opens a connection for blocking commands
+//
+// In cluster mode the connection doesn't follow redirections, as only connections bound to
+// a node support the read timeouts of blocking commands.
+func (s *Service) openBlockingConn() (redis.Conn, error) {
+	return s.openConn(s.cluster == nil)
+}
+
+// LTrim trims a list to the elements between start and stop (inclusive, negative indexes count
+// from the end), e.g. LTrim(key, 0, 99) after LPush keeps the latest 100 items
+func (s *Service) LTrim(key string, start int, stop int) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("LTRIM", key, start, stop)
+
+	return err
+}
+
+// LSet replaces the element at index of a list
+func (s *Service) LSet(key string, index int, data []byte) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("LSET", key, index, data)
+
+	return err
+}
+
+// LInsert inserts an element before or after the first occurrence of pivot in a list
+//
+// Returns the new length of the list, -1 if pivot was not found and 0 if the key doesn't exist
+func (s *Service) LInsert(key string, before bool, pivot []byte, data []byte) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	position := "AFTER"
+	if before {
+		position = "BEFORE"
+	}
+
+	return redis.Int(conn.Do("LINSERT", key, position, pivot, data))
+}
+
+// LMove atomically pops an element from one end of source and pushes it to one end of
+// destination (redis >= 6.2)
+//
+// Returns ErrNil if source is empty. In cluster mode both keys must belong to the same slot.
+func (s *Service) LMove(source string, destination string, from ListDirection, to ListDirection) ([]byte, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bytes(conn.Do("LMOVE", source, destination, string(from), string(to)))
+}
+
+// BLMove is the blocking variant of LMove, waiting up to timeout for an element (0 blocks forever)
+//
+// Returns ErrNil if the timeout is reached
+func (s *Service) BLMove(source string, destination string, from ListDirection, to ListDirection, timeout time.Duration) ([]byte, error) {
+	conn, err := s.openBlockingConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	timeoutArg := "0"
+	if timeout > 0 {
+		timeoutArg = blockTimeoutArg(timeout)
+	}
+
+	return redis.Bytes(redis.DoWithTimeout(conn, blockReadTimeout(timeout), "BLMOVE", source, destination, string(from), string(to), timeoutArg))
+}
+
+// blockingPop runs BLPOP / BRPOP on multiple keys and returns the key and the popped element
+func (s *Service) blockingPop(commandName string, keys []string, timeout time.Duration) (string, []byte, error) {
+	conn, err := s.openBlockingConn()
+	if err != nil {
+		return "", nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	timeoutArg := "0"
+	if timeout > 0 {
+		timeoutArg = blockTimeoutArg(timeout)
+	}
+
+	result, err := redis.ByteSlices(redis.DoWithTimeout(conn, blockReadTimeout(timeout), commandName, redis.Args{}.AddFlat(keys).Add(timeoutArg)...))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(result) < 2 || result[0] == nil || result[1] == nil {
+		return "", nil, ErrNil
+	}
+
+	return s.stripKeyPrefix(string(result[0])), result[1], nil
+}
+
+// BLPopMulti waits for a new item in one of multiple lists (blocking with timeout, 0 blocks forever)
+//
+// The lists are checked in the order of the keys, so they can be used as priority queues.
+// Returns the key of the list and the item or ErrNil if the timeout is reached. In cluster
+// mode all keys must belong to the same slot.
+func (s *Service) BLPopMulti(keys []string, timeout time.Duration) (string, []byte, error) {
+	return s.blockingPop("BLPOP", keys, timeout)
+}
+
+// BRPop waits for a new item at the end of a list (blocking with timeout in seconds)
+func (s *Service) BRPop(key string, timeout int) ([]byte, error) {
+	_, data, err := s.blockingPop("BRPOP", []string{key}, time.Duration(timeout)*time.Second)
+
+	return data, err
+}
+
+// BRPopMulti waits for a new item at the end of one of multiple lists (blocking with timeout,
+// 0 blocks forever), see BLPopMulti
+func (s *Service) BRPopMulti(keys []string, timeout time.Duration) (string, []byte, error) {
+	return s.blockingPop("BRPOP", keys, timeout)
+}
@@ -0,0 +1,155 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pushItems(t *testing.T, service *gousuredis.Service, key string, items ...string) {
+	for _, item := range items {
+		_, err := service.RPush(key, []byte(item))
+		require.NoError(t, err)
+	}
+}
+
+func listItems(t *testing.T, service *gousuredis.Service, key string) []string {
+	values, err := service.LRange(key, 0, -1)
+	require.NoError(t, err)
+
+	items := make([]string, len(values))
+	for i, value := range values {
+		items[i] = string(value)
+	}
+
+	return items
+}
+
+func TestListModify(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	pushItems(t, service, "list1", "a", "b", "c", "d", "e")
+
+	err := service.LTrim("list1", 1, -2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "d"}, listItems(t, service, "list1"))
+
+	err = service.LSet("list1", 1, []byte("x"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "x", "d"}, listItems(t, service, "list1"))
+
+	err = service.LSet("list1", 10, []byte("y"))
+	assert.Error(t, err)
+
+	length, err := service.LInsert("list1", true, []byte("x"), []byte("w"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, length)
+
+	length, err = service.LInsert("list1", false, []byte("d"), []byte("e"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, length)
+	assert.Equal(t, []string{"b", "w", "x", "d", "e"}, listItems(t, service, "list1"))
+
+	length, err = service.LInsert("list1", true, []byte("missing"), []byte("z"))
+	require.NoError(t, err)
+	assert.Equal(t, -1, length)
+
+	length, err = service.LInsert("missing", true, []byte("a"), []byte("z"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, length)
+}
+
+func TestLMove(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	pushItems(t, service, "{list}1", "a", "b", "c")
+
+	data, err := service.LMove("{list}1", "{list}2", gousuredis.ListRight, gousuredis.ListLeft)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("c"), data)
+
+	data, err = service.LMove("{list}1", "{list}2", gousuredis.ListLeft, gousuredis.ListLeft)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), data)
+
+	assert.Equal(t, []string{"b"}, listItems(t, service, "{list}1"))
+	assert.Equal(t, []string{"a", "c"}, listItems(t, service, "{list}2"))
+
+	_, err = service.LMove("{list}3", "{list}2", gousuredis.ListLeft, gousuredis.ListRight)
+	assert.Equal(t, gousuredis.ErrNil, err)
+}
+
+func TestBLMove(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	_, err := service.BLMove("{list}1", "{list}2", gousuredis.ListLeft, gousuredis.ListRight, 100*time.Millisecond)
+	assert.Equal(t, gousuredis.ErrNil, err)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		service.RPush("{list}1", []byte("a"))
+	}()
+
+	data, err := service.BLMove("{list}1", "{list}2", gousuredis.ListLeft, gousuredis.ListRight, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), data)
+	assert.Equal(t, []string{"a"}, listItems(t, service, "{list}2"))
+}
+
+func TestBlockingPopMulti(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	pushItems(t, service, "{queue}low", "low1", "low2")
+	pushItems(t, service, "{queue}high", "high1", "high2")
+
+	keys := []string{"{queue}high", "{queue}low"}
+
+	key, data, err := service.BLPopMulti(keys, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "{queue}high", key)
+	assert.Equal(t, []byte("high1"), data)
+
+	key, data, err = service.BRPopMulti(keys, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "{queue}high", key)
+	assert.Equal(t, []byte("high2"), data)
+
+	key, data, err = service.BLPopMulti(keys, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "{queue}low", key)
+	assert.Equal(t, []byte("low1"), data)
+
+	data, err = service.BRPop("{queue}low", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("low2"), data)
+
+	_, _, err = service.BLPopMulti(keys, 100*time.Millisecond)
+	assert.Equal(t, gousuredis.ErrNil, err)
+
+	_, _, err = service.BRPopMulti(keys, 100*time.Millisecond)
+	assert.Equal(t, gousuredis.ErrNil, err)
+
+	_, err = service.BRPop("{queue}low", 1)
+	assert.Equal(t, gousuredis.ErrNil, err)
+}
+
+func TestBlockingPopMultiKeyPrefix(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.KeyPrefix = "app:"
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		service.RPush("{queue}low", []byte("low1"))
+	}()
+
+	key, data, err := service.BLPopMulti([]string{"{queue}high", "{queue}low"}, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "{queue}low", key)
+	assert.Equal(t, []byte("low1"), data)
+}
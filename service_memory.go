@@ -0,0 +1,177 @@
+package gousuredis
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// MemoryStatsDB contains the memory overhead of the hash tables of a database
+type MemoryStatsDB struct {
+	OverheadHashtableMain    int64
+	OverheadHashtableExpires int64
+}
+
+// MemoryStats contains the parsed output of the MEMORY STATS and MEMORY DOCTOR commands
+//
+// All fields are available as strings in Fields, fields of databases are prefixed with
+// the database name (e.g. "db.0.overhead.hashtable.main").
+type MemoryStats struct {
+	Fields map[string]string
+
+	PeakAllocated      int64
+	TotalAllocated     int64
+	StartupAllocated   int64
+	ReplicationBacklog int64
+	ClientsReplicas    int64
+	ClientsNormal      int64
+	OverheadTotal      int64
+	KeysCount          int64
+	KeysBytesPerKey    int64
+	DatasetBytes       int64
+	DatasetPercentage  float64
+	PeakPercentage     float64
+	Fragmentation      float64
+	FragmentationBytes int64
+
+	// DBs by database name (e.g. "db.0")
+	DBs map[string]MemoryStatsDB
+
+	// Doctor is the report of MEMORY DOCTOR about memory problems
+	Doctor string
+}
+
+// parseMemoryStatsFields flattens the name / value pairs of MEMORY STATS into fields
+func parseMemoryStatsFields(fields map[string]string, prefix string, values []interface{}) error {
+	if len(values)%2 != 0 {
+		return fmt.Errorf("expected even number of values for memory stats, got %d", len(values))
+	}
+
+	for i := 0; i < len(values); i += 2 {
+		name, err := redis.String(values[i], nil)
+		if err != nil {
+			return fmt.Errorf("parsing name of memory stats failed: %s", err)
+		}
+
+		switch value := values[i+1].(type) {
+		case []interface{}:
+			err = parseMemoryStatsFields(fields, prefix+name+".", value)
+			if err != nil {
+				return err
+			}
+		case int64:
+			fields[prefix+name] = strconv.FormatInt(value, 10)
+		case []byte:
+			fields[prefix+name] = string(value)
+		case string:
+			fields[prefix+name] = value
+		}
+	}
+
+	return nil
+}
+
+// parseMemoryStats parses the reply of the MEMORY STATS command
+func parseMemoryStats(reply []interface{}) (*MemoryStats, error) {
+	stats := &MemoryStats{
+		Fields: map[string]string{},
+		DBs:    map[string]MemoryStatsDB{},
+	}
+
+	for i := 0; i+1 < len(reply); i += 2 {
+		values, ok := reply[i+1].([]interface{})
+		if !ok {
+			continue
+		}
+
+		name, err := redis.String(reply[i], nil)
+		if err != nil {
+			return nil, fmt.Errorf("parsing name of memory stats failed: %s", err)
+		}
+
+		dbFields := map[string]string{}
+
+		err = parseMemoryStatsFields(dbFields, "", values)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.DBs[name] = MemoryStatsDB{
+			OverheadHashtableMain:    parseInfoInt(dbFields, "overhead.hashtable.main"),
+			OverheadHashtableExpires: parseInfoInt(dbFields, "overhead.hashtable.expires"),
+		}
+	}
+
+	err := parseMemoryStatsFields(stats.Fields, "", reply)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := stats.Fields
+
+	stats.PeakAllocated = parseInfoInt(fields, "peak.allocated")
+	stats.TotalAllocated = parseInfoInt(fields, "total.allocated")
+	stats.StartupAllocated = parseInfoInt(fields, "startup.allocated")
+	stats.ReplicationBacklog = parseInfoInt(fields, "replication.backlog")
+	stats.ClientsReplicas = parseInfoInt(fields, "clients.slaves")
+	stats.ClientsNormal = parseInfoInt(fields, "clients.normal")
+	stats.OverheadTotal = parseInfoInt(fields, "overhead.total")
+	stats.KeysCount = parseInfoInt(fields, "keys.count")
+	stats.KeysBytesPerKey = parseInfoInt(fields, "keys.bytes-per-key")
+	stats.DatasetBytes = parseInfoInt(fields, "dataset.bytes")
+	stats.DatasetPercentage, _ = strconv.ParseFloat(fields["dataset.percentage"], 64)
+	stats.PeakPercentage, _ = strconv.ParseFloat(fields["peak.percentage"], 64)
+	stats.Fragmentation, _ = strconv.ParseFloat(fields["fragmentation"], 64)
+	stats.FragmentationBytes = parseInfoInt(fields, "fragmentation.bytes")
+
+	return stats, nil
+}
+
+// MemoryUsage returns the number of bytes a key and its value take in memory
+//
+// samples is the number of nested values sampled for aggregate types (e.g. lists, hashes),
+// 0 samples all of them and a negative value uses the server default (5). Returns ErrNil
+// if the key doesn't exist
+func (s *Service) MemoryUsage(key string, samples int) (int64, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{}.Add("USAGE", key)
+	if samples >= 0 {
+		args = args.Add("SAMPLES", samples)
+	}
+
+	return redis.Int64(conn.Do("MEMORY", args...))
+}
+
+// MemoryStats runs the MEMORY STATS and MEMORY DOCTOR commands and parses the result
+//
+// In cluster mode the stats of a single node are returned.
+func (s *Service) MemoryStats() (*MemoryStats, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	reply, err := redis.Values(conn.Do("MEMORY", "STATS"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := parseMemoryStats(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Doctor, err = redis.String(conn.Do("MEMORY", "DOCTOR"))
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
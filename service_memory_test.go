@@ -0,0 +1,44 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemoryStats(t *testing.T) {
+	reply := []interface{}{
+		[]byte("peak.allocated"), int64(2097152),
+		[]byte("total.allocated"), int64(1048576),
+		[]byte("clients.normal"), int64(20496),
+		[]byte("db.0"), []interface{}{
+			[]byte("overhead.hashtable.main"), int64(72),
+			[]byte("overhead.hashtable.expires"), int64(32),
+		},
+		[]byte("keys.count"), int64(42),
+		[]byte("dataset.percentage"), []byte("12.5"),
+		[]byte("fragmentation"), []byte("1.25"),
+	}
+
+	stats, err := parseMemoryStats(reply)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2097152), stats.PeakAllocated)
+	assert.Equal(t, int64(1048576), stats.TotalAllocated)
+	assert.Equal(t, int64(20496), stats.ClientsNormal)
+	assert.Equal(t, int64(42), stats.KeysCount)
+	assert.Equal(t, 12.5, stats.DatasetPercentage)
+	assert.Equal(t, 1.25, stats.Fragmentation)
+	assert.Equal(t, map[string]MemoryStatsDB{"db.0": {OverheadHashtableMain: 72, OverheadHashtableExpires: 32}}, stats.DBs)
+	assert.Equal(t, "72", stats.Fields["db.0.overhead.hashtable.main"])
+
+	_, err = parseMemoryStats([]interface{}{[]byte("db.0"), []interface{}{[]byte("overhead.hashtable.main")}})
+	assert.Error(t, err)
+}
+
+func TestMemoryUsagePrefixesKey(t *testing.T) {
+	args := prefixArgs("app:", "MEMORY", []interface{}{"USAGE", "key", "SAMPLES", 0})
+
+	assert.Equal(t, []interface{}{"USAGE", "app:key", "SAMPLES", 0}, args)
+}
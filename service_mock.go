@@ -1,6 +1,7 @@
 package gousuredis
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-redsync/redsync/v4"
@@ -12,74 +13,555 @@ import (
 type MockService struct {
 	gousu.MockService
 
-	NewMutexFunc           func(name string, options ...redsync.Option) *redsync.Mutex
-	GetPoolFunc            func() *redis.Pool
-	GetFunc                func(key string) ([]byte, error)
-	SetFunc                func(key string, data []byte) error
-	SetNXPXFunc            func(key string, data []byte, timeoutMS int) error
-	SetPXFunc              func(key string, data []byte, timeoutMS int) error
-	DelFunc                func(key string) error
-	ExistsFunc             func(key string) (bool, error)
-	ScanFunc               func(pattern string, cursor int) (int, []string, error)
-	RPushFunc              func(key string, data []byte) (int, error)
-	LPushFunc              func(key string, data []byte) (int, error)
-	LRangeFunc             func(key string, start int, stop int) ([][]byte, error)
-	LRemFunc               func(key string, count int, data []byte) (int, error)
-	LPopFunc               func(key string) ([]byte, error)
-	RPopFunc               func(key string) ([]byte, error)
-	BLPopFunc              func(key string, timeout int) ([]byte, error)
-	HGetFunc               func(key string, field string) ([]byte, error)
-	HSetFunc               func(key string, field string, data []byte) error
-	HScanFunc              func(key string, cursor int) (int, map[string][]byte, error)
-	HKeysFunc              func(key string) ([][]byte, error)
-	HDelFunc               func(key string, field string) error
-	HLenFunc               func(key string) (int, error)
-	LIndexFunc             func(key string, position int) ([]byte, error)
-	LLenFunc               func(key string) (int, error)
-	SubscribeFunc          func(channels []string) (chan Message, ISubscription, error)
-	PublishFunc            func(channel string, data []byte) error
-	XAddFunc               func(key string, data map[string]string) (string, error)
-	XGroupCreateFunc       func(groupName string, key string, offset XGroupCreateOffset, mkStream bool, ignoreBusy bool) error
-	XReadGroupFunc         func(groupName string, consumerName string, key string, timeout time.Duration, streamID XReadGroupStreamID) (*XEvent, error)
-	XAckFunc               func(groupName string, key string, id string) (int, error)
-	NewMutexFuncCalled     int
-	GetPoolFuncCalled      int
-	GetFuncCalled          int
-	SetFuncCalled          int
-	SetNXPXFuncCalled      int
-	SetPXFuncCalled        int
-	DelFuncCalled          int
-	ExistsFuncCalled       int
-	ScanFuncCalled         int
-	RPushFuncCalled        int
-	LPushFuncCalled        int
-	LRangeFuncCalled       int
-	LRemFuncCalled         int
-	LPopFuncCalled         int
-	RPopFuncCalled         int
-	BLPopFuncCalled        int
-	HGetFuncCalled         int
-	HSetFuncCalled         int
-	HScanFuncCalled        int
-	HKeysFuncCalled        int
-	HDelFuncCalled         int
-	HLenFuncCalled         int
-	LIndexFuncCalled       int
-	LLenFuncCalled         int
-	SubscribeFuncCalled    int
-	PublishFuncCalled      int
-	XAddFuncCalled         int
-	XGroupCreateFuncCalled int
-	XReadGroupFuncCalled   int
-	XAckFuncCalled         int
+	NewMutexFunc                   func(name string, options ...redsync.Option) *redsync.Mutex
+	GetPoolFunc                    func() *redis.Pool
+	GetFunc                        func(key string) ([]byte, error)
+	SetFunc                        func(key string, data []byte) error
+	SetNXPXFunc                    func(key string, data []byte, timeoutMS int) error
+	SetPXFunc                      func(key string, data []byte, timeoutMS int) error
+	DelFunc                        func(keys ...string) error
+	ExistsFunc                     func(key string) (bool, error)
+	ScanFunc                       func(pattern string, cursor int) (int, []string, error)
+	RPushFunc                      func(key string, data []byte) (int, error)
+	LPushFunc                      func(key string, data []byte) (int, error)
+	LRangeFunc                     func(key string, start int, stop int) ([][]byte, error)
+	LRemFunc                       func(key string, count int, data []byte) (int, error)
+	LPopFunc                       func(key string) ([]byte, error)
+	RPopFunc                       func(key string) ([]byte, error)
+	BLPopFunc                      func(key string, timeout int) ([]byte, error)
+	HGetFunc                       func(key string, field string) ([]byte, error)
+	HSetFunc                       func(key string, field string, data []byte) error
+	HScanFunc                      func(key string, cursor int) (int, map[string][]byte, error)
+	HKeysFunc                      func(key string) ([][]byte, error)
+	HDelFunc                       func(key string, field string) error
+	HLenFunc                       func(key string) (int, error)
+	LIndexFunc                     func(key string, position int) ([]byte, error)
+	LLenFunc                       func(key string) (int, error)
+	SubscribeFunc                  func(channels []string) (chan Message, ISubscription, error)
+	PublishFunc                    func(channel string, data []byte) (int, error)
+	XAddFunc                       func(key string, data map[string]string) (string, error)
+	XGroupCreateFunc               func(groupName string, key string, offset XGroupCreateOffset, mkStream bool, ignoreBusy bool) error
+	XReadGroupFunc                 func(groupName string, consumerName string, key string, timeout time.Duration, streamID XReadGroupStreamID) (*XEvent, error)
+	XAckFunc                       func(groupName string, key string, id string) (int, error)
+	OnExpireFunc                   func(pattern string, fn func(key string))
+	ExistsMultiFunc                func(keys ...string) (int, error)
+	WatchKeyFunc                   func(ctx context.Context, key string) (<-chan KeyEvent, error)
+	MonitorQueueFunc               func(config QueueMonitorConfig) error
+	GetQueueDepthsFunc             func() []QueueDepth
+	ScriptKillFunc                 func() error
+	BLPopCtxFunc                   func(ctx context.Context, key string, timeout time.Duration) ([]byte, error)
+	HGetAllMultiFunc               func(keys []string) (map[string]map[string][]byte, error)
+	ZAddFunc                       func(key string, score float64, member []byte) (int, error)
+	ZRangeFunc                     func(key string, start int, stop int) ([][]byte, error)
+	ZRangeByScoreFunc              func(key string, min string, max string) ([]ZMember, error)
+	ZRemFunc                       func(key string, member []byte) (int, error)
+	ZIncrByFunc                    func(key string, increment float64, member []byte) (float64, error)
+	ZScoreFunc                     func(key string, member []byte) (float64, error)
+	ZRankFunc                      func(key string, member []byte) (int, error)
+	ZCardFunc                      func(key string) (int, error)
+	ZPopMinFunc                    func(key string, count int) ([]ZMember, error)
+	SAddFunc                       func(key string, members ...[]byte) (int, error)
+	SRemFunc                       func(key string, members ...[]byte) (int, error)
+	SMembersFunc                   func(key string) ([][]byte, error)
+	SIsMemberFunc                  func(key string, member []byte) (bool, error)
+	SCardFunc                      func(key string) (int, error)
+	SPopFunc                       func(key string, count int) ([][]byte, error)
+	SRandMemberFunc                func(key string, count int) ([][]byte, error)
+	SInterFunc                     func(keys ...string) ([][]byte, error)
+	SUnionFunc                     func(keys ...string) ([][]byte, error)
+	SDiffFunc                      func(keys ...string) ([][]byte, error)
+	XRangeFunc                     func(key string, start string, end string, count int) ([]*XEvent, error)
+	XReadFunc                      func(key string, timeout time.Duration, lastID string, count int) ([]*XEvent, error)
+	XLenFunc                       func(key string) (int, error)
+	ConsumeGroupFunc               func(groupName string, consumerName string, key string) (chan XMessage, IConsumerGroup, error)
+	WithContextFunc                func(ctx context.Context) IService
+	PipelineFunc                   func() IPipeline
+	TxFunc                         func(watchKeys []string, fn func(tx ITx) error) ([]interface{}, error)
+	CachedFunc                     func(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error)
+	GetJSONFunc                    func(key string, dest interface{}) error
+	SetJSONFunc                    func(key string, v interface{}) error
+	SetJSONPXFunc                  func(key string, v interface{}, timeoutMS int) error
+	SetJSONNXPXFunc                func(key string, v interface{}, timeoutMS int) error
+	HGetJSONFunc                   func(key string, field string, dest interface{}) error
+	HSetJSONFunc                   func(key string, field string, v interface{}) error
+	SSubscribeFunc                 func(channels []string) (chan Message, ISubscription, error)
+	SPublishFunc                   func(channel string, data []byte) (int, error)
+	PubSubChannelsFunc             func(pattern string) ([]string, error)
+	PubSubNumSubFunc               func(channels ...string) (map[string]int, error)
+	HGetAllFunc                    func(key string) (map[string][]byte, error)
+	HMGetFunc                      func(key string, fields ...string) ([][]byte, error)
+	HMSetFunc                      func(key string, values map[string][]byte) error
+	HSetNXFunc                     func(key string, field string, data []byte) (bool, error)
+	HIncrByFunc                    func(key string, field string, increment int) (int, error)
+	HIncrByFloatFunc               func(key string, field string, increment float64) (float64, error)
+	IncrFunc                       func(key string) (int, error)
+	DecrFunc                       func(key string) (int, error)
+	IncrByFunc                     func(key string, increment int) (int, error)
+	DecrByFunc                     func(key string, decrement int) (int, error)
+	IncrByFloatFunc                func(key string, increment float64) (float64, error)
+	ExpireFunc                     func(key string, seconds int) (bool, error)
+	PExpireFunc                    func(key string, timeoutMS int) (bool, error)
+	ExpireAtFunc                   func(key string, at time.Time) (bool, error)
+	TTLFunc                        func(key string) (int, error)
+	PTTLFunc                       func(key string) (int, error)
+	PersistFunc                    func(key string) (bool, error)
+	TypeFunc                       func(key string) (string, error)
+	ObjectEncodingFunc             func(key string) (string, error)
+	ObjectIdleTimeFunc             func(key string) (int, error)
+	ObjectFreqFunc                 func(key string) (int, error)
+	MGetFunc                       func(keys ...string) ([][]byte, error)
+	MSetFunc                       func(values map[string][]byte) error
+	MSetNXFunc                     func(values map[string][]byte) (bool, error)
+	GetDelFunc                     func(key string) ([]byte, error)
+	GetExFunc                      func(key string, timeoutMS int) ([]byte, error)
+	GetSetFunc                     func(key string, data []byte) ([]byte, error)
+	AppendFunc                     func(key string, data []byte) (int, error)
+	StrLenFunc                     func(key string) (int, error)
+	GetRangeFunc                   func(key string, start int, end int) ([]byte, error)
+	SetRangeFunc                   func(key string, offset int, data []byte) (int, error)
+	SetBitFunc                     func(key string, offset int, value bool) (bool, error)
+	GetBitFunc                     func(key string, offset int) (bool, error)
+	BitCountFunc                   func(key string, start int, end int) (int, error)
+	BitPosFunc                     func(key string, value bool, start int) (int, error)
+	BitOpFunc                      func(operation BitOperation, destKey string, keys ...string) (int, error)
+	BitFieldFunc                   func(key string, args ...interface{}) ([]int64, error)
+	PFAddFunc                      func(key string, elements ...[]byte) (bool, error)
+	PFCountFunc                    func(keys ...string) (int, error)
+	PFMergeFunc                    func(destKey string, sourceKeys ...string) error
+	GeoAddFunc                     func(key string, locations ...GeoLocation) (int, error)
+	GeoPosFunc                     func(key string, members ...string) ([]*GeoPosition, error)
+	GeoDistFunc                    func(key string, member1 string, member2 string, unit GeoUnit) (float64, error)
+	GeoSearchFunc                  func(key string, query GeoSearchQuery) ([]GeoSearchResult, error)
+	GeoRadiusFunc                  func(key string, longitude float64, latitude float64, radius float64, unit GeoUnit) ([]GeoSearchResult, error)
+	LTrimFunc                      func(key string, start int, stop int) error
+	LSetFunc                       func(key string, index int, data []byte) error
+	LInsertFunc                    func(key string, before bool, pivot []byte, data []byte) (int, error)
+	LMoveFunc                      func(source string, destination string, from ListDirection, to ListDirection) ([]byte, error)
+	BLMoveFunc                     func(source string, destination string, from ListDirection, to ListDirection, timeout time.Duration) ([]byte, error)
+	BLPopMultiFunc                 func(keys []string, timeout time.Duration) (string, []byte, error)
+	BRPopFunc                      func(key string, timeout int) ([]byte, error)
+	BRPopMultiFunc                 func(keys []string, timeout time.Duration) (string, []byte, error)
+	UnlinkFunc                     func(keys ...string) (int, error)
+	DelPatternFunc                 func(pattern string) (int, error)
+	ScanAllFunc                    func(pattern string, options ScanOptions, fn func(keys []string) error) error
+	HScanMatchFunc                 func(key string, cursor int, match string, count int) (int, map[string][]byte, error)
+	HScanAllFunc                   func(key string, match string) (map[string][]byte, error)
+	SScanFunc                      func(key string, cursor int, match string, count int) (int, [][]byte, error)
+	SScanAllFunc                   func(key string, match string) ([][]byte, error)
+	ZScanFunc                      func(key string, cursor int, match string, count int) (int, []ZMember, error)
+	ZScanAllFunc                   func(key string, match string) ([]ZMember, error)
+	RenameFunc                     func(key string, newKey string) error
+	RenameNXFunc                   func(key string, newKey string) (bool, error)
+	CopyFunc                       func(source string, destination string, replace bool) (bool, error)
+	CopyToDBFunc                   func(source string, destination string, db int, replace bool) (bool, error)
+	DumpFunc                       func(key string) ([]byte, error)
+	RestoreFunc                    func(key string, ttlMS int, payload []byte, replace bool) error
+	SortFunc                       func(key string, options SortOptions) ([][]byte, error)
+	DBSizeFunc                     func() (int, error)
+	FlushDBFunc                    func() error
+	FlushAllFunc                   func() error
+	InfoFunc                       func(section ...string) (*Info, error)
+	ClientListFunc                 func() ([]ClientInfo, error)
+	ClientKillFunc                 func(filter ClientKillFilter) (int, error)
+	StatsFunc                      func() Stats
+	AddHookFunc                    func(hook Hook)
+	HealthDetailsFunc              func() (*HealthDetails, error)
+	ReadyFunc                      func() bool
+	WaitFunc                       func(numReplicas int, timeoutMS int) (int, error)
+	SetWithReplicationFunc         func(key string, data []byte, numReplicas int, timeoutMS int) (int, error)
+	SetPXWithReplicationFunc       func(key string, data []byte, expireMS int, numReplicas int, timeoutMS int) (int, error)
+	BloomAvailableFunc             func() (bool, error)
+	BFReserveFunc                  func(key string, errorRate float64, capacity int) error
+	BFAddFunc                      func(key string, item []byte) (bool, error)
+	BFMAddFunc                     func(key string, items ...[]byte) ([]bool, error)
+	BFExistsFunc                   func(key string, item []byte) (bool, error)
+	BFMExistsFunc                  func(key string, items ...[]byte) ([]bool, error)
+	CFReserveFunc                  func(key string, capacity int) error
+	CFAddFunc                      func(key string, item []byte) error
+	CFAddNXFunc                    func(key string, item []byte) (bool, error)
+	CFExistsFunc                   func(key string, item []byte) (bool, error)
+	CFDelFunc                      func(key string, item []byte) (bool, error)
+	CFCountFunc                    func(key string, item []byte) (int, error)
+	WithConnFunc                   func(fn func(conn redis.Conn) error) error
+	DoFunc                         func(commandName string, args ...interface{}) (interface{}, error)
+	DoCtxFunc                      func(ctx context.Context, commandName string, args ...interface{}) (interface{}, error)
+	HSetMultiFunc                  func(key string, values map[string][]byte) (int, error)
+	HSetStructFunc                 func(key string, v interface{}) error
+	HGetAllStructFunc              func(key string, dest interface{}) error
+	XPendingFunc                   func(groupName string, key string, start string, end string, count int, minIdle time.Duration) ([]XPendingEntry, error)
+	XClaimFunc                     func(groupName string, key string, consumerName string, minIdle time.Duration, ids ...string) ([]*XEvent, error)
+	XAutoClaimFunc                 func(groupName string, key string, consumerName string, minIdle time.Duration, start string, count int) (string, []*XEvent, error)
+	OnPushFunc                     func(fn func(msg PushMessage))
+	OnConnectFunc                  func(fn func(addr string))
+	OnDisconnectFunc               func(fn func(addr string, err error))
+	OnReconnectFunc                func(fn func(addr string))
+	MemoryUsageFunc                func(key string, samples int) (int64, error)
+	MemoryStatsFunc                func() (*MemoryStats, error)
+	NewMutexFuncCalled             int
+	GetPoolFuncCalled              int
+	GetFuncCalled                  int
+	SetFuncCalled                  int
+	SetNXPXFuncCalled              int
+	SetPXFuncCalled                int
+	DelFuncCalled                  int
+	ExistsFuncCalled               int
+	ScanFuncCalled                 int
+	RPushFuncCalled                int
+	LPushFuncCalled                int
+	LRangeFuncCalled               int
+	LRemFuncCalled                 int
+	LPopFuncCalled                 int
+	RPopFuncCalled                 int
+	BLPopFuncCalled                int
+	HGetFuncCalled                 int
+	HSetFuncCalled                 int
+	HScanFuncCalled                int
+	HKeysFuncCalled                int
+	HDelFuncCalled                 int
+	HLenFuncCalled                 int
+	LIndexFuncCalled               int
+	LLenFuncCalled                 int
+	SubscribeFuncCalled            int
+	PublishFuncCalled              int
+	XAddFuncCalled                 int
+	XGroupCreateFuncCalled         int
+	XReadGroupFuncCalled           int
+	XAckFuncCalled                 int
+	OnExpireFuncCalled             int
+	ExistsMultiFuncCalled          int
+	WatchKeyFuncCalled             int
+	MonitorQueueFuncCalled         int
+	GetQueueDepthsFuncCalled       int
+	ScriptKillFuncCalled           int
+	BLPopCtxFuncCalled             int
+	HGetAllMultiFuncCalled         int
+	ZAddFuncCalled                 int
+	ZRangeFuncCalled               int
+	ZRangeByScoreFuncCalled        int
+	ZRemFuncCalled                 int
+	ZIncrByFuncCalled              int
+	ZScoreFuncCalled               int
+	ZRankFuncCalled                int
+	ZCardFuncCalled                int
+	ZPopMinFuncCalled              int
+	SAddFuncCalled                 int
+	SRemFuncCalled                 int
+	SMembersFuncCalled             int
+	SIsMemberFuncCalled            int
+	SCardFuncCalled                int
+	SPopFuncCalled                 int
+	SRandMemberFuncCalled          int
+	SInterFuncCalled               int
+	SUnionFuncCalled               int
+	SDiffFuncCalled                int
+	XRangeFuncCalled               int
+	XReadFuncCalled                int
+	XLenFuncCalled                 int
+	ConsumeGroupFuncCalled         int
+	WithContextFuncCalled          int
+	PipelineFuncCalled             int
+	TxFuncCalled                   int
+	CachedFuncCalled               int
+	GetJSONFuncCalled              int
+	SetJSONFuncCalled              int
+	SetJSONPXFuncCalled            int
+	SetJSONNXPXFuncCalled          int
+	HGetJSONFuncCalled             int
+	HSetJSONFuncCalled             int
+	SSubscribeFuncCalled           int
+	SPublishFuncCalled             int
+	PubSubChannelsFuncCalled       int
+	PubSubNumSubFuncCalled         int
+	HGetAllFuncCalled              int
+	HMGetFuncCalled                int
+	HMSetFuncCalled                int
+	HSetNXFuncCalled               int
+	HIncrByFuncCalled              int
+	HIncrByFloatFuncCalled         int
+	IncrFuncCalled                 int
+	DecrFuncCalled                 int
+	IncrByFuncCalled               int
+	DecrByFuncCalled               int
+	IncrByFloatFuncCalled          int
+	ExpireFuncCalled               int
+	PExpireFuncCalled              int
+	ExpireAtFuncCalled             int
+	TTLFuncCalled                  int
+	PTTLFuncCalled                 int
+	PersistFuncCalled              int
+	TypeFuncCalled                 int
+	ObjectEncodingFuncCalled       int
+	ObjectIdleTimeFuncCalled       int
+	ObjectFreqFuncCalled           int
+	MGetFuncCalled                 int
+	MSetFuncCalled                 int
+	MSetNXFuncCalled               int
+	GetDelFuncCalled               int
+	GetExFuncCalled                int
+	GetSetFuncCalled               int
+	AppendFuncCalled               int
+	StrLenFuncCalled               int
+	GetRangeFuncCalled             int
+	SetRangeFuncCalled             int
+	SetBitFuncCalled               int
+	GetBitFuncCalled               int
+	BitCountFuncCalled             int
+	BitPosFuncCalled               int
+	BitOpFuncCalled                int
+	BitFieldFuncCalled             int
+	PFAddFuncCalled                int
+	PFCountFuncCalled              int
+	PFMergeFuncCalled              int
+	GeoAddFuncCalled               int
+	GeoPosFuncCalled               int
+	GeoDistFuncCalled              int
+	GeoSearchFuncCalled            int
+	GeoRadiusFuncCalled            int
+	LTrimFuncCalled                int
+	LSetFuncCalled                 int
+	LInsertFuncCalled              int
+	LMoveFuncCalled                int
+	BLMoveFuncCalled               int
+	BLPopMultiFuncCalled           int
+	BRPopFuncCalled                int
+	BRPopMultiFuncCalled           int
+	UnlinkFuncCalled               int
+	DelPatternFuncCalled           int
+	ScanAllFuncCalled              int
+	HScanMatchFuncCalled           int
+	HScanAllFuncCalled             int
+	SScanFuncCalled                int
+	SScanAllFuncCalled             int
+	ZScanFuncCalled                int
+	ZScanAllFuncCalled             int
+	RenameFuncCalled               int
+	RenameNXFuncCalled             int
+	CopyFuncCalled                 int
+	CopyToDBFuncCalled             int
+	DumpFuncCalled                 int
+	RestoreFuncCalled              int
+	SortFuncCalled                 int
+	DBSizeFuncCalled               int
+	FlushDBFuncCalled              int
+	FlushAllFuncCalled             int
+	InfoFuncCalled                 int
+	ClientListFuncCalled           int
+	ClientKillFuncCalled           int
+	StatsFuncCalled                int
+	AddHookFuncCalled              int
+	HealthDetailsFuncCalled        int
+	ReadyFuncCalled                int
+	WaitFuncCalled                 int
+	SetWithReplicationFuncCalled   int
+	SetPXWithReplicationFuncCalled int
+	BloomAvailableFuncCalled       int
+	BFReserveFuncCalled            int
+	BFAddFuncCalled                int
+	BFMAddFuncCalled               int
+	BFExistsFuncCalled             int
+	BFMExistsFuncCalled            int
+	CFReserveFuncCalled            int
+	CFAddFuncCalled                int
+	CFAddNXFuncCalled              int
+	CFExistsFuncCalled             int
+	CFDelFuncCalled                int
+	CFCountFuncCalled              int
+	WithConnFuncCalled             int
+	DoFuncCalled                   int
+	DoCtxFuncCalled                int
+	HSetMultiFuncCalled            int
+	HSetStructFuncCalled           int
+	HGetAllStructFuncCalled        int
+	XPendingFuncCalled             int
+	XClaimFuncCalled               int
+	XAutoClaimFuncCalled           int
+	OnPushFuncCalled               int
+	OnConnectFuncCalled            int
+	OnDisconnectFuncCalled         int
+	OnReconnectFuncCalled          int
+	MemoryUsageFuncCalled          int
+	MemoryStatsFuncCalled          int
+
+	NewMutexCalls             []MockNewMutexCall
+	GetCalls                  []MockGetCall
+	SetCalls                  []MockSetCall
+	SetNXPXCalls              []MockSetNXPXCall
+	SetPXCalls                []MockSetPXCall
+	DelCalls                  []MockDelCall
+	ExistsCalls               []MockExistsCall
+	ScanCalls                 []MockScanCall
+	RPushCalls                []MockRPushCall
+	LPushCalls                []MockLPushCall
+	LRangeCalls               []MockLRangeCall
+	LRemCalls                 []MockLRemCall
+	LPopCalls                 []MockLPopCall
+	RPopCalls                 []MockRPopCall
+	BLPopCalls                []MockBLPopCall
+	HGetCalls                 []MockHGetCall
+	HSetCalls                 []MockHSetCall
+	HScanCalls                []MockHScanCall
+	HKeysCalls                []MockHKeysCall
+	HDelCalls                 []MockHDelCall
+	HLenCalls                 []MockHLenCall
+	LIndexCalls               []MockLIndexCall
+	LLenCalls                 []MockLLenCall
+	SubscribeCalls            []MockSubscribeCall
+	PublishCalls              []MockPublishCall
+	XAddCalls                 []MockXAddCall
+	XGroupCreateCalls         []MockXGroupCreateCall
+	XReadGroupCalls           []MockXReadGroupCall
+	XAckCalls                 []MockXAckCall
+	OnExpireCalls             []MockOnExpireCall
+	ExistsMultiCalls          []MockExistsMultiCall
+	WatchKeyCalls             []MockWatchKeyCall
+	MonitorQueueCalls         []MockMonitorQueueCall
+	BLPopCtxCalls             []MockBLPopCtxCall
+	HGetAllMultiCalls         []MockHGetAllMultiCall
+	ZAddCalls                 []MockZAddCall
+	ZRangeCalls               []MockZRangeCall
+	ZRangeByScoreCalls        []MockZRangeByScoreCall
+	ZRemCalls                 []MockZRemCall
+	ZIncrByCalls              []MockZIncrByCall
+	ZScoreCalls               []MockZScoreCall
+	ZRankCalls                []MockZRankCall
+	ZCardCalls                []MockZCardCall
+	ZPopMinCalls              []MockZPopMinCall
+	SAddCalls                 []MockSAddCall
+	SRemCalls                 []MockSRemCall
+	SMembersCalls             []MockSMembersCall
+	SIsMemberCalls            []MockSIsMemberCall
+	SCardCalls                []MockSCardCall
+	SPopCalls                 []MockSPopCall
+	SRandMemberCalls          []MockSRandMemberCall
+	SInterCalls               []MockSInterCall
+	SUnionCalls               []MockSUnionCall
+	SDiffCalls                []MockSDiffCall
+	XRangeCalls               []MockXRangeCall
+	XReadCalls                []MockXReadCall
+	XLenCalls                 []MockXLenCall
+	ConsumeGroupCalls         []MockConsumeGroupCall
+	WithContextCalls          []MockWithContextCall
+	TxCalls                   []MockTxCall
+	CachedCalls               []MockCachedCall
+	GetJSONCalls              []MockGetJSONCall
+	SetJSONCalls              []MockSetJSONCall
+	SetJSONPXCalls            []MockSetJSONPXCall
+	SetJSONNXPXCalls          []MockSetJSONNXPXCall
+	HGetJSONCalls             []MockHGetJSONCall
+	HSetJSONCalls             []MockHSetJSONCall
+	SSubscribeCalls           []MockSSubscribeCall
+	SPublishCalls             []MockSPublishCall
+	PubSubChannelsCalls       []MockPubSubChannelsCall
+	PubSubNumSubCalls         []MockPubSubNumSubCall
+	HGetAllCalls              []MockHGetAllCall
+	HMGetCalls                []MockHMGetCall
+	HMSetCalls                []MockHMSetCall
+	HSetNXCalls               []MockHSetNXCall
+	HIncrByCalls              []MockHIncrByCall
+	HIncrByFloatCalls         []MockHIncrByFloatCall
+	IncrCalls                 []MockIncrCall
+	DecrCalls                 []MockDecrCall
+	IncrByCalls               []MockIncrByCall
+	DecrByCalls               []MockDecrByCall
+	IncrByFloatCalls          []MockIncrByFloatCall
+	ExpireCalls               []MockExpireCall
+	PExpireCalls              []MockPExpireCall
+	ExpireAtCalls             []MockExpireAtCall
+	TTLCalls                  []MockTTLCall
+	PTTLCalls                 []MockPTTLCall
+	PersistCalls              []MockPersistCall
+	TypeCalls                 []MockTypeCall
+	ObjectEncodingCalls       []MockObjectEncodingCall
+	ObjectIdleTimeCalls       []MockObjectIdleTimeCall
+	ObjectFreqCalls           []MockObjectFreqCall
+	MGetCalls                 []MockMGetCall
+	MSetCalls                 []MockMSetCall
+	MSetNXCalls               []MockMSetNXCall
+	GetDelCalls               []MockGetDelCall
+	GetExCalls                []MockGetExCall
+	GetSetCalls               []MockGetSetCall
+	AppendCalls               []MockAppendCall
+	StrLenCalls               []MockStrLenCall
+	GetRangeCalls             []MockGetRangeCall
+	SetRangeCalls             []MockSetRangeCall
+	SetBitCalls               []MockSetBitCall
+	GetBitCalls               []MockGetBitCall
+	BitCountCalls             []MockBitCountCall
+	BitPosCalls               []MockBitPosCall
+	BitOpCalls                []MockBitOpCall
+	BitFieldCalls             []MockBitFieldCall
+	PFAddCalls                []MockPFAddCall
+	PFCountCalls              []MockPFCountCall
+	PFMergeCalls              []MockPFMergeCall
+	GeoAddCalls               []MockGeoAddCall
+	GeoPosCalls               []MockGeoPosCall
+	GeoDistCalls              []MockGeoDistCall
+	GeoSearchCalls            []MockGeoSearchCall
+	GeoRadiusCalls            []MockGeoRadiusCall
+	LTrimCalls                []MockLTrimCall
+	LSetCalls                 []MockLSetCall
+	LInsertCalls              []MockLInsertCall
+	LMoveCalls                []MockLMoveCall
+	BLMoveCalls               []MockBLMoveCall
+	BLPopMultiCalls           []MockBLPopMultiCall
+	BRPopCalls                []MockBRPopCall
+	BRPopMultiCalls           []MockBRPopMultiCall
+	UnlinkCalls               []MockUnlinkCall
+	DelPatternCalls           []MockDelPatternCall
+	ScanAllCalls              []MockScanAllCall
+	HScanMatchCalls           []MockHScanMatchCall
+	HScanAllCalls             []MockHScanAllCall
+	SScanCalls                []MockSScanCall
+	SScanAllCalls             []MockSScanAllCall
+	ZScanCalls                []MockZScanCall
+	ZScanAllCalls             []MockZScanAllCall
+	RenameCalls               []MockRenameCall
+	RenameNXCalls             []MockRenameNXCall
+	CopyCalls                 []MockCopyCall
+	CopyToDBCalls             []MockCopyToDBCall
+	DumpCalls                 []MockDumpCall
+	RestoreCalls              []MockRestoreCall
+	SortCalls                 []MockSortCall
+	InfoCalls                 []MockInfoCall
+	ClientKillCalls           []MockClientKillCall
+	AddHookCalls              []MockAddHookCall
+	WaitCalls                 []MockWaitCall
+	SetWithReplicationCalls   []MockSetWithReplicationCall
+	SetPXWithReplicationCalls []MockSetPXWithReplicationCall
+	BFReserveCalls            []MockBFReserveCall
+	BFAddCalls                []MockBFAddCall
+	BFMAddCalls               []MockBFMAddCall
+	BFExistsCalls             []MockBFExistsCall
+	BFMExistsCalls            []MockBFMExistsCall
+	CFReserveCalls            []MockCFReserveCall
+	CFAddCalls                []MockCFAddCall
+	CFAddNXCalls              []MockCFAddNXCall
+	CFExistsCalls             []MockCFExistsCall
+	CFDelCalls                []MockCFDelCall
+	CFCountCalls              []MockCFCountCall
+	WithConnCalls             []MockWithConnCall
+	DoCalls                   []MockDoCall
+	DoCtxCalls                []MockDoCtxCall
+	HSetMultiCalls            []MockHSetMultiCall
+	HSetStructCalls           []MockHSetStructCall
+	HGetAllStructCalls        []MockHGetAllStructCall
+	XPendingCalls             []MockXPendingCall
+	XClaimCalls               []MockXClaimCall
+	XAutoClaimCalls           []MockXAutoClaimCall
+	OnPushCalls               []MockOnPushCall
+	OnConnectCalls            []MockOnConnectCall
+	OnDisconnectCalls         []MockOnDisconnectCall
+	OnReconnectCalls          []MockOnReconnectCall
+	MemoryUsageCalls          []MockMemoryUsageCall
 }
 
 // MockService implements IService
 var _ (IService) = (*MockService)(nil)
 
-// NewMutex calls NewMutexFunc and increases NewMutexFuncCalled
+// NewMutex calls NewMutexFunc, increases NewMutexFuncCalled and records the arguments in NewMutexCalls
 func (s *MockService) NewMutex(name string, options ...redsync.Option) *redsync.Mutex {
 	s.NewMutexFuncCalled++
+	s.NewMutexCalls = append(s.NewMutexCalls, MockNewMutexCall{Name: name, Options: options})
 
 	return s.NewMutexFunc(name, options...)
 }
@@ -91,205 +573,1453 @@ func (s *MockService) GetPool() *redis.Pool {
 	return s.GetPoolFunc()
 }
 
-// Get calls GetFunc and increases GetFuncCalled
+// Get calls GetFunc, increases GetFuncCalled and records the arguments in GetCalls
 func (s *MockService) Get(key string) ([]byte, error) {
 	s.GetFuncCalled++
+	s.GetCalls = append(s.GetCalls, MockGetCall{Key: key})
 
 	return s.GetFunc(key)
 }
 
-// Set calls SetFunc and increases SetFuncCalled
+// Set calls SetFunc, increases SetFuncCalled and records the arguments in SetCalls
 func (s *MockService) Set(key string, data []byte) error {
 	s.SetFuncCalled++
+	s.SetCalls = append(s.SetCalls, MockSetCall{Key: key, Data: data})
 
 	return s.SetFunc(key, data)
 }
 
-// SetNXPX calls SetNXPXFunc and increases SetNXPXFuncCalled
+// SetNXPX calls SetNXPXFunc, increases SetNXPXFuncCalled and records the arguments in SetNXPXCalls
 func (s *MockService) SetNXPX(key string, data []byte, timeoutMS int) error {
 	s.SetNXPXFuncCalled++
+	s.SetNXPXCalls = append(s.SetNXPXCalls, MockSetNXPXCall{Key: key, Data: data, TimeoutMS: timeoutMS})
 
 	return s.SetNXPXFunc(key, data, timeoutMS)
 }
 
-// SetPX calls SetPXFunc and increases SetPXFuncCalled
+// SetPX calls SetPXFunc, increases SetPXFuncCalled and records the arguments in SetPXCalls
 func (s *MockService) SetPX(key string, data []byte, timeoutMS int) error {
 	s.SetPXFuncCalled++
+	s.SetPXCalls = append(s.SetPXCalls, MockSetPXCall{Key: key, Data: data, TimeoutMS: timeoutMS})
 
 	return s.SetPXFunc(key, data, timeoutMS)
 }
 
-// Del calls DelFunc and increases DelFuncCalled
-func (s *MockService) Del(key string) error {
+// Del calls DelFunc, increases DelFuncCalled and records the arguments in DelCalls
+func (s *MockService) Del(keys ...string) error {
 	s.DelFuncCalled++
+	s.DelCalls = append(s.DelCalls, MockDelCall{Keys: keys})
 
-	return s.DelFunc(key)
+	return s.DelFunc(keys...)
 }
 
-// Exists calls ExistsFunc and increases ExistsFuncCalled
+// Exists calls ExistsFunc, increases ExistsFuncCalled and records the arguments in ExistsCalls
 func (s *MockService) Exists(key string) (bool, error) {
 	s.ExistsFuncCalled++
+	s.ExistsCalls = append(s.ExistsCalls, MockExistsCall{Key: key})
 
 	return s.ExistsFunc(key)
 }
 
-// Scan calls ScanFunc and increases ScanFuncCalled
+// Scan calls ScanFunc, increases ScanFuncCalled and records the arguments in ScanCalls
 func (s *MockService) Scan(pattern string, cursor int) (int, []string, error) {
 	s.ScanFuncCalled++
+	s.ScanCalls = append(s.ScanCalls, MockScanCall{Pattern: pattern, Cursor: cursor})
 
 	return s.ScanFunc(pattern, cursor)
 }
 
-// RPush calls RPushFunc and increases RPushFuncCalled
+// RPush calls RPushFunc, increases RPushFuncCalled and records the arguments in RPushCalls
 func (s *MockService) RPush(key string, data []byte) (int, error) {
 	s.RPushFuncCalled++
+	s.RPushCalls = append(s.RPushCalls, MockRPushCall{Key: key, Data: data})
 
 	return s.RPushFunc(key, data)
 }
 
-// LPush calls LPushFunc and increases LPushFuncCalled
+// LPush calls LPushFunc, increases LPushFuncCalled and records the arguments in LPushCalls
 func (s *MockService) LPush(key string, data []byte) (int, error) {
 	s.LPushFuncCalled++
+	s.LPushCalls = append(s.LPushCalls, MockLPushCall{Key: key, Data: data})
 
 	return s.LPushFunc(key, data)
 }
 
-// LRange calls LRangeFunc and increases LRangeFuncCalled
+// LRange calls LRangeFunc, increases LRangeFuncCalled and records the arguments in LRangeCalls
 func (s *MockService) LRange(key string, start int, stop int) ([][]byte, error) {
 	s.LRangeFuncCalled++
+	s.LRangeCalls = append(s.LRangeCalls, MockLRangeCall{Key: key, Start: start, Stop: stop})
 
 	return s.LRangeFunc(key, start, stop)
 }
 
-// LRem calls LRemFunc and increases LRemFuncCalled
+// LRem calls LRemFunc, increases LRemFuncCalled and records the arguments in LRemCalls
 func (s *MockService) LRem(key string, count int, data []byte) (int, error) {
 	s.LRemFuncCalled++
+	s.LRemCalls = append(s.LRemCalls, MockLRemCall{Key: key, Count: count, Data: data})
 
 	return s.LRemFunc(key, count, data)
 }
 
-// LPop calls LPopFunc and increases LPopFuncCalled
+// LPop calls LPopFunc, increases LPopFuncCalled and records the arguments in LPopCalls
 func (s *MockService) LPop(key string) ([]byte, error) {
 	s.LPopFuncCalled++
+	s.LPopCalls = append(s.LPopCalls, MockLPopCall{Key: key})
 
 	return s.LPopFunc(key)
 }
 
-// RPop calls RPopFunc and increases RPopFuncCalled
+// RPop calls RPopFunc, increases RPopFuncCalled and records the arguments in RPopCalls
 func (s *MockService) RPop(key string) ([]byte, error) {
 	s.RPopFuncCalled++
+	s.RPopCalls = append(s.RPopCalls, MockRPopCall{Key: key})
 
 	return s.RPopFunc(key)
 }
 
-// BLPop calls BLPopFunc and increases BLPopFuncCalled
+// BLPop calls BLPopFunc, increases BLPopFuncCalled and records the arguments in BLPopCalls
 func (s *MockService) BLPop(key string, timeout int) ([]byte, error) {
 	s.BLPopFuncCalled++
+	s.BLPopCalls = append(s.BLPopCalls, MockBLPopCall{Key: key, Timeout: timeout})
 
 	return s.BLPopFunc(key, timeout)
 }
 
-// HGet calls GetFunc and increases GetFuncCalled
+// HGet calls HGetFunc, increases HGetFuncCalled and records the arguments in HGetCalls
 func (s *MockService) HGet(key string, field string) ([]byte, error) {
 	s.HGetFuncCalled++
+	s.HGetCalls = append(s.HGetCalls, MockHGetCall{Key: key, Field: field})
 
 	return s.HGetFunc(key, field)
 }
 
-// HSet calls SetFunc and increases SetFuncCalled
+// HSet calls HSetFunc, increases HSetFuncCalled and records the arguments in HSetCalls
 func (s *MockService) HSet(key string, field string, data []byte) error {
 	s.HSetFuncCalled++
+	s.HSetCalls = append(s.HSetCalls, MockHSetCall{Key: key, Field: field, Data: data})
 
 	return s.HSetFunc(key, field, data)
 }
 
-// HScan calls HScanFunc and increases HScanFuncCalled
+// HScan calls HScanFunc, increases HScanFuncCalled and records the arguments in HScanCalls
 func (s *MockService) HScan(key string, cursor int) (int, map[string][]byte, error) {
 	s.HScanFuncCalled++
+	s.HScanCalls = append(s.HScanCalls, MockHScanCall{Key: key, Cursor: cursor})
 
 	return s.HScanFunc(key, cursor)
 }
 
-// HKeys calls HKeysFunc and increases HKeysFuncCalled
+// HKeys calls HKeysFunc, increases HKeysFuncCalled and records the arguments in HKeysCalls
 func (s *MockService) HKeys(key string) ([][]byte, error) {
 	s.HKeysFuncCalled++
+	s.HKeysCalls = append(s.HKeysCalls, MockHKeysCall{Key: key})
 
 	return s.HKeysFunc(key)
 }
 
-// HDel calls HDelFunc and increases HDelFuncCalled
+// HDel calls HDelFunc, increases HDelFuncCalled and records the arguments in HDelCalls
 func (s *MockService) HDel(key string, field string) error {
 	s.HDelFuncCalled++
+	s.HDelCalls = append(s.HDelCalls, MockHDelCall{Key: key, Field: field})
 
 	return s.HDelFunc(key, field)
 }
 
-// HLen calls HLenFunc and increases HLenFuncCalled
+// HLen calls HLenFunc, increases HLenFuncCalled and records the arguments in HLenCalls
 func (s *MockService) HLen(key string) (int, error) {
 	s.HLenFuncCalled++
+	s.HLenCalls = append(s.HLenCalls, MockHLenCall{Key: key})
 
 	return s.HLenFunc(key)
 }
 
-// LIndex calls LIndexFunc and increases LIndexFuncCalled
+// LIndex calls LIndexFunc, increases LIndexFuncCalled and records the arguments in LIndexCalls
 func (s *MockService) LIndex(key string, position int) ([]byte, error) {
 	s.LIndexFuncCalled++
+	s.LIndexCalls = append(s.LIndexCalls, MockLIndexCall{Key: key, Position: position})
 
 	return s.LIndexFunc(key, position)
 }
 
-// LLen calls LLenFunc and increases LLenFuncCalled
+// LLen calls LLenFunc, increases LLenFuncCalled and records the arguments in LLenCalls
 func (s *MockService) LLen(key string) (int, error) {
 	s.LLenFuncCalled++
+	s.LLenCalls = append(s.LLenCalls, MockLLenCall{Key: key})
 
 	return s.LLenFunc(key)
 }
 
-// Subscribe calls SubscribeFunc and increases SubscribeFunc
+// Subscribe calls SubscribeFunc, increases SubscribeFuncCalled and records the arguments in SubscribeCalls
 func (s *MockService) Subscribe(channels []string) (chan Message, ISubscription, error) {
 	s.SubscribeFuncCalled++
+	s.SubscribeCalls = append(s.SubscribeCalls, MockSubscribeCall{Channels: channels})
 
 	return s.SubscribeFunc(channels)
 }
 
-// Publish calls PublishFunc and increases PublishFuncCalled
-func (s *MockService) Publish(channel string, data []byte) error {
+// Publish calls PublishFunc, increases PublishFuncCalled and records the arguments in PublishCalls
+func (s *MockService) Publish(channel string, data []byte) (int, error) {
 	s.PublishFuncCalled++
+	s.PublishCalls = append(s.PublishCalls, MockPublishCall{Channel: channel, Data: data})
 
 	return s.PublishFunc(channel, data)
 }
 
-// XAdd calls XAddFunc and increases XAddFuncCalled
+// XAdd calls XAddFunc, increases XAddFuncCalled and records the arguments in XAddCalls
 func (s *MockService) XAdd(key string, data map[string]string) (string, error) {
 	s.XAddFuncCalled++
+	s.XAddCalls = append(s.XAddCalls, MockXAddCall{Key: key, Data: data})
 
 	return s.XAddFunc(key, data)
 }
 
-// XGroupCreate calls XGroupCreateFunc and increases XGroupCreateFuncCalled
+// XGroupCreate calls XGroupCreateFunc, increases XGroupCreateFuncCalled and records the arguments in XGroupCreateCalls
 func (s *MockService) XGroupCreate(groupName string, key string, offset XGroupCreateOffset, mkStream bool, ignoreBusy bool) error {
 	s.XGroupCreateFuncCalled++
+	s.XGroupCreateCalls = append(s.XGroupCreateCalls, MockXGroupCreateCall{GroupName: groupName, Key: key, Offset: offset, MkStream: mkStream, IgnoreBusy: ignoreBusy})
 
 	return s.XGroupCreateFunc(groupName, key, offset, mkStream, ignoreBusy)
 }
 
-// XReadGroup calls XReadGroupFunc and increases XReadGroupFuncCalled
+// XReadGroup calls XReadGroupFunc, increases XReadGroupFuncCalled and records the arguments in XReadGroupCalls
 func (s *MockService) XReadGroup(groupName string, consumerName string, key string, timeout time.Duration, streamID XReadGroupStreamID) (*XEvent, error) {
 	s.XReadGroupFuncCalled++
+	s.XReadGroupCalls = append(s.XReadGroupCalls, MockXReadGroupCall{GroupName: groupName, ConsumerName: consumerName, Key: key, Timeout: timeout, StreamID: streamID})
 
 	return s.XReadGroupFunc(groupName, consumerName, key, timeout, streamID)
 }
 
-// XAck calls XAckFunc and increases XAckFuncCalled
+// XAck calls XAckFunc, increases XAckFuncCalled and records the arguments in XAckCalls
 func (s *MockService) XAck(groupName string, key string, id string) (int, error) {
 	s.XAckFuncCalled++
+	s.XAckCalls = append(s.XAckCalls, MockXAckCall{GroupName: groupName, Key: key, ID: id})
 
 	return s.XAckFunc(groupName, key, id)
 }
 
+// OnExpire calls OnExpireFunc, increases OnExpireFuncCalled and records the arguments in OnExpireCalls
+func (s *MockService) OnExpire(pattern string, fn func(key string)) {
+	s.OnExpireFuncCalled++
+	s.OnExpireCalls = append(s.OnExpireCalls, MockOnExpireCall{Pattern: pattern, Fn: fn})
+
+	s.OnExpireFunc(pattern, fn)
+}
+
+// ExistsMulti calls ExistsMultiFunc, increases ExistsMultiFuncCalled and records the arguments in ExistsMultiCalls
+func (s *MockService) ExistsMulti(keys ...string) (int, error) {
+	s.ExistsMultiFuncCalled++
+	s.ExistsMultiCalls = append(s.ExistsMultiCalls, MockExistsMultiCall{Keys: keys})
+
+	return s.ExistsMultiFunc(keys...)
+}
+
+// WatchKey calls WatchKeyFunc, increases WatchKeyFuncCalled and records the arguments in WatchKeyCalls
+func (s *MockService) WatchKey(ctx context.Context, key string) (<-chan KeyEvent, error) {
+	s.WatchKeyFuncCalled++
+	s.WatchKeyCalls = append(s.WatchKeyCalls, MockWatchKeyCall{Ctx: ctx, Key: key})
+
+	return s.WatchKeyFunc(ctx, key)
+}
+
+// MonitorQueue calls MonitorQueueFunc, increases MonitorQueueFuncCalled and records the arguments in MonitorQueueCalls
+func (s *MockService) MonitorQueue(config QueueMonitorConfig) error {
+	s.MonitorQueueFuncCalled++
+	s.MonitorQueueCalls = append(s.MonitorQueueCalls, MockMonitorQueueCall{Config: config})
+
+	return s.MonitorQueueFunc(config)
+}
+
+// GetQueueDepths calls GetQueueDepthsFunc and increases GetQueueDepthsFuncCalled
+func (s *MockService) GetQueueDepths() []QueueDepth {
+	s.GetQueueDepthsFuncCalled++
+
+	return s.GetQueueDepthsFunc()
+}
+
+// ScriptKill calls ScriptKillFunc and increases ScriptKillFuncCalled
+func (s *MockService) ScriptKill() error {
+	s.ScriptKillFuncCalled++
+
+	return s.ScriptKillFunc()
+}
+
+// BLPopCtx calls BLPopCtxFunc, increases BLPopCtxFuncCalled and records the arguments in BLPopCtxCalls
+func (s *MockService) BLPopCtx(ctx context.Context, key string, timeout time.Duration) ([]byte, error) {
+	s.BLPopCtxFuncCalled++
+	s.BLPopCtxCalls = append(s.BLPopCtxCalls, MockBLPopCtxCall{Ctx: ctx, Key: key, Timeout: timeout})
+
+	return s.BLPopCtxFunc(ctx, key, timeout)
+}
+
+// HGetAllMulti calls HGetAllMultiFunc, increases HGetAllMultiFuncCalled and records the arguments in HGetAllMultiCalls
+func (s *MockService) HGetAllMulti(keys []string) (map[string]map[string][]byte, error) {
+	s.HGetAllMultiFuncCalled++
+	s.HGetAllMultiCalls = append(s.HGetAllMultiCalls, MockHGetAllMultiCall{Keys: keys})
+
+	return s.HGetAllMultiFunc(keys)
+}
+
+// ZAdd calls ZAddFunc, increases ZAddFuncCalled and records the arguments in ZAddCalls
+func (s *MockService) ZAdd(key string, score float64, member []byte) (int, error) {
+	s.ZAddFuncCalled++
+	s.ZAddCalls = append(s.ZAddCalls, MockZAddCall{Key: key, Score: score, Member: member})
+
+	return s.ZAddFunc(key, score, member)
+}
+
+// ZRange calls ZRangeFunc, increases ZRangeFuncCalled and records the arguments in ZRangeCalls
+func (s *MockService) ZRange(key string, start int, stop int) ([][]byte, error) {
+	s.ZRangeFuncCalled++
+	s.ZRangeCalls = append(s.ZRangeCalls, MockZRangeCall{Key: key, Start: start, Stop: stop})
+
+	return s.ZRangeFunc(key, start, stop)
+}
+
+// ZRangeByScore calls ZRangeByScoreFunc, increases ZRangeByScoreFuncCalled and records the arguments in ZRangeByScoreCalls
+func (s *MockService) ZRangeByScore(key string, min string, max string) ([]ZMember, error) {
+	s.ZRangeByScoreFuncCalled++
+	s.ZRangeByScoreCalls = append(s.ZRangeByScoreCalls, MockZRangeByScoreCall{Key: key, Min: min, Max: max})
+
+	return s.ZRangeByScoreFunc(key, min, max)
+}
+
+// ZRem calls ZRemFunc, increases ZRemFuncCalled and records the arguments in ZRemCalls
+func (s *MockService) ZRem(key string, member []byte) (int, error) {
+	s.ZRemFuncCalled++
+	s.ZRemCalls = append(s.ZRemCalls, MockZRemCall{Key: key, Member: member})
+
+	return s.ZRemFunc(key, member)
+}
+
+// ZIncrBy calls ZIncrByFunc, increases ZIncrByFuncCalled and records the arguments in ZIncrByCalls
+func (s *MockService) ZIncrBy(key string, increment float64, member []byte) (float64, error) {
+	s.ZIncrByFuncCalled++
+	s.ZIncrByCalls = append(s.ZIncrByCalls, MockZIncrByCall{Key: key, Increment: increment, Member: member})
+
+	return s.ZIncrByFunc(key, increment, member)
+}
+
+// ZScore calls ZScoreFunc, increases ZScoreFuncCalled and records the arguments in ZScoreCalls
+func (s *MockService) ZScore(key string, member []byte) (float64, error) {
+	s.ZScoreFuncCalled++
+	s.ZScoreCalls = append(s.ZScoreCalls, MockZScoreCall{Key: key, Member: member})
+
+	return s.ZScoreFunc(key, member)
+}
+
+// ZRank calls ZRankFunc, increases ZRankFuncCalled and records the arguments in ZRankCalls
+func (s *MockService) ZRank(key string, member []byte) (int, error) {
+	s.ZRankFuncCalled++
+	s.ZRankCalls = append(s.ZRankCalls, MockZRankCall{Key: key, Member: member})
+
+	return s.ZRankFunc(key, member)
+}
+
+// ZCard calls ZCardFunc, increases ZCardFuncCalled and records the arguments in ZCardCalls
+func (s *MockService) ZCard(key string) (int, error) {
+	s.ZCardFuncCalled++
+	s.ZCardCalls = append(s.ZCardCalls, MockZCardCall{Key: key})
+
+	return s.ZCardFunc(key)
+}
+
+// ZPopMin calls ZPopMinFunc, increases ZPopMinFuncCalled and records the arguments in ZPopMinCalls
+func (s *MockService) ZPopMin(key string, count int) ([]ZMember, error) {
+	s.ZPopMinFuncCalled++
+	s.ZPopMinCalls = append(s.ZPopMinCalls, MockZPopMinCall{Key: key, Count: count})
+
+	return s.ZPopMinFunc(key, count)
+}
+
+// SAdd calls SAddFunc, increases SAddFuncCalled and records the arguments in SAddCalls
+func (s *MockService) SAdd(key string, members ...[]byte) (int, error) {
+	s.SAddFuncCalled++
+	s.SAddCalls = append(s.SAddCalls, MockSAddCall{Key: key, Members: members})
+
+	return s.SAddFunc(key, members...)
+}
+
+// SRem calls SRemFunc, increases SRemFuncCalled and records the arguments in SRemCalls
+func (s *MockService) SRem(key string, members ...[]byte) (int, error) {
+	s.SRemFuncCalled++
+	s.SRemCalls = append(s.SRemCalls, MockSRemCall{Key: key, Members: members})
+
+	return s.SRemFunc(key, members...)
+}
+
+// SMembers calls SMembersFunc, increases SMembersFuncCalled and records the arguments in SMembersCalls
+func (s *MockService) SMembers(key string) ([][]byte, error) {
+	s.SMembersFuncCalled++
+	s.SMembersCalls = append(s.SMembersCalls, MockSMembersCall{Key: key})
+
+	return s.SMembersFunc(key)
+}
+
+// SIsMember calls SIsMemberFunc, increases SIsMemberFuncCalled and records the arguments in SIsMemberCalls
+func (s *MockService) SIsMember(key string, member []byte) (bool, error) {
+	s.SIsMemberFuncCalled++
+	s.SIsMemberCalls = append(s.SIsMemberCalls, MockSIsMemberCall{Key: key, Member: member})
+
+	return s.SIsMemberFunc(key, member)
+}
+
+// SCard calls SCardFunc, increases SCardFuncCalled and records the arguments in SCardCalls
+func (s *MockService) SCard(key string) (int, error) {
+	s.SCardFuncCalled++
+	s.SCardCalls = append(s.SCardCalls, MockSCardCall{Key: key})
+
+	return s.SCardFunc(key)
+}
+
+// SPop calls SPopFunc, increases SPopFuncCalled and records the arguments in SPopCalls
+func (s *MockService) SPop(key string, count int) ([][]byte, error) {
+	s.SPopFuncCalled++
+	s.SPopCalls = append(s.SPopCalls, MockSPopCall{Key: key, Count: count})
+
+	return s.SPopFunc(key, count)
+}
+
+// SRandMember calls SRandMemberFunc, increases SRandMemberFuncCalled and records the arguments in SRandMemberCalls
+func (s *MockService) SRandMember(key string, count int) ([][]byte, error) {
+	s.SRandMemberFuncCalled++
+	s.SRandMemberCalls = append(s.SRandMemberCalls, MockSRandMemberCall{Key: key, Count: count})
+
+	return s.SRandMemberFunc(key, count)
+}
+
+// SInter calls SInterFunc, increases SInterFuncCalled and records the arguments in SInterCalls
+func (s *MockService) SInter(keys ...string) ([][]byte, error) {
+	s.SInterFuncCalled++
+	s.SInterCalls = append(s.SInterCalls, MockSInterCall{Keys: keys})
+
+	return s.SInterFunc(keys...)
+}
+
+// SUnion calls SUnionFunc, increases SUnionFuncCalled and records the arguments in SUnionCalls
+func (s *MockService) SUnion(keys ...string) ([][]byte, error) {
+	s.SUnionFuncCalled++
+	s.SUnionCalls = append(s.SUnionCalls, MockSUnionCall{Keys: keys})
+
+	return s.SUnionFunc(keys...)
+}
+
+// SDiff calls SDiffFunc, increases SDiffFuncCalled and records the arguments in SDiffCalls
+func (s *MockService) SDiff(keys ...string) ([][]byte, error) {
+	s.SDiffFuncCalled++
+	s.SDiffCalls = append(s.SDiffCalls, MockSDiffCall{Keys: keys})
+
+	return s.SDiffFunc(keys...)
+}
+
+// XRange calls XRangeFunc, increases XRangeFuncCalled and records the arguments in XRangeCalls
+func (s *MockService) XRange(key string, start string, end string, count int) ([]*XEvent, error) {
+	s.XRangeFuncCalled++
+	s.XRangeCalls = append(s.XRangeCalls, MockXRangeCall{Key: key, Start: start, End: end, Count: count})
+
+	return s.XRangeFunc(key, start, end, count)
+}
+
+// XRead calls XReadFunc, increases XReadFuncCalled and records the arguments in XReadCalls
+func (s *MockService) XRead(key string, timeout time.Duration, lastID string, count int) ([]*XEvent, error) {
+	s.XReadFuncCalled++
+	s.XReadCalls = append(s.XReadCalls, MockXReadCall{Key: key, Timeout: timeout, LastID: lastID, Count: count})
+
+	return s.XReadFunc(key, timeout, lastID, count)
+}
+
+// XLen calls XLenFunc, increases XLenFuncCalled and records the arguments in XLenCalls
+func (s *MockService) XLen(key string) (int, error) {
+	s.XLenFuncCalled++
+	s.XLenCalls = append(s.XLenCalls, MockXLenCall{Key: key})
+
+	return s.XLenFunc(key)
+}
+
+// ConsumeGroup calls ConsumeGroupFunc, increases ConsumeGroupFuncCalled and records the arguments in ConsumeGroupCalls
+func (s *MockService) ConsumeGroup(groupName string, consumerName string, key string) (chan XMessage, IConsumerGroup, error) {
+	s.ConsumeGroupFuncCalled++
+	s.ConsumeGroupCalls = append(s.ConsumeGroupCalls, MockConsumeGroupCall{GroupName: groupName, ConsumerName: consumerName, Key: key})
+
+	return s.ConsumeGroupFunc(groupName, consumerName, key)
+}
+
+// WithContext calls WithContextFunc, increases WithContextFuncCalled and records the arguments in WithContextCalls
+func (s *MockService) WithContext(ctx context.Context) IService {
+	s.WithContextFuncCalled++
+	s.WithContextCalls = append(s.WithContextCalls, MockWithContextCall{Ctx: ctx})
+
+	return s.WithContextFunc(ctx)
+}
+
+// Pipeline calls PipelineFunc and increases PipelineFuncCalled
+func (s *MockService) Pipeline() IPipeline {
+	s.PipelineFuncCalled++
+
+	return s.PipelineFunc()
+}
+
+// Tx calls TxFunc, increases TxFuncCalled and records the arguments in TxCalls
+func (s *MockService) Tx(watchKeys []string, fn func(tx ITx) error) ([]interface{}, error) {
+	s.TxFuncCalled++
+	s.TxCalls = append(s.TxCalls, MockTxCall{WatchKeys: watchKeys, Fn: fn})
+
+	return s.TxFunc(watchKeys, fn)
+}
+
+// Cached calls CachedFunc, increases CachedFuncCalled and records the arguments in CachedCalls
+func (s *MockService) Cached(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+	s.CachedFuncCalled++
+	s.CachedCalls = append(s.CachedCalls, MockCachedCall{Key: key, TTL: ttl, Loader: loader})
+
+	return s.CachedFunc(key, ttl, loader)
+}
+
+// GetJSON calls GetJSONFunc, increases GetJSONFuncCalled and records the arguments in GetJSONCalls
+func (s *MockService) GetJSON(key string, dest interface{}) error {
+	s.GetJSONFuncCalled++
+	s.GetJSONCalls = append(s.GetJSONCalls, MockGetJSONCall{Key: key, Dest: dest})
+
+	return s.GetJSONFunc(key, dest)
+}
+
+// SetJSON calls SetJSONFunc, increases SetJSONFuncCalled and records the arguments in SetJSONCalls
+func (s *MockService) SetJSON(key string, v interface{}) error {
+	s.SetJSONFuncCalled++
+	s.SetJSONCalls = append(s.SetJSONCalls, MockSetJSONCall{Key: key, V: v})
+
+	return s.SetJSONFunc(key, v)
+}
+
+// SetJSONPX calls SetJSONPXFunc, increases SetJSONPXFuncCalled and records the arguments in SetJSONPXCalls
+func (s *MockService) SetJSONPX(key string, v interface{}, timeoutMS int) error {
+	s.SetJSONPXFuncCalled++
+	s.SetJSONPXCalls = append(s.SetJSONPXCalls, MockSetJSONPXCall{Key: key, V: v, TimeoutMS: timeoutMS})
+
+	return s.SetJSONPXFunc(key, v, timeoutMS)
+}
+
+// SetJSONNXPX calls SetJSONNXPXFunc, increases SetJSONNXPXFuncCalled and records the arguments in SetJSONNXPXCalls
+func (s *MockService) SetJSONNXPX(key string, v interface{}, timeoutMS int) error {
+	s.SetJSONNXPXFuncCalled++
+	s.SetJSONNXPXCalls = append(s.SetJSONNXPXCalls, MockSetJSONNXPXCall{Key: key, V: v, TimeoutMS: timeoutMS})
+
+	return s.SetJSONNXPXFunc(key, v, timeoutMS)
+}
+
+// HGetJSON calls HGetJSONFunc, increases HGetJSONFuncCalled and records the arguments in HGetJSONCalls
+func (s *MockService) HGetJSON(key string, field string, dest interface{}) error {
+	s.HGetJSONFuncCalled++
+	s.HGetJSONCalls = append(s.HGetJSONCalls, MockHGetJSONCall{Key: key, Field: field, Dest: dest})
+
+	return s.HGetJSONFunc(key, field, dest)
+}
+
+// HSetJSON calls HSetJSONFunc, increases HSetJSONFuncCalled and records the arguments in HSetJSONCalls
+func (s *MockService) HSetJSON(key string, field string, v interface{}) error {
+	s.HSetJSONFuncCalled++
+	s.HSetJSONCalls = append(s.HSetJSONCalls, MockHSetJSONCall{Key: key, Field: field, V: v})
+
+	return s.HSetJSONFunc(key, field, v)
+}
+
+// SSubscribe calls SSubscribeFunc, increases SSubscribeFuncCalled and records the arguments in SSubscribeCalls
+func (s *MockService) SSubscribe(channels []string) (chan Message, ISubscription, error) {
+	s.SSubscribeFuncCalled++
+	s.SSubscribeCalls = append(s.SSubscribeCalls, MockSSubscribeCall{Channels: channels})
+
+	return s.SSubscribeFunc(channels)
+}
+
+// SPublish calls SPublishFunc, increases SPublishFuncCalled and records the arguments in SPublishCalls
+func (s *MockService) SPublish(channel string, data []byte) (int, error) {
+	s.SPublishFuncCalled++
+	s.SPublishCalls = append(s.SPublishCalls, MockSPublishCall{Channel: channel, Data: data})
+
+	return s.SPublishFunc(channel, data)
+}
+
+// PubSubChannels calls PubSubChannelsFunc, increases PubSubChannelsFuncCalled and records the arguments in PubSubChannelsCalls
+func (s *MockService) PubSubChannels(pattern string) ([]string, error) {
+	s.PubSubChannelsFuncCalled++
+	s.PubSubChannelsCalls = append(s.PubSubChannelsCalls, MockPubSubChannelsCall{Pattern: pattern})
+
+	return s.PubSubChannelsFunc(pattern)
+}
+
+// PubSubNumSub calls PubSubNumSubFunc, increases PubSubNumSubFuncCalled and records the arguments in PubSubNumSubCalls
+func (s *MockService) PubSubNumSub(channels ...string) (map[string]int, error) {
+	s.PubSubNumSubFuncCalled++
+	s.PubSubNumSubCalls = append(s.PubSubNumSubCalls, MockPubSubNumSubCall{Channels: channels})
+
+	return s.PubSubNumSubFunc(channels...)
+}
+
+// HGetAll calls HGetAllFunc, increases HGetAllFuncCalled and records the arguments in HGetAllCalls
+func (s *MockService) HGetAll(key string) (map[string][]byte, error) {
+	s.HGetAllFuncCalled++
+	s.HGetAllCalls = append(s.HGetAllCalls, MockHGetAllCall{Key: key})
+
+	return s.HGetAllFunc(key)
+}
+
+// HMGet calls HMGetFunc, increases HMGetFuncCalled and records the arguments in HMGetCalls
+func (s *MockService) HMGet(key string, fields ...string) ([][]byte, error) {
+	s.HMGetFuncCalled++
+	s.HMGetCalls = append(s.HMGetCalls, MockHMGetCall{Key: key, Fields: fields})
+
+	return s.HMGetFunc(key, fields...)
+}
+
+// HMSet calls HMSetFunc, increases HMSetFuncCalled and records the arguments in HMSetCalls
+func (s *MockService) HMSet(key string, values map[string][]byte) error {
+	s.HMSetFuncCalled++
+	s.HMSetCalls = append(s.HMSetCalls, MockHMSetCall{Key: key, Values: values})
+
+	return s.HMSetFunc(key, values)
+}
+
+// HSetNX calls HSetNXFunc, increases HSetNXFuncCalled and records the arguments in HSetNXCalls
+func (s *MockService) HSetNX(key string, field string, data []byte) (bool, error) {
+	s.HSetNXFuncCalled++
+	s.HSetNXCalls = append(s.HSetNXCalls, MockHSetNXCall{Key: key, Field: field, Data: data})
+
+	return s.HSetNXFunc(key, field, data)
+}
+
+// HIncrBy calls HIncrByFunc, increases HIncrByFuncCalled and records the arguments in HIncrByCalls
+func (s *MockService) HIncrBy(key string, field string, increment int) (int, error) {
+	s.HIncrByFuncCalled++
+	s.HIncrByCalls = append(s.HIncrByCalls, MockHIncrByCall{Key: key, Field: field, Increment: increment})
+
+	return s.HIncrByFunc(key, field, increment)
+}
+
+// HIncrByFloat calls HIncrByFloatFunc, increases HIncrByFloatFuncCalled and records the arguments in HIncrByFloatCalls
+func (s *MockService) HIncrByFloat(key string, field string, increment float64) (float64, error) {
+	s.HIncrByFloatFuncCalled++
+	s.HIncrByFloatCalls = append(s.HIncrByFloatCalls, MockHIncrByFloatCall{Key: key, Field: field, Increment: increment})
+
+	return s.HIncrByFloatFunc(key, field, increment)
+}
+
+// Incr calls IncrFunc, increases IncrFuncCalled and records the arguments in IncrCalls
+func (s *MockService) Incr(key string) (int, error) {
+	s.IncrFuncCalled++
+	s.IncrCalls = append(s.IncrCalls, MockIncrCall{Key: key})
+
+	return s.IncrFunc(key)
+}
+
+// Decr calls DecrFunc, increases DecrFuncCalled and records the arguments in DecrCalls
+func (s *MockService) Decr(key string) (int, error) {
+	s.DecrFuncCalled++
+	s.DecrCalls = append(s.DecrCalls, MockDecrCall{Key: key})
+
+	return s.DecrFunc(key)
+}
+
+// IncrBy calls IncrByFunc, increases IncrByFuncCalled and records the arguments in IncrByCalls
+func (s *MockService) IncrBy(key string, increment int) (int, error) {
+	s.IncrByFuncCalled++
+	s.IncrByCalls = append(s.IncrByCalls, MockIncrByCall{Key: key, Increment: increment})
+
+	return s.IncrByFunc(key, increment)
+}
+
+// DecrBy calls DecrByFunc, increases DecrByFuncCalled and records the arguments in DecrByCalls
+func (s *MockService) DecrBy(key string, decrement int) (int, error) {
+	s.DecrByFuncCalled++
+	s.DecrByCalls = append(s.DecrByCalls, MockDecrByCall{Key: key, Decrement: decrement})
+
+	return s.DecrByFunc(key, decrement)
+}
+
+// IncrByFloat calls IncrByFloatFunc, increases IncrByFloatFuncCalled and records the arguments in IncrByFloatCalls
+func (s *MockService) IncrByFloat(key string, increment float64) (float64, error) {
+	s.IncrByFloatFuncCalled++
+	s.IncrByFloatCalls = append(s.IncrByFloatCalls, MockIncrByFloatCall{Key: key, Increment: increment})
+
+	return s.IncrByFloatFunc(key, increment)
+}
+
+// Expire calls ExpireFunc, increases ExpireFuncCalled and records the arguments in ExpireCalls
+func (s *MockService) Expire(key string, seconds int) (bool, error) {
+	s.ExpireFuncCalled++
+	s.ExpireCalls = append(s.ExpireCalls, MockExpireCall{Key: key, Seconds: seconds})
+
+	return s.ExpireFunc(key, seconds)
+}
+
+// PExpire calls PExpireFunc, increases PExpireFuncCalled and records the arguments in PExpireCalls
+func (s *MockService) PExpire(key string, timeoutMS int) (bool, error) {
+	s.PExpireFuncCalled++
+	s.PExpireCalls = append(s.PExpireCalls, MockPExpireCall{Key: key, TimeoutMS: timeoutMS})
+
+	return s.PExpireFunc(key, timeoutMS)
+}
+
+// ExpireAt calls ExpireAtFunc, increases ExpireAtFuncCalled and records the arguments in ExpireAtCalls
+func (s *MockService) ExpireAt(key string, at time.Time) (bool, error) {
+	s.ExpireAtFuncCalled++
+	s.ExpireAtCalls = append(s.ExpireAtCalls, MockExpireAtCall{Key: key, At: at})
+
+	return s.ExpireAtFunc(key, at)
+}
+
+// TTL calls TTLFunc, increases TTLFuncCalled and records the arguments in TTLCalls
+func (s *MockService) TTL(key string) (int, error) {
+	s.TTLFuncCalled++
+	s.TTLCalls = append(s.TTLCalls, MockTTLCall{Key: key})
+
+	return s.TTLFunc(key)
+}
+
+// PTTL calls PTTLFunc, increases PTTLFuncCalled and records the arguments in PTTLCalls
+func (s *MockService) PTTL(key string) (int, error) {
+	s.PTTLFuncCalled++
+	s.PTTLCalls = append(s.PTTLCalls, MockPTTLCall{Key: key})
+
+	return s.PTTLFunc(key)
+}
+
+// Persist calls PersistFunc, increases PersistFuncCalled and records the arguments in PersistCalls
+func (s *MockService) Persist(key string) (bool, error) {
+	s.PersistFuncCalled++
+	s.PersistCalls = append(s.PersistCalls, MockPersistCall{Key: key})
+
+	return s.PersistFunc(key)
+}
+
+// Type calls TypeFunc, increases TypeFuncCalled and records the arguments in TypeCalls
+func (s *MockService) Type(key string) (string, error) {
+	s.TypeFuncCalled++
+	s.TypeCalls = append(s.TypeCalls, MockTypeCall{Key: key})
+
+	return s.TypeFunc(key)
+}
+
+// ObjectEncoding calls ObjectEncodingFunc, increases ObjectEncodingFuncCalled and records the arguments in ObjectEncodingCalls
+func (s *MockService) ObjectEncoding(key string) (string, error) {
+	s.ObjectEncodingFuncCalled++
+	s.ObjectEncodingCalls = append(s.ObjectEncodingCalls, MockObjectEncodingCall{Key: key})
+
+	return s.ObjectEncodingFunc(key)
+}
+
+// ObjectIdleTime calls ObjectIdleTimeFunc, increases ObjectIdleTimeFuncCalled and records the arguments in ObjectIdleTimeCalls
+func (s *MockService) ObjectIdleTime(key string) (int, error) {
+	s.ObjectIdleTimeFuncCalled++
+	s.ObjectIdleTimeCalls = append(s.ObjectIdleTimeCalls, MockObjectIdleTimeCall{Key: key})
+
+	return s.ObjectIdleTimeFunc(key)
+}
+
+// ObjectFreq calls ObjectFreqFunc, increases ObjectFreqFuncCalled and records the arguments in ObjectFreqCalls
+func (s *MockService) ObjectFreq(key string) (int, error) {
+	s.ObjectFreqFuncCalled++
+	s.ObjectFreqCalls = append(s.ObjectFreqCalls, MockObjectFreqCall{Key: key})
+
+	return s.ObjectFreqFunc(key)
+}
+
+// MGet calls MGetFunc, increases MGetFuncCalled and records the arguments in MGetCalls
+func (s *MockService) MGet(keys ...string) ([][]byte, error) {
+	s.MGetFuncCalled++
+	s.MGetCalls = append(s.MGetCalls, MockMGetCall{Keys: keys})
+
+	return s.MGetFunc(keys...)
+}
+
+// MSet calls MSetFunc, increases MSetFuncCalled and records the arguments in MSetCalls
+func (s *MockService) MSet(values map[string][]byte) error {
+	s.MSetFuncCalled++
+	s.MSetCalls = append(s.MSetCalls, MockMSetCall{Values: values})
+
+	return s.MSetFunc(values)
+}
+
+// MSetNX calls MSetNXFunc, increases MSetNXFuncCalled and records the arguments in MSetNXCalls
+func (s *MockService) MSetNX(values map[string][]byte) (bool, error) {
+	s.MSetNXFuncCalled++
+	s.MSetNXCalls = append(s.MSetNXCalls, MockMSetNXCall{Values: values})
+
+	return s.MSetNXFunc(values)
+}
+
+// GetDel calls GetDelFunc, increases GetDelFuncCalled and records the arguments in GetDelCalls
+func (s *MockService) GetDel(key string) ([]byte, error) {
+	s.GetDelFuncCalled++
+	s.GetDelCalls = append(s.GetDelCalls, MockGetDelCall{Key: key})
+
+	return s.GetDelFunc(key)
+}
+
+// GetEx calls GetExFunc, increases GetExFuncCalled and records the arguments in GetExCalls
+func (s *MockService) GetEx(key string, timeoutMS int) ([]byte, error) {
+	s.GetExFuncCalled++
+	s.GetExCalls = append(s.GetExCalls, MockGetExCall{Key: key, TimeoutMS: timeoutMS})
+
+	return s.GetExFunc(key, timeoutMS)
+}
+
+// GetSet calls GetSetFunc, increases GetSetFuncCalled and records the arguments in GetSetCalls
+func (s *MockService) GetSet(key string, data []byte) ([]byte, error) {
+	s.GetSetFuncCalled++
+	s.GetSetCalls = append(s.GetSetCalls, MockGetSetCall{Key: key, Data: data})
+
+	return s.GetSetFunc(key, data)
+}
+
+// Append calls AppendFunc, increases AppendFuncCalled and records the arguments in AppendCalls
+func (s *MockService) Append(key string, data []byte) (int, error) {
+	s.AppendFuncCalled++
+	s.AppendCalls = append(s.AppendCalls, MockAppendCall{Key: key, Data: data})
+
+	return s.AppendFunc(key, data)
+}
+
+// StrLen calls StrLenFunc, increases StrLenFuncCalled and records the arguments in StrLenCalls
+func (s *MockService) StrLen(key string) (int, error) {
+	s.StrLenFuncCalled++
+	s.StrLenCalls = append(s.StrLenCalls, MockStrLenCall{Key: key})
+
+	return s.StrLenFunc(key)
+}
+
+// GetRange calls GetRangeFunc, increases GetRangeFuncCalled and records the arguments in GetRangeCalls
+func (s *MockService) GetRange(key string, start int, end int) ([]byte, error) {
+	s.GetRangeFuncCalled++
+	s.GetRangeCalls = append(s.GetRangeCalls, MockGetRangeCall{Key: key, Start: start, End: end})
+
+	return s.GetRangeFunc(key, start, end)
+}
+
+// SetRange calls SetRangeFunc, increases SetRangeFuncCalled and records the arguments in SetRangeCalls
+func (s *MockService) SetRange(key string, offset int, data []byte) (int, error) {
+	s.SetRangeFuncCalled++
+	s.SetRangeCalls = append(s.SetRangeCalls, MockSetRangeCall{Key: key, Offset: offset, Data: data})
+
+	return s.SetRangeFunc(key, offset, data)
+}
+
+// SetBit calls SetBitFunc, increases SetBitFuncCalled and records the arguments in SetBitCalls
+func (s *MockService) SetBit(key string, offset int, value bool) (bool, error) {
+	s.SetBitFuncCalled++
+	s.SetBitCalls = append(s.SetBitCalls, MockSetBitCall{Key: key, Offset: offset, Value: value})
+
+	return s.SetBitFunc(key, offset, value)
+}
+
+// GetBit calls GetBitFunc, increases GetBitFuncCalled and records the arguments in GetBitCalls
+func (s *MockService) GetBit(key string, offset int) (bool, error) {
+	s.GetBitFuncCalled++
+	s.GetBitCalls = append(s.GetBitCalls, MockGetBitCall{Key: key, Offset: offset})
+
+	return s.GetBitFunc(key, offset)
+}
+
+// BitCount calls BitCountFunc, increases BitCountFuncCalled and records the arguments in BitCountCalls
+func (s *MockService) BitCount(key string, start int, end int) (int, error) {
+	s.BitCountFuncCalled++
+	s.BitCountCalls = append(s.BitCountCalls, MockBitCountCall{Key: key, Start: start, End: end})
+
+	return s.BitCountFunc(key, start, end)
+}
+
+// BitPos calls BitPosFunc, increases BitPosFuncCalled and records the arguments in BitPosCalls
+func (s *MockService) BitPos(key string, value bool, start int) (int, error) {
+	s.BitPosFuncCalled++
+	s.BitPosCalls = append(s.BitPosCalls, MockBitPosCall{Key: key, Value: value, Start: start})
+
+	return s.BitPosFunc(key, value, start)
+}
+
+// BitOp calls BitOpFunc, increases BitOpFuncCalled and records the arguments in BitOpCalls
+func (s *MockService) BitOp(operation BitOperation, destKey string, keys ...string) (int, error) {
+	s.BitOpFuncCalled++
+	s.BitOpCalls = append(s.BitOpCalls, MockBitOpCall{Operation: operation, DestKey: destKey, Keys: keys})
+
+	return s.BitOpFunc(operation, destKey, keys...)
+}
+
+// BitField calls BitFieldFunc, increases BitFieldFuncCalled and records the arguments in BitFieldCalls
+func (s *MockService) BitField(key string, args ...interface{}) ([]int64, error) {
+	s.BitFieldFuncCalled++
+	s.BitFieldCalls = append(s.BitFieldCalls, MockBitFieldCall{Key: key, Args: args})
+
+	return s.BitFieldFunc(key, args...)
+}
+
+// PFAdd calls PFAddFunc, increases PFAddFuncCalled and records the arguments in PFAddCalls
+func (s *MockService) PFAdd(key string, elements ...[]byte) (bool, error) {
+	s.PFAddFuncCalled++
+	s.PFAddCalls = append(s.PFAddCalls, MockPFAddCall{Key: key, Elements: elements})
+
+	return s.PFAddFunc(key, elements...)
+}
+
+// PFCount calls PFCountFunc, increases PFCountFuncCalled and records the arguments in PFCountCalls
+func (s *MockService) PFCount(keys ...string) (int, error) {
+	s.PFCountFuncCalled++
+	s.PFCountCalls = append(s.PFCountCalls, MockPFCountCall{Keys: keys})
+
+	return s.PFCountFunc(keys...)
+}
+
+// PFMerge calls PFMergeFunc, increases PFMergeFuncCalled and records the arguments in PFMergeCalls
+func (s *MockService) PFMerge(destKey string, sourceKeys ...string) error {
+	s.PFMergeFuncCalled++
+	s.PFMergeCalls = append(s.PFMergeCalls, MockPFMergeCall{DestKey: destKey, SourceKeys: sourceKeys})
+
+	return s.PFMergeFunc(destKey, sourceKeys...)
+}
+
+// GeoAdd calls GeoAddFunc, increases GeoAddFuncCalled and records the arguments in GeoAddCalls
+func (s *MockService) GeoAdd(key string, locations ...GeoLocation) (int, error) {
+	s.GeoAddFuncCalled++
+	s.GeoAddCalls = append(s.GeoAddCalls, MockGeoAddCall{Key: key, Locations: locations})
+
+	return s.GeoAddFunc(key, locations...)
+}
+
+// GeoPos calls GeoPosFunc, increases GeoPosFuncCalled and records the arguments in GeoPosCalls
+func (s *MockService) GeoPos(key string, members ...string) ([]*GeoPosition, error) {
+	s.GeoPosFuncCalled++
+	s.GeoPosCalls = append(s.GeoPosCalls, MockGeoPosCall{Key: key, Members: members})
+
+	return s.GeoPosFunc(key, members...)
+}
+
+// GeoDist calls GeoDistFunc, increases GeoDistFuncCalled and records the arguments in GeoDistCalls
+func (s *MockService) GeoDist(key string, member1 string, member2 string, unit GeoUnit) (float64, error) {
+	s.GeoDistFuncCalled++
+	s.GeoDistCalls = append(s.GeoDistCalls, MockGeoDistCall{Key: key, Member1: member1, Member2: member2, Unit: unit})
+
+	return s.GeoDistFunc(key, member1, member2, unit)
+}
+
+// GeoSearch calls GeoSearchFunc, increases GeoSearchFuncCalled and records the arguments in GeoSearchCalls
+func (s *MockService) GeoSearch(key string, query GeoSearchQuery) ([]GeoSearchResult, error) {
+	s.GeoSearchFuncCalled++
+	s.GeoSearchCalls = append(s.GeoSearchCalls, MockGeoSearchCall{Key: key, Query: query})
+
+	return s.GeoSearchFunc(key, query)
+}
+
+// GeoRadius calls GeoRadiusFunc, increases GeoRadiusFuncCalled and records the arguments in GeoRadiusCalls
+func (s *MockService) GeoRadius(key string, longitude float64, latitude float64, radius float64, unit GeoUnit) ([]GeoSearchResult, error) {
+	s.GeoRadiusFuncCalled++
+	s.GeoRadiusCalls = append(s.GeoRadiusCalls, MockGeoRadiusCall{Key: key, Longitude: longitude, Latitude: latitude, Radius: radius, Unit: unit})
+
+	return s.GeoRadiusFunc(key, longitude, latitude, radius, unit)
+}
+
+// LTrim calls LTrimFunc, increases LTrimFuncCalled and records the arguments in LTrimCalls
+func (s *MockService) LTrim(key string, start int, stop int) error {
+	s.LTrimFuncCalled++
+	s.LTrimCalls = append(s.LTrimCalls, MockLTrimCall{Key: key, Start: start, Stop: stop})
+
+	return s.LTrimFunc(key, start, stop)
+}
+
+// LSet calls LSetFunc, increases LSetFuncCalled and records the arguments in LSetCalls
+func (s *MockService) LSet(key string, index int, data []byte) error {
+	s.LSetFuncCalled++
+	s.LSetCalls = append(s.LSetCalls, MockLSetCall{Key: key, Index: index, Data: data})
+
+	return s.LSetFunc(key, index, data)
+}
+
+// LInsert calls LInsertFunc, increases LInsertFuncCalled and records the arguments in LInsertCalls
+func (s *MockService) LInsert(key string, before bool, pivot []byte, data []byte) (int, error) {
+	s.LInsertFuncCalled++
+	s.LInsertCalls = append(s.LInsertCalls, MockLInsertCall{Key: key, Before: before, Pivot: pivot, Data: data})
+
+	return s.LInsertFunc(key, before, pivot, data)
+}
+
+// LMove calls LMoveFunc, increases LMoveFuncCalled and records the arguments in LMoveCalls
+func (s *MockService) LMove(source string, destination string, from ListDirection, to ListDirection) ([]byte, error) {
+	s.LMoveFuncCalled++
+	s.LMoveCalls = append(s.LMoveCalls, MockLMoveCall{Source: source, Destination: destination, From: from, To: to})
+
+	return s.LMoveFunc(source, destination, from, to)
+}
+
+// BLMove calls BLMoveFunc, increases BLMoveFuncCalled and records the arguments in BLMoveCalls
+func (s *MockService) BLMove(source string, destination string, from ListDirection, to ListDirection, timeout time.Duration) ([]byte, error) {
+	s.BLMoveFuncCalled++
+	s.BLMoveCalls = append(s.BLMoveCalls, MockBLMoveCall{Source: source, Destination: destination, From: from, To: to, Timeout: timeout})
+
+	return s.BLMoveFunc(source, destination, from, to, timeout)
+}
+
+// BLPopMulti calls BLPopMultiFunc, increases BLPopMultiFuncCalled and records the arguments in BLPopMultiCalls
+func (s *MockService) BLPopMulti(keys []string, timeout time.Duration) (string, []byte, error) {
+	s.BLPopMultiFuncCalled++
+	s.BLPopMultiCalls = append(s.BLPopMultiCalls, MockBLPopMultiCall{Keys: keys, Timeout: timeout})
+
+	return s.BLPopMultiFunc(keys, timeout)
+}
+
+// BRPop calls BRPopFunc, increases BRPopFuncCalled and records the arguments in BRPopCalls
+func (s *MockService) BRPop(key string, timeout int) ([]byte, error) {
+	s.BRPopFuncCalled++
+	s.BRPopCalls = append(s.BRPopCalls, MockBRPopCall{Key: key, Timeout: timeout})
+
+	return s.BRPopFunc(key, timeout)
+}
+
+// BRPopMulti calls BRPopMultiFunc, increases BRPopMultiFuncCalled and records the arguments in BRPopMultiCalls
+func (s *MockService) BRPopMulti(keys []string, timeout time.Duration) (string, []byte, error) {
+	s.BRPopMultiFuncCalled++
+	s.BRPopMultiCalls = append(s.BRPopMultiCalls, MockBRPopMultiCall{Keys: keys, Timeout: timeout})
+
+	return s.BRPopMultiFunc(keys, timeout)
+}
+
+// Unlink calls UnlinkFunc, increases UnlinkFuncCalled and records the arguments in UnlinkCalls
+func (s *MockService) Unlink(keys ...string) (int, error) {
+	s.UnlinkFuncCalled++
+	s.UnlinkCalls = append(s.UnlinkCalls, MockUnlinkCall{Keys: keys})
+
+	return s.UnlinkFunc(keys...)
+}
+
+// DelPattern calls DelPatternFunc, increases DelPatternFuncCalled and records the arguments in DelPatternCalls
+func (s *MockService) DelPattern(pattern string) (int, error) {
+	s.DelPatternFuncCalled++
+	s.DelPatternCalls = append(s.DelPatternCalls, MockDelPatternCall{Pattern: pattern})
+
+	return s.DelPatternFunc(pattern)
+}
+
+// ScanAll calls ScanAllFunc, increases ScanAllFuncCalled and records the arguments in ScanAllCalls
+func (s *MockService) ScanAll(pattern string, options ScanOptions, fn func(keys []string) error) error {
+	s.ScanAllFuncCalled++
+	s.ScanAllCalls = append(s.ScanAllCalls, MockScanAllCall{Pattern: pattern, Options: options, Fn: fn})
+
+	return s.ScanAllFunc(pattern, options, fn)
+}
+
+// HScanMatch calls HScanMatchFunc, increases HScanMatchFuncCalled and records the arguments in HScanMatchCalls
+func (s *MockService) HScanMatch(key string, cursor int, match string, count int) (int, map[string][]byte, error) {
+	s.HScanMatchFuncCalled++
+	s.HScanMatchCalls = append(s.HScanMatchCalls, MockHScanMatchCall{Key: key, Cursor: cursor, Match: match, Count: count})
+
+	return s.HScanMatchFunc(key, cursor, match, count)
+}
+
+// HScanAll calls HScanAllFunc, increases HScanAllFuncCalled and records the arguments in HScanAllCalls
+func (s *MockService) HScanAll(key string, match string) (map[string][]byte, error) {
+	s.HScanAllFuncCalled++
+	s.HScanAllCalls = append(s.HScanAllCalls, MockHScanAllCall{Key: key, Match: match})
+
+	return s.HScanAllFunc(key, match)
+}
+
+// SScan calls SScanFunc, increases SScanFuncCalled and records the arguments in SScanCalls
+func (s *MockService) SScan(key string, cursor int, match string, count int) (int, [][]byte, error) {
+	s.SScanFuncCalled++
+	s.SScanCalls = append(s.SScanCalls, MockSScanCall{Key: key, Cursor: cursor, Match: match, Count: count})
+
+	return s.SScanFunc(key, cursor, match, count)
+}
+
+// SScanAll calls SScanAllFunc, increases SScanAllFuncCalled and records the arguments in SScanAllCalls
+func (s *MockService) SScanAll(key string, match string) ([][]byte, error) {
+	s.SScanAllFuncCalled++
+	s.SScanAllCalls = append(s.SScanAllCalls, MockSScanAllCall{Key: key, Match: match})
+
+	return s.SScanAllFunc(key, match)
+}
+
+// ZScan calls ZScanFunc, increases ZScanFuncCalled and records the arguments in ZScanCalls
+func (s *MockService) ZScan(key string, cursor int, match string, count int) (int, []ZMember, error) {
+	s.ZScanFuncCalled++
+	s.ZScanCalls = append(s.ZScanCalls, MockZScanCall{Key: key, Cursor: cursor, Match: match, Count: count})
+
+	return s.ZScanFunc(key, cursor, match, count)
+}
+
+// ZScanAll calls ZScanAllFunc, increases ZScanAllFuncCalled and records the arguments in ZScanAllCalls
+func (s *MockService) ZScanAll(key string, match string) ([]ZMember, error) {
+	s.ZScanAllFuncCalled++
+	s.ZScanAllCalls = append(s.ZScanAllCalls, MockZScanAllCall{Key: key, Match: match})
+
+	return s.ZScanAllFunc(key, match)
+}
+
+// Rename calls RenameFunc, increases RenameFuncCalled and records the arguments in RenameCalls
+func (s *MockService) Rename(key string, newKey string) error {
+	s.RenameFuncCalled++
+	s.RenameCalls = append(s.RenameCalls, MockRenameCall{Key: key, NewKey: newKey})
+
+	return s.RenameFunc(key, newKey)
+}
+
+// RenameNX calls RenameNXFunc, increases RenameNXFuncCalled and records the arguments in RenameNXCalls
+func (s *MockService) RenameNX(key string, newKey string) (bool, error) {
+	s.RenameNXFuncCalled++
+	s.RenameNXCalls = append(s.RenameNXCalls, MockRenameNXCall{Key: key, NewKey: newKey})
+
+	return s.RenameNXFunc(key, newKey)
+}
+
+// Copy calls CopyFunc, increases CopyFuncCalled and records the arguments in CopyCalls
+func (s *MockService) Copy(source string, destination string, replace bool) (bool, error) {
+	s.CopyFuncCalled++
+	s.CopyCalls = append(s.CopyCalls, MockCopyCall{Source: source, Destination: destination, Replace: replace})
+
+	return s.CopyFunc(source, destination, replace)
+}
+
+// CopyToDB calls CopyToDBFunc, increases CopyToDBFuncCalled and records the arguments in CopyToDBCalls
+func (s *MockService) CopyToDB(source string, destination string, db int, replace bool) (bool, error) {
+	s.CopyToDBFuncCalled++
+	s.CopyToDBCalls = append(s.CopyToDBCalls, MockCopyToDBCall{Source: source, Destination: destination, DB: db, Replace: replace})
+
+	return s.CopyToDBFunc(source, destination, db, replace)
+}
+
+// Dump calls DumpFunc, increases DumpFuncCalled and records the arguments in DumpCalls
+func (s *MockService) Dump(key string) ([]byte, error) {
+	s.DumpFuncCalled++
+	s.DumpCalls = append(s.DumpCalls, MockDumpCall{Key: key})
+
+	return s.DumpFunc(key)
+}
+
+// Restore calls RestoreFunc, increases RestoreFuncCalled and records the arguments in RestoreCalls
+func (s *MockService) Restore(key string, ttlMS int, payload []byte, replace bool) error {
+	s.RestoreFuncCalled++
+	s.RestoreCalls = append(s.RestoreCalls, MockRestoreCall{Key: key, TTLMS: ttlMS, Payload: payload, Replace: replace})
+
+	return s.RestoreFunc(key, ttlMS, payload, replace)
+}
+
+// Sort calls SortFunc, increases SortFuncCalled and records the arguments in SortCalls
+func (s *MockService) Sort(key string, options SortOptions) ([][]byte, error) {
+	s.SortFuncCalled++
+	s.SortCalls = append(s.SortCalls, MockSortCall{Key: key, Options: options})
+
+	return s.SortFunc(key, options)
+}
+
+// DBSize calls DBSizeFunc and increases DBSizeFuncCalled
+func (s *MockService) DBSize() (int, error) {
+	s.DBSizeFuncCalled++
+
+	return s.DBSizeFunc()
+}
+
+// FlushDB calls FlushDBFunc and increases FlushDBFuncCalled
+func (s *MockService) FlushDB() error {
+	s.FlushDBFuncCalled++
+
+	return s.FlushDBFunc()
+}
+
+// FlushAll calls FlushAllFunc and increases FlushAllFuncCalled
+func (s *MockService) FlushAll() error {
+	s.FlushAllFuncCalled++
+
+	return s.FlushAllFunc()
+}
+
+// Info calls InfoFunc, increases InfoFuncCalled and records the arguments in InfoCalls
+func (s *MockService) Info(section ...string) (*Info, error) {
+	s.InfoFuncCalled++
+	s.InfoCalls = append(s.InfoCalls, MockInfoCall{Section: section})
+
+	return s.InfoFunc(section...)
+}
+
+// ClientList calls ClientListFunc and increases ClientListFuncCalled
+func (s *MockService) ClientList() ([]ClientInfo, error) {
+	s.ClientListFuncCalled++
+
+	return s.ClientListFunc()
+}
+
+// ClientKill calls ClientKillFunc, increases ClientKillFuncCalled and records the arguments in ClientKillCalls
+func (s *MockService) ClientKill(filter ClientKillFilter) (int, error) {
+	s.ClientKillFuncCalled++
+	s.ClientKillCalls = append(s.ClientKillCalls, MockClientKillCall{Filter: filter})
+
+	return s.ClientKillFunc(filter)
+}
+
+// Stats calls StatsFunc and increases StatsFuncCalled
+func (s *MockService) Stats() Stats {
+	s.StatsFuncCalled++
+
+	return s.StatsFunc()
+}
+
+// AddHook calls AddHookFunc, increases AddHookFuncCalled and records the arguments in AddHookCalls
+func (s *MockService) AddHook(hook Hook) {
+	s.AddHookFuncCalled++
+	s.AddHookCalls = append(s.AddHookCalls, MockAddHookCall{Hook: hook})
+
+	s.AddHookFunc(hook)
+}
+
+// HealthDetails calls HealthDetailsFunc and increases HealthDetailsFuncCalled
+func (s *MockService) HealthDetails() (*HealthDetails, error) {
+	s.HealthDetailsFuncCalled++
+
+	return s.HealthDetailsFunc()
+}
+
+// Ready calls ReadyFunc and increases ReadyFuncCalled
+func (s *MockService) Ready() bool {
+	s.ReadyFuncCalled++
+
+	return s.ReadyFunc()
+}
+
+// Wait calls WaitFunc, increases WaitFuncCalled and records the arguments in WaitCalls
+func (s *MockService) Wait(numReplicas int, timeoutMS int) (int, error) {
+	s.WaitFuncCalled++
+	s.WaitCalls = append(s.WaitCalls, MockWaitCall{NumReplicas: numReplicas, TimeoutMS: timeoutMS})
+
+	return s.WaitFunc(numReplicas, timeoutMS)
+}
+
+// SetWithReplication calls SetWithReplicationFunc, increases SetWithReplicationFuncCalled and records the arguments in SetWithReplicationCalls
+func (s *MockService) SetWithReplication(key string, data []byte, numReplicas int, timeoutMS int) (int, error) {
+	s.SetWithReplicationFuncCalled++
+	s.SetWithReplicationCalls = append(s.SetWithReplicationCalls, MockSetWithReplicationCall{Key: key, Data: data, NumReplicas: numReplicas, TimeoutMS: timeoutMS})
+
+	return s.SetWithReplicationFunc(key, data, numReplicas, timeoutMS)
+}
+
+// SetPXWithReplication calls SetPXWithReplicationFunc, increases SetPXWithReplicationFuncCalled and records the arguments in SetPXWithReplicationCalls
+func (s *MockService) SetPXWithReplication(key string, data []byte, expireMS int, numReplicas int, timeoutMS int) (int, error) {
+	s.SetPXWithReplicationFuncCalled++
+	s.SetPXWithReplicationCalls = append(s.SetPXWithReplicationCalls, MockSetPXWithReplicationCall{Key: key, Data: data, ExpireMS: expireMS, NumReplicas: numReplicas, TimeoutMS: timeoutMS})
+
+	return s.SetPXWithReplicationFunc(key, data, expireMS, numReplicas, timeoutMS)
+}
+
+// BloomAvailable calls BloomAvailableFunc and increases BloomAvailableFuncCalled
+func (s *MockService) BloomAvailable() (bool, error) {
+	s.BloomAvailableFuncCalled++
+
+	return s.BloomAvailableFunc()
+}
+
+// BFReserve calls BFReserveFunc, increases BFReserveFuncCalled and records the arguments in BFReserveCalls
+func (s *MockService) BFReserve(key string, errorRate float64, capacity int) error {
+	s.BFReserveFuncCalled++
+	s.BFReserveCalls = append(s.BFReserveCalls, MockBFReserveCall{Key: key, ErrorRate: errorRate, Capacity: capacity})
+
+	return s.BFReserveFunc(key, errorRate, capacity)
+}
+
+// BFAdd calls BFAddFunc, increases BFAddFuncCalled and records the arguments in BFAddCalls
+func (s *MockService) BFAdd(key string, item []byte) (bool, error) {
+	s.BFAddFuncCalled++
+	s.BFAddCalls = append(s.BFAddCalls, MockBFAddCall{Key: key, Item: item})
+
+	return s.BFAddFunc(key, item)
+}
+
+// BFMAdd calls BFMAddFunc, increases BFMAddFuncCalled and records the arguments in BFMAddCalls
+func (s *MockService) BFMAdd(key string, items ...[]byte) ([]bool, error) {
+	s.BFMAddFuncCalled++
+	s.BFMAddCalls = append(s.BFMAddCalls, MockBFMAddCall{Key: key, Items: items})
+
+	return s.BFMAddFunc(key, items...)
+}
+
+// BFExists calls BFExistsFunc, increases BFExistsFuncCalled and records the arguments in BFExistsCalls
+func (s *MockService) BFExists(key string, item []byte) (bool, error) {
+	s.BFExistsFuncCalled++
+	s.BFExistsCalls = append(s.BFExistsCalls, MockBFExistsCall{Key: key, Item: item})
+
+	return s.BFExistsFunc(key, item)
+}
+
+// BFMExists calls BFMExistsFunc, increases BFMExistsFuncCalled and records the arguments in BFMExistsCalls
+func (s *MockService) BFMExists(key string, items ...[]byte) ([]bool, error) {
+	s.BFMExistsFuncCalled++
+	s.BFMExistsCalls = append(s.BFMExistsCalls, MockBFMExistsCall{Key: key, Items: items})
+
+	return s.BFMExistsFunc(key, items...)
+}
+
+// CFReserve calls CFReserveFunc, increases CFReserveFuncCalled and records the arguments in CFReserveCalls
+func (s *MockService) CFReserve(key string, capacity int) error {
+	s.CFReserveFuncCalled++
+	s.CFReserveCalls = append(s.CFReserveCalls, MockCFReserveCall{Key: key, Capacity: capacity})
+
+	return s.CFReserveFunc(key, capacity)
+}
+
+// CFAdd calls CFAddFunc, increases CFAddFuncCalled and records the arguments in CFAddCalls
+func (s *MockService) CFAdd(key string, item []byte) error {
+	s.CFAddFuncCalled++
+	s.CFAddCalls = append(s.CFAddCalls, MockCFAddCall{Key: key, Item: item})
+
+	return s.CFAddFunc(key, item)
+}
+
+// CFAddNX calls CFAddNXFunc, increases CFAddNXFuncCalled and records the arguments in CFAddNXCalls
+func (s *MockService) CFAddNX(key string, item []byte) (bool, error) {
+	s.CFAddNXFuncCalled++
+	s.CFAddNXCalls = append(s.CFAddNXCalls, MockCFAddNXCall{Key: key, Item: item})
+
+	return s.CFAddNXFunc(key, item)
+}
+
+// CFExists calls CFExistsFunc, increases CFExistsFuncCalled and records the arguments in CFExistsCalls
+func (s *MockService) CFExists(key string, item []byte) (bool, error) {
+	s.CFExistsFuncCalled++
+	s.CFExistsCalls = append(s.CFExistsCalls, MockCFExistsCall{Key: key, Item: item})
+
+	return s.CFExistsFunc(key, item)
+}
+
+// CFDel calls CFDelFunc, increases CFDelFuncCalled and records the arguments in CFDelCalls
+func (s *MockService) CFDel(key string, item []byte) (bool, error) {
+	s.CFDelFuncCalled++
+	s.CFDelCalls = append(s.CFDelCalls, MockCFDelCall{Key: key, Item: item})
+
+	return s.CFDelFunc(key, item)
+}
+
+// CFCount calls CFCountFunc, increases CFCountFuncCalled and records the arguments in CFCountCalls
+func (s *MockService) CFCount(key string, item []byte) (int, error) {
+	s.CFCountFuncCalled++
+	s.CFCountCalls = append(s.CFCountCalls, MockCFCountCall{Key: key, Item: item})
+
+	return s.CFCountFunc(key, item)
+}
+
+// WithConn calls WithConnFunc, increases WithConnFuncCalled and records the arguments in WithConnCalls
+func (s *MockService) WithConn(fn func(conn redis.Conn) error) error {
+	s.WithConnFuncCalled++
+	s.WithConnCalls = append(s.WithConnCalls, MockWithConnCall{Fn: fn})
+
+	return s.WithConnFunc(fn)
+}
+
+// Do calls DoFunc, increases DoFuncCalled and records the arguments in DoCalls
+func (s *MockService) Do(commandName string, args ...interface{}) (interface{}, error) {
+	s.DoFuncCalled++
+	s.DoCalls = append(s.DoCalls, MockDoCall{CommandName: commandName, Args: args})
+
+	return s.DoFunc(commandName, args...)
+}
+
+// DoCtx calls DoCtxFunc, increases DoCtxFuncCalled and records the arguments in DoCtxCalls
+func (s *MockService) DoCtx(ctx context.Context, commandName string, args ...interface{}) (interface{}, error) {
+	s.DoCtxFuncCalled++
+	s.DoCtxCalls = append(s.DoCtxCalls, MockDoCtxCall{Ctx: ctx, CommandName: commandName, Args: args})
+
+	return s.DoCtxFunc(ctx, commandName, args...)
+}
+
+// HSetMulti calls HSetMultiFunc, increases HSetMultiFuncCalled and records the arguments in HSetMultiCalls
+func (s *MockService) HSetMulti(key string, values map[string][]byte) (int, error) {
+	s.HSetMultiFuncCalled++
+	s.HSetMultiCalls = append(s.HSetMultiCalls, MockHSetMultiCall{Key: key, Values: values})
+
+	return s.HSetMultiFunc(key, values)
+}
+
+// HSetStruct calls HSetStructFunc, increases HSetStructFuncCalled and records the arguments in HSetStructCalls
+func (s *MockService) HSetStruct(key string, v interface{}) error {
+	s.HSetStructFuncCalled++
+	s.HSetStructCalls = append(s.HSetStructCalls, MockHSetStructCall{Key: key, V: v})
+
+	return s.HSetStructFunc(key, v)
+}
+
+// HGetAllStruct calls HGetAllStructFunc, increases HGetAllStructFuncCalled and records the arguments in HGetAllStructCalls
+func (s *MockService) HGetAllStruct(key string, dest interface{}) error {
+	s.HGetAllStructFuncCalled++
+	s.HGetAllStructCalls = append(s.HGetAllStructCalls, MockHGetAllStructCall{Key: key, Dest: dest})
+
+	return s.HGetAllStructFunc(key, dest)
+}
+
+// XPending calls XPendingFunc, increases XPendingFuncCalled and records the arguments in XPendingCalls
+func (s *MockService) XPending(groupName string, key string, start string, end string, count int, minIdle time.Duration) ([]XPendingEntry, error) {
+	s.XPendingFuncCalled++
+	s.XPendingCalls = append(s.XPendingCalls, MockXPendingCall{GroupName: groupName, Key: key, Start: start, End: end, Count: count, MinIdle: minIdle})
+
+	return s.XPendingFunc(groupName, key, start, end, count, minIdle)
+}
+
+// XClaim calls XClaimFunc, increases XClaimFuncCalled and records the arguments in XClaimCalls
+func (s *MockService) XClaim(groupName string, key string, consumerName string, minIdle time.Duration, ids ...string) ([]*XEvent, error) {
+	s.XClaimFuncCalled++
+	s.XClaimCalls = append(s.XClaimCalls, MockXClaimCall{GroupName: groupName, Key: key, ConsumerName: consumerName, MinIdle: minIdle, Ids: ids})
+
+	return s.XClaimFunc(groupName, key, consumerName, minIdle, ids...)
+}
+
+// XAutoClaim calls XAutoClaimFunc, increases XAutoClaimFuncCalled and records the arguments in XAutoClaimCalls
+func (s *MockService) XAutoClaim(groupName string, key string, consumerName string, minIdle time.Duration, start string, count int) (string, []*XEvent, error) {
+	s.XAutoClaimFuncCalled++
+	s.XAutoClaimCalls = append(s.XAutoClaimCalls, MockXAutoClaimCall{GroupName: groupName, Key: key, ConsumerName: consumerName, MinIdle: minIdle, Start: start, Count: count})
+
+	return s.XAutoClaimFunc(groupName, key, consumerName, minIdle, start, count)
+}
+
+// OnPush calls OnPushFunc, increases OnPushFuncCalled and records the arguments in OnPushCalls
+func (s *MockService) OnPush(fn func(msg PushMessage)) {
+	s.OnPushFuncCalled++
+	s.OnPushCalls = append(s.OnPushCalls, MockOnPushCall{Fn: fn})
+
+	s.OnPushFunc(fn)
+}
+
+// OnConnect calls OnConnectFunc, increases OnConnectFuncCalled and records the arguments in OnConnectCalls
+func (s *MockService) OnConnect(fn func(addr string)) {
+	s.OnConnectFuncCalled++
+	s.OnConnectCalls = append(s.OnConnectCalls, MockOnConnectCall{Fn: fn})
+
+	s.OnConnectFunc(fn)
+}
+
+// OnDisconnect calls OnDisconnectFunc, increases OnDisconnectFuncCalled and records the arguments in OnDisconnectCalls
+func (s *MockService) OnDisconnect(fn func(addr string, err error)) {
+	s.OnDisconnectFuncCalled++
+	s.OnDisconnectCalls = append(s.OnDisconnectCalls, MockOnDisconnectCall{Fn: fn})
+
+	s.OnDisconnectFunc(fn)
+}
+
+// OnReconnect calls OnReconnectFunc, increases OnReconnectFuncCalled and records the arguments in OnReconnectCalls
+func (s *MockService) OnReconnect(fn func(addr string)) {
+	s.OnReconnectFuncCalled++
+	s.OnReconnectCalls = append(s.OnReconnectCalls, MockOnReconnectCall{Fn: fn})
+
+	s.OnReconnectFunc(fn)
+}
+
+// MemoryUsage calls MemoryUsageFunc, increases MemoryUsageFuncCalled and records the arguments in MemoryUsageCalls
+func (s *MockService) MemoryUsage(key string, samples int) (int64, error) {
+	s.MemoryUsageFuncCalled++
+	s.MemoryUsageCalls = append(s.MemoryUsageCalls, MockMemoryUsageCall{Key: key, Samples: samples})
+
+	return s.MemoryUsageFunc(key, samples)
+}
+
+// MemoryStats calls MemoryStatsFunc and increases MemoryStatsFuncCalled
+func (s *MockService) MemoryStats() (*MemoryStats, error) {
+	s.MemoryStatsFuncCalled++
+
+	return s.MemoryStatsFunc()
+}
+
 // NewMockService creates a new initialized instance of MockService
 func NewMockService() *MockService {
-	return &MockService{
+	s := &MockService{
 		MockService: gousu.MockService{
 			NameFunc: func() string {
 				return ServiceName
@@ -311,7 +2041,7 @@ func NewMockService() *MockService {
 		SetPXFunc: func(key string, data []byte, timeoutMS int) error {
 			return nil
 		},
-		DelFunc: func(key string) error {
+		DelFunc: func(keys ...string) error {
 			return nil
 		},
 		ExistsFunc: func(key string) (bool, error) {
@@ -368,8 +2098,8 @@ func NewMockService() *MockService {
 		SubscribeFunc: func(channels []string) (chan Message, ISubscription, error) {
 			return nil, nil, nil
 		},
-		PublishFunc: func(channel string, data []byte) error {
-			return nil
+		PublishFunc: func(channel string, data []byte) (int, error) {
+			return 0, nil
 		},
 		XAddFunc: func(key string, data map[string]string) (string, error) {
 			return "", nil
@@ -383,5 +2113,464 @@ func NewMockService() *MockService {
 		XAckFunc: func(groupName string, key string, id string) (int, error) {
 			return 0, nil
 		},
+		OnExpireFunc: func(pattern string, fn func(key string)) {
+		},
+		ExistsMultiFunc: func(keys ...string) (int, error) {
+			return 0, nil
+		},
+		WatchKeyFunc: func(ctx context.Context, key string) (<-chan KeyEvent, error) {
+			return make(chan KeyEvent), nil
+		},
+		MonitorQueueFunc: func(config QueueMonitorConfig) error {
+			return nil
+		},
+		GetQueueDepthsFunc: func() []QueueDepth {
+			return []QueueDepth{}
+		},
+		ScriptKillFunc: func() error {
+			return nil
+		},
+		BLPopCtxFunc: func(ctx context.Context, key string, timeout time.Duration) ([]byte, error) {
+			return []byte{}, nil
+		},
+		HGetAllMultiFunc: func(keys []string) (map[string]map[string][]byte, error) {
+			return map[string]map[string][]byte{}, nil
+		},
+		ZAddFunc: func(key string, score float64, member []byte) (int, error) {
+			return 0, nil
+		},
+		ZRangeFunc: func(key string, start int, stop int) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		ZRangeByScoreFunc: func(key string, min string, max string) ([]ZMember, error) {
+			return []ZMember{}, nil
+		},
+		ZRemFunc: func(key string, member []byte) (int, error) {
+			return 0, nil
+		},
+		ZIncrByFunc: func(key string, increment float64, member []byte) (float64, error) {
+			return 0, nil
+		},
+		ZScoreFunc: func(key string, member []byte) (float64, error) {
+			return 0, nil
+		},
+		ZRankFunc: func(key string, member []byte) (int, error) {
+			return 0, nil
+		},
+		ZCardFunc: func(key string) (int, error) {
+			return 0, nil
+		},
+		ZPopMinFunc: func(key string, count int) ([]ZMember, error) {
+			return []ZMember{}, nil
+		},
+		SAddFunc: func(key string, members ...[]byte) (int, error) {
+			return 0, nil
+		},
+		SRemFunc: func(key string, members ...[]byte) (int, error) {
+			return 0, nil
+		},
+		SMembersFunc: func(key string) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		SIsMemberFunc: func(key string, member []byte) (bool, error) {
+			return false, nil
+		},
+		SCardFunc: func(key string) (int, error) {
+			return 0, nil
+		},
+		SPopFunc: func(key string, count int) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		SRandMemberFunc: func(key string, count int) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		SInterFunc: func(keys ...string) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		SUnionFunc: func(keys ...string) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		SDiffFunc: func(keys ...string) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		XRangeFunc: func(key string, start string, end string, count int) ([]*XEvent, error) {
+			return []*XEvent{}, nil
+		},
+		XReadFunc: func(key string, timeout time.Duration, lastID string, count int) ([]*XEvent, error) {
+			return []*XEvent{}, nil
+		},
+		XLenFunc: func(key string) (int, error) {
+			return 0, nil
+		},
+		ConsumeGroupFunc: func(groupName string, consumerName string, key string) (chan XMessage, IConsumerGroup, error) {
+			return nil, nil, nil
+		},
+		PipelineFunc: func() IPipeline {
+			return nil
+		},
+		TxFunc: func(watchKeys []string, fn func(tx ITx) error) ([]interface{}, error) {
+			return []interface{}{}, nil
+		},
+		CachedFunc: func(key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+			return loader()
+		},
+		GetJSONFunc: func(key string, dest interface{}) error {
+			return nil
+		},
+		SetJSONFunc: func(key string, v interface{}) error {
+			return nil
+		},
+		SetJSONPXFunc: func(key string, v interface{}, timeoutMS int) error {
+			return nil
+		},
+		SetJSONNXPXFunc: func(key string, v interface{}, timeoutMS int) error {
+			return nil
+		},
+		HGetJSONFunc: func(key string, field string, dest interface{}) error {
+			return nil
+		},
+		HSetJSONFunc: func(key string, field string, v interface{}) error {
+			return nil
+		},
+		SSubscribeFunc: func(channels []string) (chan Message, ISubscription, error) {
+			return nil, nil, nil
+		},
+		SPublishFunc: func(channel string, data []byte) (int, error) {
+			return 0, nil
+		},
+		PubSubChannelsFunc: func(pattern string) ([]string, error) {
+			return []string{}, nil
+		},
+		PubSubNumSubFunc: func(channels ...string) (map[string]int, error) {
+			return map[string]int{}, nil
+		},
+		HGetAllFunc: func(key string) (map[string][]byte, error) {
+			return map[string][]byte{}, nil
+		},
+		HMGetFunc: func(key string, fields ...string) ([][]byte, error) {
+			return make([][]byte, len(fields)), nil
+		},
+		HMSetFunc: func(key string, values map[string][]byte) error {
+			return nil
+		},
+		HSetNXFunc: func(key string, field string, data []byte) (bool, error) {
+			return true, nil
+		},
+		HIncrByFunc: func(key string, field string, increment int) (int, error) {
+			return increment, nil
+		},
+		HIncrByFloatFunc: func(key string, field string, increment float64) (float64, error) {
+			return increment, nil
+		},
+		IncrFunc: func(key string) (int, error) {
+			return 1, nil
+		},
+		DecrFunc: func(key string) (int, error) {
+			return -1, nil
+		},
+		IncrByFunc: func(key string, increment int) (int, error) {
+			return increment, nil
+		},
+		DecrByFunc: func(key string, decrement int) (int, error) {
+			return -decrement, nil
+		},
+		IncrByFloatFunc: func(key string, increment float64) (float64, error) {
+			return increment, nil
+		},
+		ExpireFunc: func(key string, seconds int) (bool, error) {
+			return true, nil
+		},
+		PExpireFunc: func(key string, timeoutMS int) (bool, error) {
+			return true, nil
+		},
+		ExpireAtFunc: func(key string, at time.Time) (bool, error) {
+			return true, nil
+		},
+		TTLFunc: func(key string) (int, error) {
+			return -2, nil
+		},
+		PTTLFunc: func(key string) (int, error) {
+			return -2, nil
+		},
+		PersistFunc: func(key string) (bool, error) {
+			return false, nil
+		},
+		TypeFunc: func(key string) (string, error) {
+			return "none", nil
+		},
+		ObjectEncodingFunc: func(key string) (string, error) {
+			return "", ErrNil
+		},
+		ObjectIdleTimeFunc: func(key string) (int, error) {
+			return 0, ErrNil
+		},
+		ObjectFreqFunc: func(key string) (int, error) {
+			return 0, ErrNil
+		},
+		MGetFunc: func(keys ...string) ([][]byte, error) {
+			return make([][]byte, len(keys)), nil
+		},
+		MSetFunc: func(values map[string][]byte) error {
+			return nil
+		},
+		MSetNXFunc: func(values map[string][]byte) (bool, error) {
+			return true, nil
+		},
+		GetDelFunc: func(key string) ([]byte, error) {
+			return []byte{}, nil
+		},
+		GetExFunc: func(key string, timeoutMS int) ([]byte, error) {
+			return []byte{}, nil
+		},
+		GetSetFunc: func(key string, data []byte) ([]byte, error) {
+			return []byte{}, nil
+		},
+		AppendFunc: func(key string, data []byte) (int, error) {
+			return len(data), nil
+		},
+		StrLenFunc: func(key string) (int, error) {
+			return 0, nil
+		},
+		GetRangeFunc: func(key string, start int, end int) ([]byte, error) {
+			return []byte{}, nil
+		},
+		SetRangeFunc: func(key string, offset int, data []byte) (int, error) {
+			return offset + len(data), nil
+		},
+		SetBitFunc: func(key string, offset int, value bool) (bool, error) {
+			return false, nil
+		},
+		GetBitFunc: func(key string, offset int) (bool, error) {
+			return false, nil
+		},
+		BitCountFunc: func(key string, start int, end int) (int, error) {
+			return 0, nil
+		},
+		BitPosFunc: func(key string, value bool, start int) (int, error) {
+			return -1, nil
+		},
+		BitOpFunc: func(operation BitOperation, destKey string, keys ...string) (int, error) {
+			return 0, nil
+		},
+		BitFieldFunc: func(key string, args ...interface{}) ([]int64, error) {
+			return []int64{}, nil
+		},
+		PFAddFunc: func(key string, elements ...[]byte) (bool, error) {
+			return true, nil
+		},
+		PFCountFunc: func(keys ...string) (int, error) {
+			return 0, nil
+		},
+		PFMergeFunc: func(destKey string, sourceKeys ...string) error {
+			return nil
+		},
+		GeoAddFunc: func(key string, locations ...GeoLocation) (int, error) {
+			return len(locations), nil
+		},
+		GeoPosFunc: func(key string, members ...string) ([]*GeoPosition, error) {
+			return make([]*GeoPosition, len(members)), nil
+		},
+		GeoDistFunc: func(key string, member1 string, member2 string, unit GeoUnit) (float64, error) {
+			return 0, ErrNil
+		},
+		GeoSearchFunc: func(key string, query GeoSearchQuery) ([]GeoSearchResult, error) {
+			return []GeoSearchResult{}, nil
+		},
+		GeoRadiusFunc: func(key string, longitude float64, latitude float64, radius float64, unit GeoUnit) ([]GeoSearchResult, error) {
+			return []GeoSearchResult{}, nil
+		},
+		LTrimFunc: func(key string, start int, stop int) error {
+			return nil
+		},
+		LSetFunc: func(key string, index int, data []byte) error {
+			return nil
+		},
+		LInsertFunc: func(key string, before bool, pivot []byte, data []byte) (int, error) {
+			return 0, nil
+		},
+		LMoveFunc: func(source string, destination string, from ListDirection, to ListDirection) ([]byte, error) {
+			return []byte{}, nil
+		},
+		BLMoveFunc: func(source string, destination string, from ListDirection, to ListDirection, timeout time.Duration) ([]byte, error) {
+			return []byte{}, nil
+		},
+		BLPopMultiFunc: func(keys []string, timeout time.Duration) (string, []byte, error) {
+			return "", []byte{}, nil
+		},
+		BRPopFunc: func(key string, timeout int) ([]byte, error) {
+			return []byte{}, nil
+		},
+		BRPopMultiFunc: func(keys []string, timeout time.Duration) (string, []byte, error) {
+			return "", []byte{}, nil
+		},
+		UnlinkFunc: func(keys ...string) (int, error) {
+			return len(keys), nil
+		},
+		DelPatternFunc: func(pattern string) (int, error) {
+			return 0, nil
+		},
+		ScanAllFunc: func(pattern string, options ScanOptions, fn func(keys []string) error) error {
+			return nil
+		},
+		HScanMatchFunc: func(key string, cursor int, match string, count int) (int, map[string][]byte, error) {
+			return 0, map[string][]byte{}, nil
+		},
+		HScanAllFunc: func(key string, match string) (map[string][]byte, error) {
+			return map[string][]byte{}, nil
+		},
+		SScanFunc: func(key string, cursor int, match string, count int) (int, [][]byte, error) {
+			return 0, [][]byte{}, nil
+		},
+		SScanAllFunc: func(key string, match string) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		ZScanFunc: func(key string, cursor int, match string, count int) (int, []ZMember, error) {
+			return 0, []ZMember{}, nil
+		},
+		ZScanAllFunc: func(key string, match string) ([]ZMember, error) {
+			return []ZMember{}, nil
+		},
+		RenameFunc: func(key string, newKey string) error {
+			return nil
+		},
+		RenameNXFunc: func(key string, newKey string) (bool, error) {
+			return true, nil
+		},
+		CopyFunc: func(source string, destination string, replace bool) (bool, error) {
+			return true, nil
+		},
+		CopyToDBFunc: func(source string, destination string, db int, replace bool) (bool, error) {
+			return true, nil
+		},
+		DumpFunc: func(key string) ([]byte, error) {
+			return []byte{}, nil
+		},
+		RestoreFunc: func(key string, ttlMS int, payload []byte, replace bool) error {
+			return nil
+		},
+		SortFunc: func(key string, options SortOptions) ([][]byte, error) {
+			return [][]byte{}, nil
+		},
+		DBSizeFunc: func() (int, error) {
+			return 0, nil
+		},
+		FlushDBFunc: func() error {
+			return nil
+		},
+		FlushAllFunc: func() error {
+			return nil
+		},
+		InfoFunc: func(section ...string) (*Info, error) {
+			return parseInfo(""), nil
+		},
+		ClientListFunc: func() ([]ClientInfo, error) {
+			return []ClientInfo{}, nil
+		},
+		ClientKillFunc: func(filter ClientKillFilter) (int, error) {
+			return 0, nil
+		},
+		StatsFunc: func() Stats {
+			return Stats{Nodes: map[string]redis.PoolStats{}}
+		},
+		AddHookFunc: func(hook Hook) {
+		},
+		HealthDetailsFunc: func() (*HealthDetails, error) {
+			return &HealthDetails{}, nil
+		},
+		ReadyFunc: func() bool {
+			return true
+		},
+		WaitFunc: func(numReplicas int, timeoutMS int) (int, error) {
+			return 0, nil
+		},
+		SetWithReplicationFunc: func(key string, data []byte, numReplicas int, timeoutMS int) (int, error) {
+			return numReplicas, nil
+		},
+		SetPXWithReplicationFunc: func(key string, data []byte, expireMS int, numReplicas int, timeoutMS int) (int, error) {
+			return numReplicas, nil
+		},
+		BloomAvailableFunc: func() (bool, error) {
+			return false, nil
+		},
+		BFReserveFunc: func(key string, errorRate float64, capacity int) error {
+			return nil
+		},
+		BFAddFunc: func(key string, item []byte) (bool, error) {
+			return true, nil
+		},
+		BFMAddFunc: func(key string, items ...[]byte) ([]bool, error) {
+			return make([]bool, len(items)), nil
+		},
+		BFExistsFunc: func(key string, item []byte) (bool, error) {
+			return false, nil
+		},
+		BFMExistsFunc: func(key string, items ...[]byte) ([]bool, error) {
+			return make([]bool, len(items)), nil
+		},
+		CFReserveFunc: func(key string, capacity int) error {
+			return nil
+		},
+		CFAddFunc: func(key string, item []byte) error {
+			return nil
+		},
+		CFAddNXFunc: func(key string, item []byte) (bool, error) {
+			return true, nil
+		},
+		CFExistsFunc: func(key string, item []byte) (bool, error) {
+			return false, nil
+		},
+		CFDelFunc: func(key string, item []byte) (bool, error) {
+			return false, nil
+		},
+		CFCountFunc: func(key string, item []byte) (int, error) {
+			return 0, nil
+		},
+		WithConnFunc: func(fn func(conn redis.Conn) error) error {
+			return nil
+		},
+		DoFunc: func(commandName string, args ...interface{}) (interface{}, error) {
+			return nil, nil
+		},
+		DoCtxFunc: func(ctx context.Context, commandName string, args ...interface{}) (interface{}, error) {
+			return nil, nil
+		},
+		HSetMultiFunc: func(key string, values map[string][]byte) (int, error) {
+			return len(values), nil
+		},
+		HSetStructFunc: func(key string, v interface{}) error {
+			return nil
+		},
+		HGetAllStructFunc: func(key string, dest interface{}) error {
+			return ErrNil
+		},
+		XPendingFunc: func(groupName string, key string, start string, end string, count int, minIdle time.Duration) ([]XPendingEntry, error) {
+			return []XPendingEntry{}, nil
+		},
+		XClaimFunc: func(groupName string, key string, consumerName string, minIdle time.Duration, ids ...string) ([]*XEvent, error) {
+			return []*XEvent{}, nil
+		},
+		XAutoClaimFunc: func(groupName string, key string, consumerName string, minIdle time.Duration, start string, count int) (string, []*XEvent, error) {
+			return "0-0", []*XEvent{}, nil
+		},
+		OnPushFunc: func(fn func(msg PushMessage)) {
+		},
+		OnConnectFunc: func(fn func(addr string)) {
+		},
+		OnDisconnectFunc: func(fn func(addr string, err error)) {
+		},
+		OnReconnectFunc: func(fn func(addr string)) {
+		},
+		MemoryUsageFunc: func(key string, samples int) (int64, error) {
+			return 0, ErrNil
+		},
+		MemoryStatsFunc: func() (*MemoryStats, error) {
+			return &MemoryStats{Fields: map[string]string{}, DBs: map[string]MemoryStatsDB{}}, nil
+		},
+	}
+
+	s.WithContextFunc = func(ctx context.Context) IService {
+		return s
 	}
+
+	return s
 }
@@ -0,0 +1,1065 @@
+package gousuredis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/gomodule/redigo/redis"
+)
+
+// MockNewMutexCall contains the arguments of a call of MockService.NewMutex
+type MockNewMutexCall struct {
+	Name    string
+	Options []redsync.Option
+}
+
+// MockGetCall contains the arguments of a call of MockService.Get
+type MockGetCall struct {
+	Key string
+}
+
+// MockSetCall contains the arguments of a call of MockService.Set
+type MockSetCall struct {
+	Key  string
+	Data []byte
+}
+
+// MockSetNXPXCall contains the arguments of a call of MockService.SetNXPX
+type MockSetNXPXCall struct {
+	Key       string
+	Data      []byte
+	TimeoutMS int
+}
+
+// MockSetPXCall contains the arguments of a call of MockService.SetPX
+type MockSetPXCall struct {
+	Key       string
+	Data      []byte
+	TimeoutMS int
+}
+
+// MockDelCall contains the arguments of a call of MockService.Del
+type MockDelCall struct {
+	Keys []string
+}
+
+// MockExistsCall contains the arguments of a call of MockService.Exists
+type MockExistsCall struct {
+	Key string
+}
+
+// MockScanCall contains the arguments of a call of MockService.Scan
+type MockScanCall struct {
+	Pattern string
+	Cursor  int
+}
+
+// MockRPushCall contains the arguments of a call of MockService.RPush
+type MockRPushCall struct {
+	Key  string
+	Data []byte
+}
+
+// MockLPushCall contains the arguments of a call of MockService.LPush
+type MockLPushCall struct {
+	Key  string
+	Data []byte
+}
+
+// MockLRangeCall contains the arguments of a call of MockService.LRange
+type MockLRangeCall struct {
+	Key   string
+	Start int
+	Stop  int
+}
+
+// MockLRemCall contains the arguments of a call of MockService.LRem
+type MockLRemCall struct {
+	Key   string
+	Count int
+	Data  []byte
+}
+
+// MockLPopCall contains the arguments of a call of MockService.LPop
+type MockLPopCall struct {
+	Key string
+}
+
+// MockRPopCall contains the arguments of a call of MockService.RPop
+type MockRPopCall struct {
+	Key string
+}
+
+// MockBLPopCall contains the arguments of a call of MockService.BLPop
+type MockBLPopCall struct {
+	Key     string
+	Timeout int
+}
+
+// MockHGetCall contains the arguments of a call of MockService.HGet
+type MockHGetCall struct {
+	Key   string
+	Field string
+}
+
+// MockHSetCall contains the arguments of a call of MockService.HSet
+type MockHSetCall struct {
+	Key   string
+	Field string
+	Data  []byte
+}
+
+// MockHScanCall contains the arguments of a call of MockService.HScan
+type MockHScanCall struct {
+	Key    string
+	Cursor int
+}
+
+// MockHKeysCall contains the arguments of a call of MockService.HKeys
+type MockHKeysCall struct {
+	Key string
+}
+
+// MockHDelCall contains the arguments of a call of MockService.HDel
+type MockHDelCall struct {
+	Key   string
+	Field string
+}
+
+// MockHLenCall contains the arguments of a call of MockService.HLen
+type MockHLenCall struct {
+	Key string
+}
+
+// MockLIndexCall contains the arguments of a call of MockService.LIndex
+type MockLIndexCall struct {
+	Key      string
+	Position int
+}
+
+// MockLLenCall contains the arguments of a call of MockService.LLen
+type MockLLenCall struct {
+	Key string
+}
+
+// MockSubscribeCall contains the arguments of a call of MockService.Subscribe
+type MockSubscribeCall struct {
+	Channels []string
+}
+
+// MockPublishCall contains the arguments of a call of MockService.Publish
+type MockPublishCall struct {
+	Channel string
+	Data    []byte
+}
+
+// MockXAddCall contains the arguments of a call of MockService.XAdd
+type MockXAddCall struct {
+	Key  string
+	Data map[string]string
+}
+
+// MockXGroupCreateCall contains the arguments of a call of MockService.XGroupCreate
+type MockXGroupCreateCall struct {
+	GroupName  string
+	Key        string
+	Offset     XGroupCreateOffset
+	MkStream   bool
+	IgnoreBusy bool
+}
+
+// MockXReadGroupCall contains the arguments of a call of MockService.XReadGroup
+type MockXReadGroupCall struct {
+	GroupName    string
+	ConsumerName string
+	Key          string
+	Timeout      time.Duration
+	StreamID     XReadGroupStreamID
+}
+
+// MockXAckCall contains the arguments of a call of MockService.XAck
+type MockXAckCall struct {
+	GroupName string
+	Key       string
+	ID        string
+}
+
+// MockOnExpireCall contains the arguments of a call of MockService.OnExpire
+type MockOnExpireCall struct {
+	Pattern string
+	Fn      func(key string)
+}
+
+// MockExistsMultiCall contains the arguments of a call of MockService.ExistsMulti
+type MockExistsMultiCall struct {
+	Keys []string
+}
+
+// MockWatchKeyCall contains the arguments of a call of MockService.WatchKey
+type MockWatchKeyCall struct {
+	Ctx context.Context
+	Key string
+}
+
+// MockMonitorQueueCall contains the arguments of a call of MockService.MonitorQueue
+type MockMonitorQueueCall struct {
+	Config QueueMonitorConfig
+}
+
+// MockBLPopCtxCall contains the arguments of a call of MockService.BLPopCtx
+type MockBLPopCtxCall struct {
+	Ctx     context.Context
+	Key     string
+	Timeout time.Duration
+}
+
+// MockHGetAllMultiCall contains the arguments of a call of MockService.HGetAllMulti
+type MockHGetAllMultiCall struct {
+	Keys []string
+}
+
+// MockZAddCall contains the arguments of a call of MockService.ZAdd
+type MockZAddCall struct {
+	Key    string
+	Score  float64
+	Member []byte
+}
+
+// MockZRangeCall contains the arguments of a call of MockService.ZRange
+type MockZRangeCall struct {
+	Key   string
+	Start int
+	Stop  int
+}
+
+// MockZRangeByScoreCall contains the arguments of a call of MockService.ZRangeByScore
+type MockZRangeByScoreCall struct {
+	Key string
+	Min string
+	Max string
+}
+
+// MockZRemCall contains the arguments of a call of MockService.ZRem
+type MockZRemCall struct {
+	Key    string
+	Member []byte
+}
+
+// MockZIncrByCall contains the arguments of a call of MockService.ZIncrBy
+type MockZIncrByCall struct {
+	Key       string
+	Increment float64
+	Member    []byte
+}
+
+// MockZScoreCall contains the arguments of a call of MockService.ZScore
+type MockZScoreCall struct {
+	Key    string
+	Member []byte
+}
+
+// MockZRankCall contains the arguments of a call of MockService.ZRank
+type MockZRankCall struct {
+	Key    string
+	Member []byte
+}
+
+// MockZCardCall contains the arguments of a call of MockService.ZCard
+type MockZCardCall struct {
+	Key string
+}
+
+// MockZPopMinCall contains the arguments of a call of MockService.ZPopMin
+type MockZPopMinCall struct {
+	Key   string
+	Count int
+}
+
+// MockSAddCall contains the arguments of a call of MockService.SAdd
+type MockSAddCall struct {
+	Key     string
+	Members [][]byte
+}
+
+// MockSRemCall contains the arguments of a call of MockService.SRem
+type MockSRemCall struct {
+	Key     string
+	Members [][]byte
+}
+
+// MockSMembersCall contains the arguments of a call of MockService.SMembers
+type MockSMembersCall struct {
+	Key string
+}
+
+// MockSIsMemberCall contains the arguments of a call of MockService.SIsMember
+type MockSIsMemberCall struct {
+	Key    string
+	Member []byte
+}
+
+// MockSCardCall contains the arguments of a call of MockService.SCard
+type MockSCardCall struct {
+	Key string
+}
+
+// MockSPopCall contains the arguments of a call of MockService.SPop
+type MockSPopCall struct {
+	Key   string
+	Count int
+}
+
+// MockSRandMemberCall contains the arguments of a call of MockService.SRandMember
+type MockSRandMemberCall struct {
+	Key   string
+	Count int
+}
+
+// MockSInterCall contains the arguments of a call of MockService.SInter
+type MockSInterCall struct {
+	Keys []string
+}
+
+// MockSUnionCall contains the arguments of a call of MockService.SUnion
+type MockSUnionCall struct {
+	Keys []string
+}
+
+// MockSDiffCall contains the arguments of a call of MockService.SDiff
+type MockSDiffCall struct {
+	Keys []string
+}
+
+// MockXRangeCall contains the arguments of a call of MockService.XRange
+type MockXRangeCall struct {
+	Key   string
+	Start string
+	End   string
+	Count int
+}
+
+// MockXReadCall contains the arguments of a call of MockService.XRead
+type MockXReadCall struct {
+	Key     string
+	Timeout time.Duration
+	LastID  string
+	Count   int
+}
+
+// MockXLenCall contains the arguments of a call of MockService.XLen
+type MockXLenCall struct {
+	Key string
+}
+
+// MockConsumeGroupCall contains the arguments of a call of MockService.ConsumeGroup
+type MockConsumeGroupCall struct {
+	GroupName    string
+	ConsumerName string
+	Key          string
+}
+
+// MockWithContextCall contains the arguments of a call of MockService.WithContext
+type MockWithContextCall struct {
+	Ctx context.Context
+}
+
+// MockTxCall contains the arguments of a call of MockService.Tx
+type MockTxCall struct {
+	WatchKeys []string
+	Fn        func(tx ITx) error
+}
+
+// MockCachedCall contains the arguments of a call of MockService.Cached
+type MockCachedCall struct {
+	Key    string
+	TTL    time.Duration
+	Loader func() ([]byte, error)
+}
+
+// MockGetJSONCall contains the arguments of a call of MockService.GetJSON
+type MockGetJSONCall struct {
+	Key  string
+	Dest interface{}
+}
+
+// MockSetJSONCall contains the arguments of a call of MockService.SetJSON
+type MockSetJSONCall struct {
+	Key string
+	V   interface{}
+}
+
+// MockSetJSONPXCall contains the arguments of a call of MockService.SetJSONPX
+type MockSetJSONPXCall struct {
+	Key       string
+	V         interface{}
+	TimeoutMS int
+}
+
+// MockSetJSONNXPXCall contains the arguments of a call of MockService.SetJSONNXPX
+type MockSetJSONNXPXCall struct {
+	Key       string
+	V         interface{}
+	TimeoutMS int
+}
+
+// MockHGetJSONCall contains the arguments of a call of MockService.HGetJSON
+type MockHGetJSONCall struct {
+	Key   string
+	Field string
+	Dest  interface{}
+}
+
+// MockHSetJSONCall contains the arguments of a call of MockService.HSetJSON
+type MockHSetJSONCall struct {
+	Key   string
+	Field string
+	V     interface{}
+}
+
+// MockSSubscribeCall contains the arguments of a call of MockService.SSubscribe
+type MockSSubscribeCall struct {
+	Channels []string
+}
+
+// MockSPublishCall contains the arguments of a call of MockService.SPublish
+type MockSPublishCall struct {
+	Channel string
+	Data    []byte
+}
+
+// MockPubSubChannelsCall contains the arguments of a call of MockService.PubSubChannels
+type MockPubSubChannelsCall struct {
+	Pattern string
+}
+
+// MockPubSubNumSubCall contains the arguments of a call of MockService.PubSubNumSub
+type MockPubSubNumSubCall struct {
+	Channels []string
+}
+
+// MockHGetAllCall contains the arguments of a call of MockService.HGetAll
+type MockHGetAllCall struct {
+	Key string
+}
+
+// MockHMGetCall contains the arguments of a call of MockService.HMGet
+type MockHMGetCall struct {
+	Key    string
+	Fields []string
+}
+
+// MockHMSetCall contains the arguments of a call of MockService.HMSet
+type MockHMSetCall struct {
+	Key    string
+	Values map[string][]byte
+}
+
+// MockHSetNXCall contains the arguments of a call of MockService.HSetNX
+type MockHSetNXCall struct {
+	Key   string
+	Field string
+	Data  []byte
+}
+
+// MockHIncrByCall contains the arguments of a call of MockService.HIncrBy
+type MockHIncrByCall struct {
+	Key       string
+	Field     string
+	Increment int
+}
+
+// MockHIncrByFloatCall contains the arguments of a call of MockService.HIncrByFloat
+type MockHIncrByFloatCall struct {
+	Key       string
+	Field     string
+	Increment float64
+}
+
+// MockIncrCall contains the arguments of a call of MockService.Incr
+type MockIncrCall struct {
+	Key string
+}
+
+// MockDecrCall contains the arguments of a call of MockService.Decr
+type MockDecrCall struct {
+	Key string
+}
+
+// MockIncrByCall contains the arguments of a call of MockService.IncrBy
+type MockIncrByCall struct {
+	Key       string
+	Increment int
+}
+
+// MockDecrByCall contains the arguments of a call of MockService.DecrBy
+type MockDecrByCall struct {
+	Key       string
+	Decrement int
+}
+
+// MockIncrByFloatCall contains the arguments of a call of MockService.IncrByFloat
+type MockIncrByFloatCall struct {
+	Key       string
+	Increment float64
+}
+
+// MockExpireCall contains the arguments of a call of MockService.Expire
+type MockExpireCall struct {
+	Key     string
+	Seconds int
+}
+
+// MockPExpireCall contains the arguments of a call of MockService.PExpire
+type MockPExpireCall struct {
+	Key       string
+	TimeoutMS int
+}
+
+// MockExpireAtCall contains the arguments of a call of MockService.ExpireAt
+type MockExpireAtCall struct {
+	Key string
+	At  time.Time
+}
+
+// MockTTLCall contains the arguments of a call of MockService.TTL
+type MockTTLCall struct {
+	Key string
+}
+
+// MockPTTLCall contains the arguments of a call of MockService.PTTL
+type MockPTTLCall struct {
+	Key string
+}
+
+// MockPersistCall contains the arguments of a call of MockService.Persist
+type MockPersistCall struct {
+	Key string
+}
+
+// MockTypeCall contains the arguments of a call of MockService.Type
+type MockTypeCall struct {
+	Key string
+}
+
+// MockObjectEncodingCall contains the arguments of a call of MockService.ObjectEncoding
+type MockObjectEncodingCall struct {
+	Key string
+}
+
+// MockObjectIdleTimeCall contains the arguments of a call of MockService.ObjectIdleTime
+type MockObjectIdleTimeCall struct {
+	Key string
+}
+
+// MockObjectFreqCall contains the arguments of a call of MockService.ObjectFreq
+type MockObjectFreqCall struct {
+	Key string
+}
+
+// MockMGetCall contains the arguments of a call of MockService.MGet
+type MockMGetCall struct {
+	Keys []string
+}
+
+// MockMSetCall contains the arguments of a call of MockService.MSet
+type MockMSetCall struct {
+	Values map[string][]byte
+}
+
+// MockMSetNXCall contains the arguments of a call of MockService.MSetNX
+type MockMSetNXCall struct {
+	Values map[string][]byte
+}
+
+// MockGetDelCall contains the arguments of a call of MockService.GetDel
+type MockGetDelCall struct {
+	Key string
+}
+
+// MockGetExCall contains the arguments of a call of MockService.GetEx
+type MockGetExCall struct {
+	Key       string
+	TimeoutMS int
+}
+
+// MockGetSetCall contains the arguments of a call of MockService.GetSet
+type MockGetSetCall struct {
+	Key  string
+	Data []byte
+}
+
+// MockAppendCall contains the arguments of a call of MockService.Append
+type MockAppendCall struct {
+	Key  string
+	Data []byte
+}
+
+// MockStrLenCall contains the arguments of a call of MockService.StrLen
+type MockStrLenCall struct {
+	Key string
+}
+
+// MockGetRangeCall contains the arguments of a call of MockService.GetRange
+type MockGetRangeCall struct {
+	Key   string
+	Start int
+	End   int
+}
+
+// MockSetRangeCall contains the arguments of a call of MockService.SetRange
+type MockSetRangeCall struct {
+	Key    string
+	Offset int
+	Data   []byte
+}
+
+// MockSetBitCall contains the arguments of a call of MockService.SetBit
+type MockSetBitCall struct {
+	Key    string
+	Offset int
+	Value  bool
+}
+
+// MockGetBitCall contains the arguments of a call of MockService.GetBit
+type MockGetBitCall struct {
+	Key    string
+	Offset int
+}
+
+// MockBitCountCall contains the arguments of a call of MockService.BitCount
+type MockBitCountCall struct {
+	Key   string
+	Start int
+	End   int
+}
+
+// MockBitPosCall contains the arguments of a call of MockService.BitPos
+type MockBitPosCall struct {
+	Key   string
+	Value bool
+	Start int
+}
+
+// MockBitOpCall contains the arguments of a call of MockService.BitOp
+type MockBitOpCall struct {
+	Operation BitOperation
+	DestKey   string
+	Keys      []string
+}
+
+// MockBitFieldCall contains the arguments of a call of MockService.BitField
+type MockBitFieldCall struct {
+	Key  string
+	Args []interface{}
+}
+
+// MockPFAddCall contains the arguments of a call of MockService.PFAdd
+type MockPFAddCall struct {
+	Key      string
+	Elements [][]byte
+}
+
+// MockPFCountCall contains the arguments of a call of MockService.PFCount
+type MockPFCountCall struct {
+	Keys []string
+}
+
+// MockPFMergeCall contains the arguments of a call of MockService.PFMerge
+type MockPFMergeCall struct {
+	DestKey    string
+	SourceKeys []string
+}
+
+// MockGeoAddCall contains the arguments of a call of MockService.GeoAdd
+type MockGeoAddCall struct {
+	Key       string
+	Locations []GeoLocation
+}
+
+// MockGeoPosCall contains the arguments of a call of MockService.GeoPos
+type MockGeoPosCall struct {
+	Key     string
+	Members []string
+}
+
+// MockGeoDistCall contains the arguments of a call of MockService.GeoDist
+type MockGeoDistCall struct {
+	Key     string
+	Member1 string
+	Member2 string
+	Unit    GeoUnit
+}
+
+// MockGeoSearchCall contains the arguments of a call of MockService.GeoSearch
+type MockGeoSearchCall struct {
+	Key   string
+	Query GeoSearchQuery
+}
+
+// MockGeoRadiusCall contains the arguments of a call of MockService.GeoRadius
+type MockGeoRadiusCall struct {
+	Key       string
+	Longitude float64
+	Latitude  float64
+	Radius    float64
+	Unit      GeoUnit
+}
+
+// MockLTrimCall contains the arguments of a call of MockService.LTrim
+type MockLTrimCall struct {
+	Key   string
+	Start int
+	Stop  int
+}
+
+// MockLSetCall contains the arguments of a call of MockService.LSet
+type MockLSetCall struct {
+	Key   string
+	Index int
+	Data  []byte
+}
+
+// MockLInsertCall contains the arguments of a call of MockService.LInsert
+type MockLInsertCall struct {
+	Key    string
+	Before bool
+	Pivot  []byte
+	Data   []byte
+}
+
+// MockLMoveCall contains the arguments of a call of MockService.LMove
+type MockLMoveCall struct {
+	Source      string
+	Destination string
+	From        ListDirection
+	To          ListDirection
+}
+
+// MockBLMoveCall contains the arguments of a call of MockService.BLMove
+type MockBLMoveCall struct {
+	Source      string
+	Destination string
+	From        ListDirection
+	To          ListDirection
+	Timeout     time.Duration
+}
+
+// MockBLPopMultiCall contains the arguments of a call of MockService.BLPopMulti
+type MockBLPopMultiCall struct {
+	Keys    []string
+	Timeout time.Duration
+}
+
+// MockBRPopCall contains the arguments of a call of MockService.BRPop
+type MockBRPopCall struct {
+	Key     string
+	Timeout int
+}
+
+// MockBRPopMultiCall contains the arguments of a call of MockService.BRPopMulti
+type MockBRPopMultiCall struct {
+	Keys    []string
+	Timeout time.Duration
+}
+
+// MockUnlinkCall contains the arguments of a call of MockService.Unlink
+type MockUnlinkCall struct {
+	Keys []string
+}
+
+// MockDelPatternCall contains the arguments of a call of MockService.DelPattern
+type MockDelPatternCall struct {
+	Pattern string
+}
+
+// MockScanAllCall contains the arguments of a call of MockService.ScanAll
+type MockScanAllCall struct {
+	Pattern string
+	Options ScanOptions
+	Fn      func(keys []string) error
+}
+
+// MockHScanMatchCall contains the arguments of a call of MockService.HScanMatch
+type MockHScanMatchCall struct {
+	Key    string
+	Cursor int
+	Match  string
+	Count  int
+}
+
+// MockHScanAllCall contains the arguments of a call of MockService.HScanAll
+type MockHScanAllCall struct {
+	Key   string
+	Match string
+}
+
+// MockSScanCall contains the arguments of a call of MockService.SScan
+type MockSScanCall struct {
+	Key    string
+	Cursor int
+	Match  string
+	Count  int
+}
+
+// MockSScanAllCall contains the arguments of a call of MockService.SScanAll
+type MockSScanAllCall struct {
+	Key   string
+	Match string
+}
+
+// MockZScanCall contains the arguments of a call of MockService.ZScan
+type MockZScanCall struct {
+	Key    string
+	Cursor int
+	Match  string
+	Count  int
+}
+
+// MockZScanAllCall contains the arguments of a call of MockService.ZScanAll
+type MockZScanAllCall struct {
+	Key   string
+	Match string
+}
+
+// MockRenameCall contains the arguments of a call of MockService.Rename
+type MockRenameCall struct {
+	Key    string
+	NewKey string
+}
+
+// MockRenameNXCall contains the arguments of a call of MockService.RenameNX
+type MockRenameNXCall struct {
+	Key    string
+	NewKey string
+}
+
+// MockCopyCall contains the arguments of a call of MockService.Copy
+type MockCopyCall struct {
+	Source      string
+	Destination string
+	Replace     bool
+}
+
+// MockCopyToDBCall contains the arguments of a call of MockService.CopyToDB
+type MockCopyToDBCall struct {
+	Source      string
+	Destination string
+	DB          int
+	Replace     bool
+}
+
+// MockDumpCall contains the arguments of a call of MockService.Dump
+type MockDumpCall struct {
+	Key string
+}
+
+// MockRestoreCall contains the arguments of a call of MockService.Restore
+type MockRestoreCall struct {
+	Key     string
+	TTLMS   int
+	Payload []byte
+	Replace bool
+}
+
+// MockSortCall contains the arguments of a call of MockService.Sort
+type MockSortCall struct {
+	Key     string
+	Options SortOptions
+}
+
+// MockInfoCall contains the arguments of a call of MockService.Info
+type MockInfoCall struct {
+	Section []string
+}
+
+// MockClientKillCall contains the arguments of a call of MockService.ClientKill
+type MockClientKillCall struct {
+	Filter ClientKillFilter
+}
+
+// MockAddHookCall contains the arguments of a call of MockService.AddHook
+type MockAddHookCall struct {
+	Hook Hook
+}
+
+// MockWaitCall contains the arguments of a call of MockService.Wait
+type MockWaitCall struct {
+	NumReplicas int
+	TimeoutMS   int
+}
+
+// MockSetWithReplicationCall contains the arguments of a call of MockService.SetWithReplication
+type MockSetWithReplicationCall struct {
+	Key         string
+	Data        []byte
+	NumReplicas int
+	TimeoutMS   int
+}
+
+// MockSetPXWithReplicationCall contains the arguments of a call of MockService.SetPXWithReplication
+type MockSetPXWithReplicationCall struct {
+	Key         string
+	Data        []byte
+	ExpireMS    int
+	NumReplicas int
+	TimeoutMS   int
+}
+
+// MockBFReserveCall contains the arguments of a call of MockService.BFReserve
+type MockBFReserveCall struct {
+	Key       string
+	ErrorRate float64
+	Capacity  int
+}
+
+// MockBFAddCall contains the arguments of a call of MockService.BFAdd
+type MockBFAddCall struct {
+	Key  string
+	Item []byte
+}
+
+// MockBFMAddCall contains the arguments of a call of MockService.BFMAdd
+type MockBFMAddCall struct {
+	Key   string
+	Items [][]byte
+}
+
+// MockBFExistsCall contains the arguments of a call of MockService.BFExists
+type MockBFExistsCall struct {
+	Key  string
+	Item []byte
+}
+
+// MockBFMExistsCall contains the arguments of a call of MockService.BFMExists
+type MockBFMExistsCall struct {
+	Key   string
+	Items [][]byte
+}
+
+// MockCFReserveCall contains the arguments of a call of MockService.CFReserve
+type MockCFReserveCall struct {
+	Key      string
+	Capacity int
+}
+
+// MockCFAddCall contains the arguments of a call of MockService.CFAdd
+type MockCFAddCall struct {
+	Key  string
+	Item []byte
+}
+
+// MockCFAddNXCall contains the arguments of a call of MockService.CFAddNX
+type MockCFAddNXCall struct {
+	Key  string
+	Item []byte
+}
+
+// MockCFExistsCall contains the arguments of a call of MockService.CFExists
+type MockCFExistsCall struct {
+	Key  string
+	Item []byte
+}
+
+// MockCFDelCall contains the arguments of a call of MockService.CFDel
+type MockCFDelCall struct {
+	Key  string
+	Item []byte
+}
+
+// MockCFCountCall contains the arguments of a call of MockService.CFCount
+type MockCFCountCall struct {
+	Key  string
+	Item []byte
+}
+
+// MockWithConnCall contains the arguments of a call of MockService.WithConn
+type MockWithConnCall struct {
+	Fn func(conn redis.Conn) error
+}
+
+// MockDoCall contains the arguments of a call of MockService.Do
+type MockDoCall struct {
+	CommandName string
+	Args        []interface{}
+}
+
+// MockDoCtxCall contains the arguments of a call of MockService.DoCtx
+type MockDoCtxCall struct {
+	Ctx         context.Context
+	CommandName string
+	Args        []interface{}
+}
+
+// MockHSetMultiCall contains the arguments of a call of MockService.HSetMulti
+type MockHSetMultiCall struct {
+	Key    string
+	Values map[string][]byte
+}
+
+// MockHSetStructCall contains the arguments of a call of MockService.HSetStruct
+type MockHSetStructCall struct {
+	Key string
+	V   interface{}
+}
+
+// MockHGetAllStructCall contains the arguments of a call of MockService.HGetAllStruct
+type MockHGetAllStructCall struct {
+	Key  string
+	Dest interface{}
+}
+
+// MockXPendingCall contains the arguments of a call of MockService.XPending
+type MockXPendingCall struct {
+	GroupName string
+	Key       string
+	Start     string
+	End       string
+	Count     int
+	MinIdle   time.Duration
+}
+
+// MockXClaimCall contains the arguments of a call of MockService.XClaim
+type MockXClaimCall struct {
+	GroupName    string
+	Key          string
+	ConsumerName string
+	MinIdle      time.Duration
+	Ids          []string
+}
+
+// MockXAutoClaimCall contains the arguments of a call of MockService.XAutoClaim
+type MockXAutoClaimCall struct {
+	GroupName    string
+	Key          string
+	ConsumerName string
+	MinIdle      time.Duration
+	Start        string
+	Count        int
+}
+
+// MockOnPushCall contains the arguments of a call of MockService.OnPush
+type MockOnPushCall struct {
+	Fn func(msg PushMessage)
+}
+
+// MockOnConnectCall contains the arguments of a call of MockService.OnConnect
+type MockOnConnectCall struct {
+	Fn func(addr string)
+}
+
+// MockOnDisconnectCall contains the arguments of a call of MockService.OnDisconnect
+type MockOnDisconnectCall struct {
+	Fn func(addr string, err error)
+}
+
+// MockOnReconnectCall contains the arguments of a call of MockService.OnReconnect
+type MockOnReconnectCall struct {
+	Fn func(addr string)
+}
+
+// MockMemoryUsageCall contains the arguments of a call of MockService.MemoryUsage
+type MockMemoryUsageCall struct {
+	Key     string
+	Samples int
+}
@@ -0,0 +1,23 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockServiceRecordsCalls(t *testing.T) {
+	mock := NewMockService()
+
+	assert.NoError(t, mock.Set("key1", []byte("value1")))
+	assert.NoError(t, mock.SetPX("key2", []byte("value2"), 1000))
+	assert.NoError(t, mock.Del("key1", "key2"))
+	_, _ = mock.HLen("hash1")
+
+	assert.Equal(t, 1, mock.SetFuncCalled)
+	assert.Equal(t, []MockSetCall{{Key: "key1", Data: []byte("value1")}}, mock.SetCalls)
+	assert.Equal(t, []MockSetPXCall{{Key: "key2", Data: []byte("value2"), TimeoutMS: 1000}}, mock.SetPXCalls)
+	assert.Equal(t, []MockDelCall{{Keys: []string{"key1", "key2"}}}, mock.DelCalls)
+	assert.Equal(t, []MockHLenCall{{Key: "hash1"}}, mock.HLenCalls)
+	assert.Empty(t, mock.GetCalls)
+}
@@ -0,0 +1,203 @@
+package gousuredis
+
+import (
+	"fmt"
+)
+
+// PipelineResult contains the reply or error of a single pipelined command
+type PipelineResult struct {
+	Reply interface{}
+	Error error
+}
+
+// IsError returns if an error occured
+func (r *PipelineResult) IsError() bool {
+	return r.Error != nil
+}
+
+// IPipeline defines the interface of Pipeline
+type IPipeline interface {
+	Send(commandName string, args ...interface{}) IPipeline
+	Set(key string, data []byte) IPipeline
+	SetPX(key string, data []byte, timeoutMS int) IPipeline
+	Del(key string) IPipeline
+	Expire(key string, seconds int) IPipeline
+	HSet(key string, field string, data []byte) IPipeline
+	HDel(key string, field string) IPipeline
+	RPush(key string, data []byte) IPipeline
+	LPush(key string, data []byte) IPipeline
+	Len() int
+	Flush() ([]PipelineResult, error)
+}
+
+type pipelineCommand struct {
+	name string
+	args []interface{}
+	// err is returned as result instead of sending the command, e.g. if encryption failed
+	err error
+}
+
+// Pipeline queues commands and sends them to redis in one batch via Flush()
+type Pipeline struct {
+	service  *Service
+	commands []pipelineCommand
+}
+
+var _ (IPipeline) = (*Pipeline)(nil)
+
+// Send queues a command
+func (p *Pipeline) Send(commandName string, args ...interface{}) IPipeline {
+	p.commands = append(p.commands, pipelineCommand{
+		name: commandName,
+		args: args,
+	})
+
+	return p
+}
+
+// sendEncrypted queues a command storing encrypted data, which fails in Flush if the
+// data can't be encrypted
+func (p *Pipeline) sendEncrypted(data []byte, err error, commandName string, args ...interface{}) IPipeline {
+	if err != nil {
+		p.commands = append(p.commands, pipelineCommand{
+			name: commandName,
+			err:  err,
+		})
+
+		return p
+	}
+
+	return p.Send(commandName, args...)
+}
+
+// Set queues storing a key and its value
+func (p *Pipeline) Set(key string, data []byte) IPipeline {
+	data, err := p.service.encryptValue(key, data)
+
+	return p.sendEncrypted(data, err, "SET", key, data)
+}
+
+// SetPX queues storing a key and its value with expiration time
+func (p *Pipeline) SetPX(key string, data []byte, timeoutMS int) IPipeline {
+	data, err := p.service.encryptValue(key, data)
+
+	return p.sendEncrypted(data, err, "SET", key, data, "PX", timeoutMS)
+}
+
+// Del queues deleting a key
+func (p *Pipeline) Del(key string) IPipeline {
+	return p.Send("DEL", key)
+}
+
+// Expire queues setting the expiration time of a key in seconds
+func (p *Pipeline) Expire(key string, seconds int) IPipeline {
+	return p.Send("EXPIRE", key, seconds)
+}
+
+// HSet queues storing a field and its value in a hash
+func (p *Pipeline) HSet(key string, field string, data []byte) IPipeline {
+	data, err := p.service.encryptField(key, field, data)
+
+	return p.sendEncrypted(data, err, "HSET", key, field, data)
+}
+
+// HDel queues deleting a field from a hash
+func (p *Pipeline) HDel(key string, field string) IPipeline {
+	return p.Send("HDEL", key, field)
+}
+
+// RPush queues appending an item to a list
+func (p *Pipeline) RPush(key string, data []byte) IPipeline {
+	return p.Send("RPUSH", key, data)
+}
+
+// LPush queues prepending an item to a list
+func (p *Pipeline) LPush(key string, data []byte) IPipeline {
+	return p.Send("LPUSH", key, data)
+}
+
+// Len returns the number of queued commands
+func (p *Pipeline) Len() int {
+	return len(p.commands)
+}
+
+// Flush sends all queued commands and returns their results in the same order
+//
+// The returned error is only set if the batch could not be sent, errors of single
+// commands are returned in their PipelineResult. In cluster mode the commands are sent
+// sequentially, as their keys can be located on different nodes.
+func (p *Pipeline) Flush() ([]PipelineResult, error) {
+	commands := p.commands
+	p.commands = nil
+
+	results := make([]PipelineResult, len(commands))
+
+	if len(commands) == 0 {
+		return results, nil
+	}
+
+	if p.service.cluster != nil {
+		conn, err := p.service.openConn(true)
+		if err != nil {
+			return nil, fmt.Errorf("can't connect to redis: %w", err)
+		}
+		defer conn.Close()
+
+		for i, command := range commands {
+			if command.err != nil {
+				results[i].Error = command.err
+
+				continue
+			}
+
+			results[i].Reply, results[i].Error = conn.Do(command.name, command.args...)
+		}
+
+		return results, nil
+	}
+
+	conn, err := p.service.openConn(false)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	for _, command := range commands {
+		if command.err != nil {
+			continue
+		}
+
+		err = conn.Send(command.name, command.args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = conn.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, command := range commands {
+		if command.err != nil {
+			results[i].Error = command.err
+
+			continue
+		}
+
+		results[i].Reply, results[i].Error = conn.Receive()
+
+		if results[i].Error != nil && conn.Err() != nil {
+			return nil, results[i].Error
+		}
+	}
+
+	return results, nil
+}
+
+// Pipeline creates a new pipeline for sending multiple commands in one batch
+func (s *Service) Pipeline() IPipeline {
+	return &Pipeline{
+		service: s,
+	}
+}
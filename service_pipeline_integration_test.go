@@ -0,0 +1,68 @@
+package gousuredis_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineEncrypted(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.EncryptionKeys = []string{base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))}
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	results, err := service.Pipeline().
+		Set("pipeline01", []byte("value1")).
+		SetPX("pipeline02", []byte("value2"), 60000).
+		HSet("pipeline03", "field", []byte("value3")).
+		Flush()
+	require.NoError(t, err)
+
+	for _, result := range results {
+		assert.NoError(t, result.Error)
+	}
+
+	value, err := service.Get("pipeline01")
+	assert.NoError(t, err)
+	assert.Equal(t, "value1", string(value))
+
+	value, err = service.Get("pipeline02")
+	assert.NoError(t, err)
+	assert.Equal(t, "value2", string(value))
+
+	value, err = service.HGet("pipeline03", "field")
+	assert.NoError(t, err)
+	assert.Equal(t, "value3", string(value))
+
+	// Values are stored encrypted
+	raw, err := service.Do("GET", "pipeline01")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "value1", string(raw.([]byte)))
+}
+
+func TestPipelineResults(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	results, err := service.Pipeline().
+		Set("pipeline01", []byte("value1")).
+		HSet("pipeline01", "field", []byte("value2")).
+		RPush("pipeline02", []byte("item")).
+		Flush()
+	require.NoError(t, err)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "OK", results[0].Reply)
+	assert.NoError(t, results[0].Error)
+	assert.True(t, gousuredis.IsServerError(results[1].Error))
+	assert.Equal(t, int64(1), results[2].Reply)
+	assert.NoError(t, results[2].Error)
+
+	results, err = service.Pipeline().Flush()
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,215 @@
+package gousuredis
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pipelineTestConn replies to pipelined commands in the order they were sent
+type pipelineTestConn struct {
+	fakeConn
+	sent    []string
+	replies map[string]interface{}
+	queue   []string
+	flushed bool
+}
+
+func (c *pipelineTestConn) Send(commandName string, args ...interface{}) error {
+	c.sent = append(c.sent, commandName)
+	c.queue = append(c.queue, commandName)
+
+	return nil
+}
+
+func (c *pipelineTestConn) Flush() error {
+	c.flushed = true
+
+	return nil
+}
+
+func (c *pipelineTestConn) Receive() (interface{}, error) {
+	commandName := c.queue[0]
+	c.queue = c.queue[1:]
+
+	reply := c.replies[commandName]
+	if err, ok := reply.(error); ok {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// movedTestConn is a cluster node replying MOVED to commands for keys served by the other node
+type movedTestConn struct {
+	clusterNodeConn
+}
+
+func (c *movedTestConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	if commandName == "" || commandName == "CLUSTER" {
+		return c.clusterNodeConn.Do(commandName, args...)
+	}
+
+	slot := redisc.Slot(args[0].(string))
+
+	owner := "node1:6379"
+	if slot >= 8000 {
+		owner = "node2:6379"
+	}
+
+	if owner != c.addr {
+		return nil, redis.Error(fmt.Sprintf("MOVED %d %s", slot, owner))
+	}
+
+	return c.clusterNodeConn.Do(commandName, args...)
+}
+
+func newPipelineTestService(conn redis.Conn) *Service {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+	service.pool = &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return conn, nil
+		},
+	}
+
+	return service
+}
+
+func TestPipelineFlush(t *testing.T) {
+	conn := &pipelineTestConn{
+		replies: map[string]interface{}{
+			"SET":   "OK",
+			"HSET":  redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"),
+			"RPUSH": int64(1),
+		},
+	}
+
+	service := newPipelineTestService(conn)
+
+	results, err := service.Pipeline().
+		Set("key1", []byte("value1")).
+		HSet("key1", "field", []byte("value2")).
+		RPush("list", []byte("item")).
+		Flush()
+	require.NoError(t, err)
+
+	assert.True(t, conn.flushed)
+	assert.Equal(t, []string{"SET", "HSET", "RPUSH"}, conn.sent)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "OK", results[0].Reply)
+	assert.False(t, results[0].IsError())
+	assert.True(t, results[1].IsError())
+	assert.True(t, IsServerError(results[1].Error))
+	assert.Equal(t, int64(1), results[2].Reply)
+	assert.False(t, results[2].IsError())
+}
+
+func TestPipelineFlushEmpty(t *testing.T) {
+	conn := &pipelineTestConn{}
+
+	service := newPipelineTestService(conn)
+
+	pipeline := service.Pipeline()
+	assert.Equal(t, 0, pipeline.Len())
+
+	results, err := pipeline.Flush()
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.False(t, conn.flushed)
+
+	// Flush resets the queued commands
+	pipeline.Del("key")
+	assert.Equal(t, 1, pipeline.Len())
+
+	_, err = pipeline.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, 0, pipeline.Len())
+}
+
+func TestPipelineEncryptionFailure(t *testing.T) {
+	conn := &pipelineTestConn{
+		replies: map[string]interface{}{
+			"DEL":   int64(1),
+			"RPUSH": int64(1),
+		},
+	}
+
+	service := newPipelineTestService(conn)
+	service.cipher = &valueCipher{
+		keyFunc: func(version byte) ([]byte, error) {
+			return nil, errors.New("kms unavailable")
+		},
+		version: 1,
+		aeads:   map[byte]cipher.AEAD{},
+	}
+
+	results, err := service.Pipeline().
+		Del("key1").
+		Set("key2", []byte("value")).
+		RPush("list", []byte("item")).
+		Flush()
+	require.NoError(t, err)
+
+	// The failed command isn't sent, its error is returned in its own slot
+	assert.Equal(t, []string{"DEL", "RPUSH"}, conn.sent)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, int64(1), results[0].Reply)
+	assert.NoError(t, results[0].Error)
+	require.Error(t, results[1].Error)
+	assert.Contains(t, results[1].Error.Error(), "kms unavailable")
+	assert.Equal(t, int64(1), results[2].Reply)
+	assert.NoError(t, results[2].Error)
+}
+
+func TestPipelineFlushCluster(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	commands := []string{}
+
+	service.cluster = &redisc.Cluster{
+		StartupNodes: []string{"node1:6379", "node2:6379"},
+		CreatePool: func(addr string, opts ...redis.DialOption) (*redis.Pool, error) {
+			return &redis.Pool{
+				Dial: func() (redis.Conn, error) {
+					return &movedTestConn{clusterNodeConn{addr: addr, commands: &commands}}, nil
+				},
+			}, nil
+		},
+	}
+	require.NoError(t, service.cluster.Refresh())
+	service.cipher = &valueCipher{
+		keyFunc: func(version byte) ([]byte, error) {
+			return nil, errors.New("kms unavailable")
+		},
+		version: 1,
+		aeads:   map[byte]cipher.AEAD{},
+	}
+
+	// "c" is served by node1, "app:c" by node2
+	results, err := service.Pipeline().
+		Del("c").
+		Set("c", []byte("value")).
+		Del("app:c").
+		Flush()
+	require.NoError(t, err)
+
+	// The commands are sent one by one, following redirections to the node serving its key
+	assert.Equal(t, []string{
+		"node1:6379 DEL",
+		"node2:6379 DEL",
+	}, commands)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "OK", results[0].Reply)
+	require.Error(t, results[1].Error)
+	assert.Contains(t, results[1].Error.Error(), "kms unavailable")
+	assert.Equal(t, "OK", results[2].Reply)
+}
@@ -0,0 +1,164 @@
+package gousuredis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+)
+
+// pubSubPingInterval is the interval in which subscriptions are pinged to check if the
+// connection is still alive
+const pubSubPingInterval = 5 * time.Second
+
+// pubSubReceiveTimeout is the read timeout of subscriptions, replacing redis_read_timeout_ms
+// as idle subscriptions only receive the pong of every ping
+const pubSubReceiveTimeout = 2 * pubSubPingInterval
+
+// openPubSubConn opens a connection for a subscription
+//
+// In cluster mode sharded subscriptions get bound to the node serving the slot of the channels.
+func (s *Service) openPubSubConn(sharded bool, channels []string) (*redis.PubSubConn, error) {
+	conn, err := s.openConn(false)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+
+	if s.cluster != nil && sharded && len(channels) > 0 {
+		err = redisc.BindConn(conn.(*serviceConn).delegate, channels...)
+		if err != nil {
+			conn.Close()
+
+			return nil, fmt.Errorf("can't bind connection to channels: %s", err)
+		}
+	}
+
+	return &redis.PubSubConn{Conn: conn}, nil
+}
+
+func pubSubSubscribe(psc *redis.PubSubConn, sharded bool, channels ...interface{}) error {
+	if !sharded {
+		return psc.Subscribe(channels...)
+	}
+
+	err := psc.Conn.Send("SSUBSCRIBE", channels...)
+	if err != nil {
+		return err
+	}
+
+	return psc.Conn.Flush()
+}
+
+func pubSubUnsubscribe(psc *redis.PubSubConn, sharded bool, channels ...interface{}) error {
+	if !sharded {
+		return psc.Unsubscribe(channels...)
+	}
+
+	err := psc.Conn.Send("SUNSUBSCRIBE", channels...)
+	if err != nil {
+		return err
+	}
+
+	return psc.Conn.Flush()
+}
+
+// receivePubSub receives the next pub/sub notification like redis.PubSubConn.Receive(),
+// additionally supporting the notifications of sharded subscriptions
+func receivePubSub(psc *redis.PubSubConn) interface{} {
+	reply, err := redis.Values(redis.ReceiveWithTimeout(psc.Conn, pubSubReceiveTimeout))
+	if err != nil {
+		return err
+	}
+
+	var kind string
+	reply, err = redis.Scan(reply, &kind)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case "message", "smessage":
+		var m redis.Message
+		if _, err := redis.Scan(reply, &m.Channel, &m.Data); err != nil {
+			return err
+		}
+
+		return m
+	case "pmessage":
+		var m redis.Message
+		if _, err := redis.Scan(reply, &m.Pattern, &m.Channel, &m.Data); err != nil {
+			return err
+		}
+
+		return m
+	case "subscribe", "psubscribe", "ssubscribe", "unsubscribe", "punsubscribe", "sunsubscribe":
+		n := redis.Subscription{Kind: kind}
+		if _, err := redis.Scan(reply, &n.Channel, &n.Count); err != nil {
+			return err
+		}
+
+		return n
+	case "pong":
+		var p redis.Pong
+		if _, err := redis.Scan(reply, &p.Data); err != nil {
+			return err
+		}
+
+		return p
+	}
+
+	return errors.New("unknown pubsub notification")
+}
+
+// SSubscribe subscribes to sharded channels (redis >= 7) and returns a subscription
+//
+// In cluster mode messages of sharded channels are only distributed within the shard serving
+// the channel's slot, so all channels of a subscription must belong to the same slot (e.g. by
+// using hash tags like "{orders}:created" and "{orders}:deleted").
+func (s *Service) SSubscribe(channels []string) (chan Message, ISubscription, error) {
+	return s.subscribe(channels, true)
+}
+
+// SPublish emits a message on a sharded channel (redis >= 7) and returns the number of receivers
+func (s *Service) SPublish(channel string, data []byte) (int, error) {
+	// In cluster mode the connection gets bound to the slot of the first argument (the channel)
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("SPUBLISH", channel, data))
+}
+
+// PubSubChannels lists the channels with at least one subscriber matching the glob-style
+// pattern (all channels if empty)
+//
+// In cluster mode only the channels of the node serving the connection are listed.
+func (s *Service) PubSubChannels(pattern string) ([]string, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{"CHANNELS"}
+	if pattern != "" {
+		args = args.Add(pattern)
+	}
+
+	return redis.Strings(conn.Do("PUBSUB", args...))
+}
+
+// PubSubNumSub returns the number of subscribers of each channel
+func (s *Service) PubSubNumSub(channels ...string) (map[string]int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.IntMap(conn.Do("PUBSUB", redis.Args{"NUMSUB"}.AddFlat(channels)...))
+}
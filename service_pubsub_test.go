@@ -0,0 +1,40 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeIdleBelowReadTimeout(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.ReadTimeout = 1 * time.Second
+	options.SubscribeReconnect = false
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	messages, subscription, err := service.Subscribe([]string{"idle"})
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	// Idle for longer than the read timeout and the ping interval
+	time.Sleep(6 * time.Second)
+
+	receivers, err := service.Publish("idle", []byte("message"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, receivers)
+
+	select {
+	case msg, ok := <-messages:
+		require.True(t, ok, "subscription closed while idle")
+		require.NoError(t, msg.Error)
+		assert.Equal(t, "idle", msg.Channel)
+		assert.Equal(t, "message", string(msg.Data))
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not received")
+	}
+}
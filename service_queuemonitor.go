@@ -0,0 +1,176 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// QueueType defines how the depth of a monitored queue is sampled
+type QueueType string
+
+const (
+	// QueueTypeList samples the queue depth via LLEN
+	QueueTypeList QueueType = "list"
+	// QueueTypeStream samples the queue depth via XLEN
+	QueueTypeStream QueueType = "stream"
+)
+
+// QueueMonitorConfig defines a queue key watched by the queue monitor
+type QueueMonitorConfig struct {
+	Key  string
+	Type QueueType
+	// Threshold triggers an alert if the depth reaches it (0 disables the check)
+	Threshold int
+	// MaxGrowthRate triggers an alert if the depth grows faster than n items per second (0 disables the check)
+	MaxGrowthRate float64
+	// OnAlert is called once when a queue starts exceeding its limits
+	OnAlert func(depth QueueDepth)
+}
+
+// QueueDepth is the last sampled depth of a monitored queue
+type QueueDepth struct {
+	Key        string
+	Type       QueueType
+	Depth      int
+	GrowthRate float64
+	Alerting   bool
+	SampledAt  time.Time
+	Error      error
+}
+
+type monitoredQueue struct {
+	config QueueMonitorConfig
+	depth  QueueDepth
+}
+
+// MonitorQueue registers a queue key for the background queue monitor
+//
+// The monitor is started with the first registered queue (or by Start for queues registered
+// before) and samples all queues every redis_queue_monitor_interval_ms. Queues aren't
+// sampled if the interval is 0.
+func (s *Service) MonitorQueue(config QueueMonitorConfig) error {
+	if config.Type != QueueTypeList && config.Type != QueueTypeStream {
+		return fmt.Errorf("invalid queue type '%s'", config.Type)
+	}
+
+	s.queueMonitorMutex.Lock()
+	s.monitoredQueues = append(s.monitoredQueues, &monitoredQueue{
+		config: config,
+		depth: QueueDepth{
+			Key:  config.Key,
+			Type: config.Type,
+		},
+	})
+	s.queueMonitorMutex.Unlock()
+
+	s.startQueueMonitor()
+
+	return nil
+}
+
+// startQueueMonitor starts sampling the queues once the service is started and a queue is registered
+func (s *Service) startQueueMonitor() {
+	if !s.isStarted() || s.options.QueueMonitorInterval <= 0 {
+		return
+	}
+
+	s.queueMonitorMutex.Lock()
+	defer s.queueMonitorMutex.Unlock()
+
+	if s.queueMonitorRunning || len(s.monitoredQueues) == 0 {
+		return
+	}
+
+	s.queueMonitorRunning = true
+
+	s.goWorker("queue monitor", s.withoutContext().runQueueMonitor)
+}
+
+// GetQueueDepths returns the last sampled depths of all monitored queues
+func (s *Service) GetQueueDepths() []QueueDepth {
+	s.queueMonitorMutex.Lock()
+	defer s.queueMonitorMutex.Unlock()
+
+	depths := make([]QueueDepth, 0, len(s.monitoredQueues))
+	for _, queue := range s.monitoredQueues {
+		depths = append(depths, queue.depth)
+	}
+
+	return depths
+}
+
+func (s *Service) runQueueMonitor() {
+	ticker := time.NewTicker(s.options.QueueMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		s.sampleQueues()
+
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) sampleQueues() {
+	s.queueMonitorMutex.Lock()
+	queues := make([]*monitoredQueue, len(s.monitoredQueues))
+	copy(queues, s.monitoredQueues)
+	s.queueMonitorMutex.Unlock()
+
+	for _, queue := range queues {
+		depth, err := s.sampleQueueDepth(queue.config)
+
+		s.queueMonitorMutex.Lock()
+
+		last := queue.depth
+		now := time.Now()
+
+		queue.depth.SampledAt = now
+		queue.depth.Error = err
+
+		if err != nil {
+			s.queueMonitorMutex.Unlock()
+
+			s.log.Warnf("Sampling depth of queue %s failed: %s", queue.config.Key, err)
+
+			continue
+		}
+
+		queue.depth.Depth = depth
+		queue.depth.GrowthRate = 0
+		if !last.SampledAt.IsZero() && last.Error == nil {
+			queue.depth.GrowthRate = float64(depth-last.Depth) / now.Sub(last.SampledAt).Seconds()
+		}
+
+		queue.depth.Alerting = (queue.config.Threshold > 0 && depth >= queue.config.Threshold) ||
+			(queue.config.MaxGrowthRate > 0 && queue.depth.GrowthRate > queue.config.MaxGrowthRate)
+
+		current := queue.depth
+
+		s.queueMonitorMutex.Unlock()
+
+		if current.Alerting && !last.Alerting && queue.config.OnAlert != nil {
+			queue.config.OnAlert(current)
+		}
+	}
+}
+
+func (s *Service) sampleQueueDepth(config QueueMonitorConfig) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	switch config.Type {
+	case QueueTypeStream:
+		return redis.Int(conn.Do("XLEN", config.Key))
+	default:
+		return redis.Int(conn.Do("LLEN", config.Key))
+	}
+}
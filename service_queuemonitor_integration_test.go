@@ -0,0 +1,87 @@
+package gousuredis_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queueDepth(service *gousuredis.Service, key string) gousuredis.QueueDepth {
+	for _, depth := range service.Stats().Queues {
+		if depth.Key == key {
+			return depth
+		}
+	}
+
+	return gousuredis.QueueDepth{}
+}
+
+func TestQueueMonitor(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.QueueMonitorInterval = 100 * time.Millisecond
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	mutex := sync.Mutex{}
+	alerts := []gousuredis.QueueDepth{}
+
+	require.NoError(t, service.MonitorQueue(gousuredis.QueueMonitorConfig{
+		Key:       "list",
+		Type:      gousuredis.QueueTypeList,
+		Threshold: 3,
+		OnAlert: func(depth gousuredis.QueueDepth) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			alerts = append(alerts, depth)
+		},
+	}))
+	require.NoError(t, service.MonitorQueue(gousuredis.QueueMonitorConfig{
+		Key:  "stream",
+		Type: gousuredis.QueueTypeStream,
+	}))
+
+	_, err := service.Do("RPUSH", "list", "a", "b")
+	require.NoError(t, err)
+
+	_, err = service.XAdd("stream", map[string]string{"key": "value"})
+	require.NoError(t, err)
+
+	// LLEN and XLEN are sampled
+	assert.Eventually(t, func() bool {
+		return queueDepth(service, "list").Depth == 2 && queueDepth(service, "stream").Depth == 1
+	}, 2*time.Second, 50*time.Millisecond)
+
+	assert.False(t, queueDepth(service, "list").Alerting)
+
+	// Crossing the threshold alerts once, as long as the queue stays above it
+	_, err = service.Do("RPUSH", "list", "c", "d")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return queueDepth(service, "list").Depth == 4
+	}, 2*time.Second, 50*time.Millisecond)
+
+	depth := queueDepth(service, "list")
+	assert.True(t, depth.Alerting)
+	assert.NoError(t, depth.Error)
+
+	time.Sleep(300 * time.Millisecond)
+
+	// The growth rate drops to 0 on the following samples
+	assert.Equal(t, 0.0, queueDepth(service, "list").GrowthRate)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "list", alerts[0].Key)
+	assert.Equal(t, 4, alerts[0].Depth)
+	// 2 items were added since the previous sample
+	assert.Greater(t, alerts[0].GrowthRate, 0.0)
+}
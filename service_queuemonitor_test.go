@@ -0,0 +1,28 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/indece-official/go-gousu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitorQueueBeforeStart(t *testing.T) {
+	service := NewService(gousu.NewContext()).(*Service)
+
+	assert.NoError(t, service.MonitorQueue(QueueMonitorConfig{Key: "queue", Type: QueueTypeList}))
+	assert.Error(t, service.MonitorQueue(QueueMonitorConfig{Key: "queue", Type: "set"}))
+	assert.False(t, service.queueMonitorRunning)
+	assert.Equal(t, []QueueDepth{{Key: "queue", Type: QueueTypeList}}, service.GetQueueDepths())
+}
+
+func TestMonitorQueueDisabled(t *testing.T) {
+	options := DefaultOptions()
+	options.QueueMonitorInterval = 0
+
+	service := NewServiceWithOptions("redis", options)
+	service.setStarted()
+
+	assert.NoError(t, service.MonitorQueue(QueueMonitorConfig{Key: "queue", Type: QueueTypeStream}))
+	assert.False(t, service.queueMonitorRunning)
+}
@@ -0,0 +1,88 @@
+package gousuredis
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	backgroundStartMinInterval = 1 * time.Second
+	backgroundStartMaxInterval = 30 * time.Second
+)
+
+// ErrNotReady is returned by Health while the service is still connecting in the
+// background (only with redis_start_background)
+var ErrNotReady = errors.New("redis service not ready")
+
+// Ready checks if the service has connected to redis successfully
+//
+// Without redis_start_background a started service is always ready, as Start fails
+// if redis is unreachable.
+func (s *Service) Ready() bool {
+	return atomic.LoadUint32(&s.ready) == 1
+}
+
+func (s *Service) setReady() {
+	atomic.StoreUint32(&s.ready, 1)
+}
+
+func (s *Service) setStarted() {
+	atomic.StoreUint32(&s.started, 1)
+}
+
+// isStarted checks if Start created the connection pools, background listeners
+// registered before are started by Start
+func (s *Service) isStarted() bool {
+	return atomic.LoadUint32(&s.started) == 1
+}
+
+// ping checks the connection to redis
+func (s *Service) ping() error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("PING")
+	if IsAuthError(err) {
+		return fmt.Errorf("can't authenticate against redis: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("can't ping redis: %w", err)
+	}
+
+	return nil
+}
+
+// connectInBackground pings redis with increasing intervals until it succeeds and marks
+// the service as ready
+func (s *Service) connectInBackground() {
+	interval := backgroundStartMinInterval
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-time.After(interval):
+		}
+
+		err := s.ping()
+		if err == nil {
+			s.setReady()
+
+			s.log.Infof("Connected to redis")
+
+			return
+		}
+
+		s.log.Warnf("Redis not ready, retrying in background: %s", err)
+
+		interval *= 2
+		if interval > backgroundStartMaxInterval {
+			interval = backgroundStartMaxInterval
+		}
+	}
+}
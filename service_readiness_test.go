@@ -0,0 +1,25 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartBackground(t *testing.T) {
+	options := DefaultOptions()
+	options.Port = 1
+
+	service := NewServiceWithOptions("redis", options)
+	assert.Error(t, service.Start())
+	assert.False(t, service.Ready())
+	assert.NoError(t, service.Stop())
+
+	options.StartBackground = true
+
+	service = NewServiceWithOptions("redis", options)
+	assert.NoError(t, service.Start())
+	assert.False(t, service.Ready())
+	assert.ErrorIs(t, service.Health(), ErrNotReady)
+	assert.NoError(t, service.Stop())
+}
@@ -0,0 +1,84 @@
+package gousuredis
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const connRecycleTimeout = 5 * time.Second
+
+// trackPool registers a pool created via createPool for recycling, replacing a previous
+// pool for the same address (e.g. of a cluster node)
+func (s *Service) trackPool(addr string, pool *redis.Pool) {
+	s.poolsMutex.Lock()
+	defer s.poolsMutex.Unlock()
+
+	if s.pools == nil {
+		s.pools = map[string]*redis.Pool{}
+	}
+
+	s.pools[addr] = pool
+}
+
+func (s *Service) runConnRecycler() {
+	ticker := time.NewTicker(s.options.ConnRecycleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.recycleConns()
+	}
+}
+
+// recycleConns borrows all idle connections of all pools at once, which pings them and
+// replaces dead connections and connections exceeding redis_max_conn_lifetime
+func (s *Service) recycleConns() {
+	s.poolsMutex.Lock()
+	pools := make([]*redis.Pool, 0, len(s.pools))
+	for _, pool := range s.pools {
+		pools = append(pools, pool)
+	}
+	s.poolsMutex.Unlock()
+
+	for _, pool := range pools {
+		err := recyclePool(pool)
+		if err != nil && err != redis.ErrPoolExhausted && err != context.DeadlineExceeded {
+			s.log.Warnf("Can't recycle redis connections: %s", err)
+		}
+	}
+}
+
+func recyclePool(pool *redis.Pool) error {
+	idle := pool.Stats().IdleCount
+	if idle == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), connRecycleTimeout)
+	defer cancel()
+
+	conns := make([]redis.Conn, 0, idle)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	for i := 0; i < idle; i++ {
+		conn, err := pool.GetContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		conns = append(conns, conn)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package gousuredis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConn struct {
+	redis.Conn
+	dead bool
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Err() error {
+	return nil
+}
+
+func (c *fakeConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestRecyclePool(t *testing.T) {
+	dialed := []*fakeConn{}
+
+	pool := &redis.Pool{
+		MaxIdle: 3,
+		Dial: func() (redis.Conn, error) {
+			conn := &fakeConn{}
+			dialed = append(dialed, conn)
+
+			return conn, nil
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if c.(*fakeConn).dead {
+				return errors.New("connection reset")
+			}
+
+			return nil
+		},
+	}
+
+	assert.NoError(t, recyclePool(pool))
+	assert.Len(t, dialed, 0)
+
+	conns := []redis.Conn{pool.Get(), pool.Get(), pool.Get()}
+	for _, conn := range conns {
+		conn.Close()
+	}
+
+	assert.Len(t, dialed, 3)
+	assert.Equal(t, 3, pool.Stats().IdleCount)
+
+	assert.NoError(t, recyclePool(pool))
+	assert.Len(t, dialed, 3)
+
+	dialed[0].dead = true
+	dialed[2].dead = true
+
+	assert.NoError(t, recyclePool(pool))
+	assert.Len(t, dialed, 5)
+	assert.Equal(t, 3, pool.Stats().IdleCount)
+}
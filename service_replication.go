@@ -0,0 +1,129 @@
+package gousuredis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const waitPollInterval = 10 * time.Millisecond
+
+// ErrNotReplicated is returned if a write wasn't acknowledged by the required number of
+// replicas within the timeout, the write itself is not rolled back
+var ErrNotReplicated = errors.New("write not acknowledged by enough replicas")
+
+// waitReplicas runs WAIT after a write on the same connection
+func waitReplicas(conn redis.Conn, numReplicas int, timeoutMS int) (int, error) {
+	acked, err := redis.Int(redis.DoWithTimeout(
+		conn,
+		blockReadTimeout(time.Duration(timeoutMS)*time.Millisecond),
+		"WAIT",
+		numReplicas,
+		timeoutMS,
+	))
+	if err != nil {
+		return 0, err
+	}
+
+	if acked < numReplicas {
+		return acked, fmt.Errorf("%w: %d of %d", ErrNotReplicated, acked, numReplicas)
+	}
+
+	return acked, nil
+}
+
+// Wait waits up to timeoutMS (0 = forever) until numReplicas replicas have processed all
+// writes the master received so far and returns the number of those replicas
+//
+// As WAIT only applies to writes of the same connection, Wait compares the replication
+// offsets of the replicas instead, so it covers writes sent on any pooled connection.
+// Not supported in cluster mode, use SetWithReplication instead.
+//
+// Returns ErrNotReplicated if fewer replicas caught up within the timeout.
+func (s *Service) Wait(numReplicas int, timeoutMS int) (int, error) {
+	if s.cluster != nil {
+		return 0, fmt.Errorf("wait is not supported in cluster mode")
+	}
+
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	var deadline time.Time
+	if timeoutMS > 0 {
+		deadline = time.Now().Add(time.Duration(timeoutMS) * time.Millisecond)
+	}
+
+	targetOffset := int64(-1)
+
+	for {
+		text, err := redis.String(conn.Do("INFO", "replication"))
+		if err != nil {
+			return 0, err
+		}
+
+		info := parseInfo(text)
+		if targetOffset < 0 {
+			targetOffset = info.MasterReplOffset
+		}
+
+		acked := 0
+		for _, replica := range info.Replicas {
+			if replica.Offset >= targetOffset {
+				acked++
+			}
+		}
+
+		if acked >= numReplicas {
+			return acked, nil
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return acked, fmt.Errorf("%w: %d of %d", ErrNotReplicated, acked, numReplicas)
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return acked, s.ctx.Err()
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// SetWithReplication stores a key and its value like Set and waits up to timeoutMS (0 = forever)
+// until numReplicas replicas acknowledged the write, returning the number of acknowledging replicas
+//
+// Returns ErrNotReplicated if fewer replicas acknowledged the write within the timeout.
+func (s *Service) SetWithReplication(key string, data []byte, numReplicas int, timeoutMS int) (int, error) {
+	return s.setWithReplication(key, data, nil, numReplicas, timeoutMS)
+}
+
+// SetPXWithReplication stores a key and its value with expiration time like SetPX and waits
+// for the replicas like SetWithReplication
+func (s *Service) SetPXWithReplication(key string, data []byte, expireMS int, numReplicas int, timeoutMS int) (int, error) {
+	return s.setWithReplication(key, data, []interface{}{"PX", expireMS}, numReplicas, timeoutMS)
+}
+
+func (s *Service) setWithReplication(key string, data []byte, options []interface{}, numReplicas int, timeoutMS int) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	data, err = s.encryptValue(key, data)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = conn.Do("SET", append([]interface{}{key, data}, options...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return waitReplicas(conn, numReplicas, timeoutMS)
+}
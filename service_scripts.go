@@ -0,0 +1,60 @@
+package gousuredis
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ScriptKill kills the currently running read-only lua script
+func (s *Service) ScriptKill() error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SCRIPT", "KILL")
+
+	return err
+}
+
+// trackBusy records how long redis is busy running a script and kills the
+// script after redis_script_kill_timeout_ms
+func (s *Service) trackBusy(err error) {
+	if !IsBusy(err) {
+		if err == nil && atomic.LoadInt64(&s.busySince) != 0 {
+			atomic.StoreInt64(&s.busySince, 0)
+		}
+
+		return
+	}
+
+	if s.options.ScriptKillTimeout <= 0 {
+		return
+	}
+
+	now := time.Now().UnixNano()
+
+	if atomic.CompareAndSwapInt64(&s.busySince, 0, now) {
+		return
+	}
+
+	busySince := atomic.LoadInt64(&s.busySince)
+	if busySince == 0 || time.Duration(now-busySince) < s.options.ScriptKillTimeout {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt64(&s.busySince, busySince, 0) {
+		return
+	}
+
+	s.goWorker("script killer", func() {
+		s.log.Warnf("Redis busy running a script for more than %s, killing it", s.options.ScriptKillTimeout)
+
+		err := s.ScriptKill()
+		if err != nil {
+			s.log.Errorf("Can't kill busy script: %s", err)
+		}
+	})
+}
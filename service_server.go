@@ -0,0 +1,62 @@
+package gousuredis
+
+import (
+	"errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ErrFlushNotAllowed is returned by FlushDB and FlushAll if redis_allow_flush is not set
+var ErrFlushNotAllowed = errors.New("flushing is not allowed, enable it via redis_allow_flush")
+
+// DBSize returns the number of keys in the database
+//
+// In cluster mode the keys of all master nodes are counted. Keys of other applications
+// sharing the database are counted regardless of redis_key_prefix.
+func (s *Service) DBSize() (int, error) {
+	size := 0
+
+	err := s.eachNode(func(conn redis.Conn) error {
+		nodeSize, err := redis.Int(conn.Do("DBSIZE"))
+		if err != nil {
+			return err
+		}
+
+		size += nodeSize
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// flush runs FLUSHDB / FLUSHALL on all nodes if allowed
+func (s *Service) flush(commandName string) error {
+	if !s.options.AllowFlush {
+		return ErrFlushNotAllowed
+	}
+
+	return s.eachNode(func(conn redis.Conn) error {
+		_, err := conn.Do(commandName)
+
+		return err
+	})
+}
+
+// FlushDB deletes all keys of the database, intended for resetting test environments
+//
+// Returns ErrFlushNotAllowed unless redis_allow_flush is set. Keys of other applications
+// sharing the database are deleted regardless of redis_key_prefix.
+func (s *Service) FlushDB() error {
+	return s.flush("FLUSHDB")
+}
+
+// FlushAll deletes all keys of all databases, intended for resetting test environments
+//
+// Returns ErrFlushNotAllowed unless redis_allow_flush is set.
+func (s *Service) FlushAll() error {
+	return s.flush("FLUSHALL")
+}
@@ -0,0 +1,187 @@
+package gousuredis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// SAdd adds members to a set and returns the number of newly added members
+func (s *Service) SAdd(key string, members ...[]byte) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("SADD", redis.Args{}.Add(key).AddFlat(members)...))
+}
+
+// SRem removes members from a set and returns the number of removed members
+func (s *Service) SRem(key string, members ...[]byte) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("SREM", redis.Args{}.Add(key).AddFlat(members)...))
+}
+
+// SMembers loads all members of a set
+func (s *Service) SMembers(key string) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.ByteSlices(conn.Do("SMEMBERS", key))
+}
+
+// SIsMember checks if a member is part of a set
+func (s *Service) SIsMember(key string, member []byte) (bool, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("SISMEMBER", key, member))
+}
+
+// SCard gets the number of members in a set
+func (s *Service) SCard(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("SCARD", key))
+}
+
+// SPop removes and returns up to count random members from a set
+func (s *Service) SPop(key string, count int) ([][]byte, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.ByteSlices(conn.Do("SPOP", key, count))
+}
+
+// SRandMember returns up to count random members from a set without removing them
+//
+// A negative count allows the same member to be returned multiple times
+func (s *Service) SRandMember(key string, count int) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.ByteSlices(conn.Do("SRANDMEMBER", key, count))
+}
+
+// SInter returns the members of the intersection of all given sets
+func (s *Service) SInter(keys ...string) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.ByteSlices(conn.Do("SINTER", redis.Args{}.AddFlat(keys)...))
+}
+
+// SUnion returns the members of the union of all given sets
+func (s *Service) SUnion(keys ...string) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.ByteSlices(conn.Do("SUNION", redis.Args{}.AddFlat(keys)...))
+}
+
+// SDiff returns the members of the first set which are not part of any of the following sets
+func (s *Service) SDiff(keys ...string) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.ByteSlices(conn.Do("SDIFF", redis.Args{}.AddFlat(keys)...))
+}
+
+// SScan scans a set for members matching the glob-style pattern (all if empty) and
+// returns the next cursor and the members
+//
+// count is a hint for the number of members checked per call (0 uses the server default)
+func (s *Service) SScan(key string, cursor int, match string, count int) (int, [][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return sscan(conn, key, cursor, match, count)
+}
+
+func sscan(conn redis.Conn, key string, cursor int, match string, count int) (int, [][]byte, error) {
+	members := make([][]byte, 0)
+
+	resp, err := redis.Values(conn.Do("SSCAN", collectionScanArgs(key, cursor, match, count)...))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	_, err = redis.Scan(resp, &cursor, &members)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return cursor, members, nil
+}
+
+// SScanAll loads all members matching the glob-style pattern (all if empty) of a set
+// by iterating SSCAN, so large sets don't block the server
+//
+// Members added or removed while iterating may be missing
+func (s *Service) SScanAll(key string, match string) ([][]byte, error) {
+	conn, err := s.openPinnedReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	result := [][]byte{}
+	seen := map[string]bool{}
+	cursor := 0
+
+	for {
+		var members [][]byte
+
+		cursor, members, err = sscan(conn, key, cursor, match, scanBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			if seen[string(member)] {
+				continue
+			}
+
+			seen[string(member)] = true
+			result = append(result, member)
+		}
+
+		if cursor == 0 {
+			return result, nil
+		}
+	}
+}
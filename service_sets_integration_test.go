@@ -0,0 +1,120 @@
+package gousuredis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSets(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	added, err := service.SAdd("set1", []byte("a"), []byte("b"), []byte("c"), []byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, added)
+
+	added, err = service.SAdd("set2", []byte("b"), []byte("c"), []byte("d"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, added)
+
+	members, err := service.SMembers("set1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, members)
+
+	isMember, err := service.SIsMember("set1", []byte("a"))
+	require.NoError(t, err)
+	assert.True(t, isMember)
+
+	isMember, err = service.SIsMember("set1", []byte("d"))
+	require.NoError(t, err)
+	assert.False(t, isMember)
+
+	count, err := service.SCard("set1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	members, err = service.SInter("set1", "set2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]byte{[]byte("b"), []byte("c")}, members)
+
+	members, err = service.SUnion("set1", "set2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}, members)
+
+	members, err = service.SDiff("set1", "set2")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a")}, members)
+
+	members, err = service.SRandMember("set1", 2)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	// A negative count can return members multiple times
+	members, err = service.SRandMember("set1", -5)
+	require.NoError(t, err)
+	assert.Len(t, members, 5)
+
+	count, err = service.SCard("set1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	removed, err := service.SRem("set1", []byte("a"), []byte("missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	members, err = service.SPop("set1", 5)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]byte{[]byte("b"), []byte("c")}, members)
+
+	count, err = service.SCard("set1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSetScan(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	for i := 0; i < 200; i++ {
+		_, err := service.SAdd("set", []byte(fmt.Sprintf("user:%d", i)))
+		require.NoError(t, err)
+	}
+	_, err := service.SAdd("set", []byte("other"))
+	require.NoError(t, err)
+
+	scanned := map[string]bool{}
+	cursor := 0
+
+	for {
+		var members [][]byte
+
+		cursor, members, err = service.SScan("set", cursor, "user:*", 10)
+		require.NoError(t, err)
+
+		for _, member := range members {
+			scanned[string(member)] = true
+		}
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	assert.Len(t, scanned, 200)
+	assert.True(t, scanned["user:42"])
+	assert.False(t, scanned["other"])
+
+	members, err := service.SScanAll("set", "")
+	require.NoError(t, err)
+	assert.Len(t, members, 201)
+
+	members, err = service.SScanAll("set", "user:1?")
+	require.NoError(t, err)
+	assert.Len(t, members, 10)
+
+	members, err = service.SScanAll("missing", "")
+	require.NoError(t, err)
+	assert.Len(t, members, 0)
+}
@@ -0,0 +1,60 @@
+package gousuredis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// SortOptions configures Sort
+type SortOptions struct {
+	// By sorts by the values of external keys, "*" in the pattern is replaced by the element
+	// (e.g. "weight_*" or "object_*->weight" for hash fields), "nosort" skips sorting
+	By string
+	// Offset and Count limit the result (no limit if Count is 0)
+	Offset int
+	Count  int
+	// Get returns the values of external keys instead of the elements ("#" returns the element)
+	Get []string
+	// Descending sorts from the largest to the smallest element
+	Descending bool
+	// Alpha sorts lexicographically instead of numerically
+	Alpha bool
+}
+
+// Sort returns the sorted elements of a list, set or sorted set
+//
+// With multiple Get patterns the results of all patterns are returned one after the other
+// for each element, missing external keys are nil. By and Get patterns are not supported
+// in cluster mode, as the external keys can be located on different nodes.
+func (s *Service) Sort(key string, options SortOptions) ([][]byte, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{key}
+
+	if options.By != "" {
+		args = args.Add("BY", options.By)
+	}
+
+	if options.Count > 0 {
+		args = args.Add("LIMIT", options.Offset, options.Count)
+	}
+
+	for _, pattern := range options.Get {
+		args = args.Add("GET", pattern)
+	}
+
+	if options.Descending {
+		args = args.Add("DESC")
+	}
+
+	if options.Alpha {
+		args = args.Add("ALPHA")
+	}
+
+	return redis.ByteSlices(conn.Do("SORT", args...))
+}
@@ -0,0 +1,57 @@
+package gousuredis
+
+import (
+	"sync/atomic"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Stats contains statistics of the connection pool and the commands sent by the service
+type Stats struct {
+	// Pool contains the stats of the connection pool, summed up over all nodes in cluster mode
+	Pool redis.PoolStats
+	// Nodes contains the stats of the connection pools by node address in cluster mode
+	// or by replica address if read replicas are configured
+	Nodes map[string]redis.PoolStats
+
+	// Commands is the number of commands sent since the start
+	Commands uint64
+	// Errors is the number of failed commands and connection errors since the start
+	Errors uint64
+
+	// Queues contains the last sampled depths of all queues registered via MonitorQueue
+	Queues []QueueDepth
+}
+
+// Stats returns statistics of the connection pool and the commands sent by the service
+func (s *Service) Stats() Stats {
+	stats := Stats{
+		Nodes:    map[string]redis.PoolStats{},
+		Commands: atomic.LoadUint64(&s.commandCount),
+		Errors:   atomic.LoadUint64(&s.errorCount),
+		Queues:   s.GetQueueDepths(),
+	}
+
+	if s.cluster == nil {
+		if s.pool != nil {
+			stats.Pool = s.pool.Stats()
+		}
+
+		for i, pool := range s.replicaPools {
+			stats.Nodes[s.options.ReplicaAddrs[i]] = pool.Stats()
+		}
+
+		return stats
+	}
+
+	for addr, nodeStats := range s.cluster.Stats() {
+		stats.Nodes[addr] = nodeStats
+
+		stats.Pool.ActiveCount += nodeStats.ActiveCount
+		stats.Pool.IdleCount += nodeStats.IdleCount
+		stats.Pool.WaitCount += nodeStats.WaitCount
+		stats.Pool.WaitDuration += nodeStats.WaitDuration
+	}
+
+	return stats
+}
@@ -3,6 +3,7 @@ package gousuredis
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -12,7 +13,7 @@ import (
 func (s *Service) XAdd(key string, data map[string]string) (string, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return "", fmt.Errorf("can't connect to redis: %s", err)
+		return "", fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -39,7 +40,7 @@ const (
 func (s *Service) XGroupCreate(groupName string, key string, offset XGroupCreateOffset, mkStream bool, ignoreBusy bool) error {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return fmt.Errorf("can't connect to redis: %s", err)
+		return fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
@@ -78,13 +79,13 @@ type XEvent struct {
 
 // XReadGroup waits for a new item in a stream (blocking with timeout)
 func (s *Service) XReadGroup(groupName string, consumerName string, key string, timeout time.Duration, streamID XReadGroupStreamID) (*XEvent, error) {
-	conn, err := s.openConn(true)
+	conn, err := s.openBlockingConn()
 	if err != nil {
-		return nil, fmt.Errorf("can't connect to redis: %s", err)
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
-	result, err := redis.Values(conn.Do("XREADGROUP", "GROUP", groupName, consumerName, "BLOCK", int(timeout/time.Millisecond), "STREAMS", key, streamID))
+	result, err := redis.Values(redis.DoWithTimeout(conn, blockReadTimeout(timeout), "XREADGROUP", "GROUP", groupName, consumerName, "BLOCK", int(timeout/time.Millisecond), "STREAMS", key, streamID))
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +110,8 @@ func (s *Service) XReadGroup(groupName string, consumerName string, key string,
 		return nil, fmt.Errorf("parsing key from result failed: %s", err)
 	}
 
+	evt.Key = s.stripKeyPrefix(evt.Key)
+
 	resultEvents, err := redis.Values(resultArr[1], nil)
 	if err != nil {
 		return nil, fmt.Errorf("parsing events from result failed: %s", err)
@@ -144,9 +147,252 @@ func (s *Service) XReadGroup(groupName string, consumerName string, key string,
 func (s *Service) XAck(groupName string, key string, id string) (int, error) {
 	conn, err := s.openConn(true)
 	if err != nil {
-		return 0, fmt.Errorf("can't connect to redis: %s", err)
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
 	}
 	defer conn.Close()
 
 	return redis.Int(conn.Do("XACK", key, groupName, id))
 }
+
+// xEventsReply converts a list of stream entries to events
+func xEventsReply(key string, reply interface{}) ([]*XEvent, error) {
+	entries, err := redis.Values(reply, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*XEvent, 0, len(entries))
+
+	for _, entry := range entries {
+		entryArr, err := redis.Values(entry, nil)
+		if err != nil {
+			return nil, fmt.Errorf("parsing event from result failed: %s", err)
+		}
+
+		if len(entryArr) < 2 {
+			return nil, fmt.Errorf("malformed result event: %v", entryArr)
+		}
+
+		// entries claimed via XCLAIM after they were deleted have no payload
+		if entryArr[1] == nil {
+			continue
+		}
+
+		evt := &XEvent{
+			Key: key,
+		}
+
+		evt.ID, err = redis.String(entryArr[0], nil)
+		if err != nil {
+			return nil, fmt.Errorf("parsing event id from result failed: %s", err)
+		}
+
+		evt.Data, err = redis.StringMap(entryArr[1], nil)
+		if err != nil {
+			return nil, fmt.Errorf("parsing event payload from result failed: %s", err)
+		}
+
+		events = append(events, evt)
+	}
+
+	return events, nil
+}
+
+// XRange loads events from a stream with ids between start and end
+//
+// Use "-" and "+" for the lowest and highest possible ids, count 0 loads all events
+func (s *Service) XRange(key string, start string, end string, count int) ([]*XEvent, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := []interface{}{
+		key,
+		start,
+		end,
+	}
+
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+
+	result, err := conn.Do("XRANGE", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return xEventsReply(key, result)
+}
+
+// XRead waits for events in a stream with an id greater than lastID (blocking with timeout)
+//
+// Use "$" as lastID to only receive new events, count 0 loads all available events
+func (s *Service) XRead(key string, timeout time.Duration, lastID string, count int) ([]*XEvent, error) {
+	conn, err := s.openBlockingConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := []interface{}{}
+
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+
+	args = append(args, "BLOCK", int(timeout/time.Millisecond), "STREAMS", key, lastID)
+
+	result, err := redis.Values(redis.DoWithTimeout(conn, blockReadTimeout(timeout), "XREAD", args...))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) < 1 || result[0] == nil {
+		return nil, ErrNil
+	}
+
+	resultArr, err := redis.Values(result[0], nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing result failed: %s", err)
+	}
+
+	if len(resultArr) < 2 {
+		return nil, ErrNil
+	}
+
+	return xEventsReply(key, resultArr[1])
+}
+
+// XLen gets the number of events in a stream
+func (s *Service) XLen(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("XLEN", key))
+}
+
+// XMessage is emitted by a consumer group started via ConsumeGroup(...)
+type XMessage struct {
+	Error error
+	Event *XEvent
+}
+
+// IsError returns if an error occured
+func (m *XMessage) IsError() bool {
+	return m.Error != nil
+}
+
+// IConsumerGroup defines the interface of ConsumerGroup
+type IConsumerGroup interface {
+	Close() error
+}
+
+// ConsumerGroup is used to track a consumer started via ConsumeGroup(...)
+type ConsumerGroup struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+var _ (IConsumerGroup) = (*ConsumerGroup)(nil)
+
+// Close stops reading from the stream and closes the message channel
+func (c *ConsumerGroup) Close() error {
+	select {
+	case <-c.stop:
+		return fmt.Errorf("already closed")
+	default:
+	}
+
+	c.drain()
+	<-c.stopped
+
+	return nil
+}
+
+// drain stops reading from the stream after the current read
+func (c *ConsumerGroup) drain() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+// kill does nothing, as the consumer returns immediately once drained if the output
+// isn't read
+func (c *ConsumerGroup) kill() {}
+
+const consumerGroupReadTimeout = 1 * time.Second
+
+// ConsumeGroup creates the consumer group (and the stream) if necessary and delivers
+// all new events for the consumer over the returned channel
+//
+// Failed reads are emitted as error messages and retried after a second. Events must be
+// acknowledged via XAck(...) after processing. If redis_stream_reclaim_min_idle_ms is set,
+// pending events of other consumers idle for longer are claimed and delivered again.
+func (s *Service) ConsumeGroup(groupName string, consumerName string, key string) (chan XMessage, IConsumerGroup, error) {
+	err := s.XGroupCreate(groupName, key, XGroupCreateOffsetLast, true, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't create consumer group: %s", err)
+	}
+
+	output := make(chan XMessage, 1)
+
+	consumerGroup := &ConsumerGroup{
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	s = s.withoutContext()
+
+	reclaimer := newStreamReclaimer(s, groupName, consumerName, key)
+
+	done := s.startWorker(consumerGroup)
+
+	go func() {
+		defer done()
+		defer close(consumerGroup.stopped)
+		defer close(output)
+
+		for {
+			select {
+			case <-consumerGroup.stop:
+				return
+			default:
+			}
+
+			evt, err := reclaimer.next()
+			if err == ErrNil {
+				evt, err = s.XReadGroup(groupName, consumerName, key, consumerGroupReadTimeout, XReadGroupIDStreamNew)
+			}
+			if err == ErrNil {
+				continue
+			}
+
+			msg := XMessage{
+				Error: err,
+				Event: evt,
+			}
+
+			select {
+			case output <- msg:
+			case <-consumerGroup.stop:
+				return
+			}
+
+			if err != nil {
+				select {
+				case <-time.After(1 * time.Second):
+				case <-consumerGroup.stop:
+					return
+				}
+			}
+		}
+	}()
+
+	return output, consumerGroup, nil
+}
@@ -0,0 +1,30 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXReadGroupKeyPrefix(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.KeyPrefix = "app:"
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	require.NoError(t, service.XGroupCreate("group", "events", gousuredis.XGroupCreateOffsetFirst, true, false))
+
+	id, err := service.XAdd("events", map[string]string{"key": "value"})
+	require.NoError(t, err)
+
+	evt, err := service.XReadGroup("group", "consumer", "events", time.Second, gousuredis.XReadGroupIDStreamNew)
+	require.NoError(t, err)
+
+	assert.Equal(t, "events", evt.Key)
+	assert.Equal(t, id, evt.ID)
+	assert.Equal(t, map[string]string{"key": "value"}, evt.Data)
+}
@@ -0,0 +1,188 @@
+package gousuredis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	streamReclaimBatchSize   = 100
+	streamReclaimMinInterval = 1 * time.Second
+)
+
+// XPendingEntry is a pending event of a consumer group returned by XPending
+type XPendingEntry struct {
+	ID       string
+	Consumer string
+	// Idle is the time since the event was delivered the last time
+	Idle          time.Duration
+	DeliveryCount int
+}
+
+// XPending lists up to count pending (delivered but not acknowledged) events of a consumer
+// group with ids between start and end ("-" and "+" for all)
+//
+// If minIdle is > 0, only events idle for at least minIdle are listed (redis >= 6.2).
+func (s *Service) XPending(groupName string, key string, start string, end string, count int, minIdle time.Duration) ([]XPendingEntry, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args := redis.Args{}.Add(key, groupName)
+	if minIdle > 0 {
+		args = args.Add("IDLE", minIdle.Milliseconds())
+	}
+	args = args.Add(start, end, count)
+
+	entries, err := redis.Values(conn.Do("XPENDING", args...))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]XPendingEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		entryArr, err := redis.Values(entry, nil)
+		if err != nil {
+			return nil, fmt.Errorf("parsing pending entry from result failed: %s", err)
+		}
+
+		pending := XPendingEntry{}
+		var idleMS int64
+
+		_, err = redis.Scan(entryArr, &pending.ID, &pending.Consumer, &idleMS, &pending.DeliveryCount)
+		if err != nil {
+			return nil, fmt.Errorf("parsing pending entry from result failed: %s", err)
+		}
+
+		pending.Idle = time.Duration(idleMS) * time.Millisecond
+
+		result = append(result, pending)
+	}
+
+	return result, nil
+}
+
+// XClaim transfers pending events idle for at least minIdle to another consumer and
+// returns them, events already deleted from the stream are skipped
+func (s *Service) XClaim(groupName string, key string, consumerName string, minIdle time.Duration, ids ...string) ([]*XEvent, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	result, err := conn.Do("XCLAIM", redis.Args{}.Add(key, groupName, consumerName, minIdle.Milliseconds()).AddFlat(ids)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return xEventsReply(key, result)
+}
+
+// XAutoClaim transfers up to count pending events idle for at least minIdle with an id
+// greater or equal to start ("0-0" for all) to another consumer (redis >= 6.2)
+//
+// Returns the start id for the next call ("0-0" once all pending events were scanned)
+// and the claimed events
+func (s *Service) XAutoClaim(groupName string, key string, consumerName string, minIdle time.Duration, start string, count int) (string, []*XEvent, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return "", nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	result, err := redis.Values(conn.Do("XAUTOCLAIM", key, groupName, consumerName, minIdle.Milliseconds(), start, "COUNT", count))
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(result) < 2 {
+		return "", nil, fmt.Errorf("malformed xautoclaim result: %v", result)
+	}
+
+	next, err := redis.String(result[0], nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing next id from result failed: %s", err)
+	}
+
+	events, err := xEventsReply(key, result[1])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return next, events, nil
+}
+
+// streamReclaimer claims pending events of crashed consumers for a consumer, as
+// configured via redis_stream_reclaim_min_idle_ms
+type streamReclaimer struct {
+	service      *Service
+	groupName    string
+	consumerName string
+	key          string
+	minIdle      time.Duration
+	cursor       string
+	nextScan     time.Time
+	claimed      []*XEvent
+}
+
+func newStreamReclaimer(service *Service, groupName string, consumerName string, key string) *streamReclaimer {
+	return &streamReclaimer{
+		service:      service,
+		groupName:    groupName,
+		consumerName: consumerName,
+		key:          key,
+		minIdle:      service.options.StreamReclaimMinIdle,
+		cursor:       "0-0",
+	}
+}
+
+// next returns the next claimed event, scanning the pending events at most every half
+// of the idle time
+//
+// Returns ErrNil if no event is due
+func (r *streamReclaimer) next() (*XEvent, error) {
+	if r.minIdle <= 0 {
+		return nil, ErrNil
+	}
+
+	if len(r.claimed) == 0 && time.Now().After(r.nextScan) {
+		cursor, events, err := r.service.XAutoClaim(r.groupName, r.key, r.consumerName, r.minIdle, r.cursor, streamReclaimBatchSize)
+		if err != nil {
+			// retry after the interval instead of failing every read
+			r.scheduleScan()
+
+			return nil, fmt.Errorf("can't claim pending events: %w", err)
+		}
+
+		r.cursor = cursor
+		r.claimed = events
+
+		if cursor == "0-0" {
+			r.scheduleScan()
+		}
+	}
+
+	if len(r.claimed) == 0 {
+		return nil, ErrNil
+	}
+
+	evt := r.claimed[0]
+	r.claimed = r.claimed[1:]
+
+	return evt, nil
+}
+
+func (r *streamReclaimer) scheduleScan() {
+	interval := r.minIdle / 2
+	if interval < streamReclaimMinInterval {
+		interval = streamReclaimMinInterval
+	}
+
+	r.nextScan = time.Now().Add(interval)
+}
@@ -0,0 +1,40 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXEventsReplySkipsDeleted(t *testing.T) {
+	events, err := xEventsReply("stream", []interface{}{
+		[]interface{}{[]byte("1-0"), []interface{}{[]byte("key"), []byte("value")}},
+		[]interface{}{[]byte("2-0"), nil},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*XEvent{{Key: "stream", ID: "1-0", Data: map[string]string{"key": "value"}}}, events)
+}
+
+func TestStreamReclaimer(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	reclaimer := newStreamReclaimer(service, "group", "consumer", "stream")
+
+	_, err := reclaimer.next()
+	assert.Equal(t, ErrNil, err)
+
+	reclaimer.minIdle = 10 * time.Second
+	reclaimer.claimed = []*XEvent{{ID: "1-0"}}
+	reclaimer.nextScan = time.Now().Add(time.Minute)
+
+	evt, err := reclaimer.next()
+	assert.NoError(t, err)
+	assert.Equal(t, "1-0", evt.ID)
+
+	_, err = reclaimer.next()
+	assert.Equal(t, ErrNil, err)
+
+	reclaimer.scheduleScan()
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), reclaimer.nextScan, time.Second)
+}
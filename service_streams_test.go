@@ -84,3 +84,29 @@ func TestXReadGroup(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, count)
 }
+
+func TestXRange(t *testing.T) {
+	ctx := gousu.NewContext()
+
+	service := NewService(ctx).(IService)
+	assert.NoError(t, service.Start())
+
+	id, err := service.XAdd(
+		"teststream02",
+		map[string]string{
+			"key1": "value1",
+		},
+	)
+	assert.NoError(t, err)
+
+	length, err := service.XLen("teststream02")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, length, 1)
+
+	events, err := service.XRange("teststream02", id, "+", 1)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, id, events[0].ID)
+	assert.Equal(t, "teststream02", events[0].Key)
+	assert.Equal(t, "value1", events[0].Data["key1"])
+}
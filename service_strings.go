@@ -0,0 +1,270 @@
+package gousuredis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Incr increments the integer value of a key by one and returns the new value
+func (s *Service) Incr(key string) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("INCR", key))
+}
+
+// Decr decrements the integer value of a key by one and returns the new value
+func (s *Service) Decr(key string) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("DECR", key))
+}
+
+// IncrBy increments the integer value of a key and returns the new value
+func (s *Service) IncrBy(key string, increment int) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("INCRBY", key, increment))
+}
+
+// DecrBy decrements the integer value of a key and returns the new value
+func (s *Service) DecrBy(key string, decrement int) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("DECRBY", key, decrement))
+}
+
+// IncrByFloat increments the float value of a key and returns the new value
+func (s *Service) IncrByFloat(key string, increment float64) (float64, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Float64(conn.Do("INCRBYFLOAT", key, increment))
+}
+
+// MGet loads the values of multiple keys
+//
+// The values are returned in the order of the keys, missing keys are nil. In cluster mode
+// the keys are loaded sequentially, as they can be located on different nodes.
+func (s *Service) MGet(keys ...string) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	var values [][]byte
+
+	if s.cluster != nil {
+		values = make([][]byte, len(keys))
+
+		for i, key := range keys {
+			values[i], err = redis.Bytes(conn.Do("GET", key))
+			if err != nil && err != ErrNil {
+				return nil, err
+			}
+		}
+	} else {
+		values, err = redis.ByteSlices(conn.Do("MGET", redis.Args{}.AddFlat(keys)...))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, data := range values {
+		if data == nil {
+			continue
+		}
+
+		values[i], err = s.decryptValue(keys[i], data, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// msetArgs returns the key-value-pairs for MSET / MSETNX
+func (s *Service) msetArgs(values map[string][]byte) (redis.Args, error) {
+	args := redis.Args{}
+
+	for key, data := range values {
+		data, err := s.encryptValue(key, data)
+		if err != nil {
+			return nil, err
+		}
+
+		args = args.Add(key, data)
+	}
+
+	return args, nil
+}
+
+// MSet stores multiple keys and their values
+//
+// In cluster mode the keys are stored sequentially, as they can be located on different
+// nodes, so other clients can see a partial update.
+func (s *Service) MSet(values map[string][]byte) error {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args, err := s.msetArgs(values)
+	if err != nil {
+		return err
+	}
+
+	if s.cluster != nil {
+		for i := 0; i < len(args); i += 2 {
+			_, err = conn.Do("SET", args[i], args[i+1])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	_, err = conn.Do("MSET", args...)
+
+	return err
+}
+
+// MSetNX stores multiple keys and their values if none of the keys exists
+//
+// Returns true if the keys were set. In cluster mode all keys must belong to the same
+// slot (e.g. by using hash tags).
+func (s *Service) MSetNX(values map[string][]byte) (bool, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	args, err := s.msetArgs(values)
+	if err != nil {
+		return false, err
+	}
+
+	return redis.Bool(conn.Do("MSETNX", args...))
+}
+
+// GetDel retrieves a key's value and deletes the key (redis >= 6.2)
+//
+// Returns ErrNil if the key doesn't exist
+func (s *Service) GetDel(key string) ([]byte, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("GETDEL", key))
+
+	return s.decryptValue(key, data, err)
+}
+
+// GetEx retrieves a key's value and sets its expiration time in milliseconds (redis >= 6.2)
+//
+// Returns ErrNil if the key doesn't exist
+func (s *Service) GetEx(key string, timeoutMS int) ([]byte, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("GETEX", key, "PX", timeoutMS))
+
+	return s.decryptValue(key, data, err)
+}
+
+// GetSet stores a key and its value and returns the previous value
+//
+// Returns ErrNil if the key didn't exist before
+func (s *Service) GetSet(key string, data []byte) ([]byte, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	data, err = s.encryptValue(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = redis.Bytes(conn.Do("GETSET", key, data))
+
+	return s.decryptValue(key, data, err)
+}
+
+// Append appends data to the value of a key and returns the new length of the value
+//
+// Works on plain values, so it must not be used on encrypted keys
+func (s *Service) Append(key string, data []byte) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("APPEND", key, data))
+}
+
+// StrLen returns the length of the value of a key (0 if the key doesn't exist)
+func (s *Service) StrLen(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("STRLEN", key))
+}
+
+// GetRange loads a part of the value of a key, start and end are inclusive offsets
+// (negative offsets count from the end)
+func (s *Service) GetRange(key string, start int, end int) ([]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Bytes(conn.Do("GETRANGE", key, start, end))
+}
+
+// SetRange overwrites a part of the value of a key starting at offset and returns the new
+// length of the value
+func (s *Service) SetRange(key string, offset int, data []byte) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("SETRANGE", key, offset, data))
+}
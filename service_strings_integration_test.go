@@ -0,0 +1,159 @@
+package gousuredis_test
+
+import (
+	"testing"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounters(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	value, err := service.Incr("counter")
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	value, err = service.IncrBy("counter", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 11, value)
+
+	value, err = service.Decr("counter")
+	require.NoError(t, err)
+	assert.Equal(t, 10, value)
+
+	value, err = service.DecrBy("counter", 15)
+	require.NoError(t, err)
+	assert.Equal(t, -5, value)
+
+	float, err := service.IncrByFloat("float", 1.5)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, float)
+
+	float, err = service.IncrByFloat("float", -0.25)
+	require.NoError(t, err)
+	assert.Equal(t, 1.25, float)
+
+	require.NoError(t, service.Set("string", []byte("value")))
+
+	_, err = service.Incr("string")
+	assert.True(t, gousuredis.IsServerError(err))
+}
+
+func TestMGetMSet(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.MSet(map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	}))
+
+	values, err := service.MGet("key2", "missing", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value2"), nil, []byte("value1")}, values)
+
+	// MSetNX doesn't set any key if one of them exists
+	set, err := service.MSetNX(map[string][]byte{
+		"key1": []byte("other"),
+		"key3": []byte("value3"),
+	})
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	exists, err := service.Exists("key3")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	set, err = service.MSetNX(map[string][]byte{
+		"key3": []byte("value3"),
+		"key4": []byte("value4"),
+	})
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	values, err = service.MGet("key3", "key4")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value3"), []byte("value4")}, values)
+}
+
+func TestGetDelGetExGetSet(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	require.NoError(t, service.Set("key", []byte("value1")))
+
+	previous, err := service.GetSet("key", []byte("value2"))
+	require.NoError(t, err)
+	assert.Equal(t, "value1", string(previous))
+
+	_, err = service.GetSet("new", []byte("value"))
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+
+	value, err := service.GetEx("key", 60000)
+	require.NoError(t, err)
+	assert.Equal(t, "value2", string(value))
+
+	ttl, err := service.PTTL("key")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 50000)
+
+	_, err = service.GetEx("missing", 60000)
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+
+	value, err = service.GetDel("key")
+	require.NoError(t, err)
+	assert.Equal(t, "value2", string(value))
+
+	exists, err := service.Exists("key")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = service.GetDel("key")
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+}
+
+func TestStringRanges(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	length, err := service.Append("key", []byte("Hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, length)
+
+	length, err = service.Append("key", []byte(" World"))
+	require.NoError(t, err)
+	assert.Equal(t, 11, length)
+
+	length, err = service.StrLen("key")
+	require.NoError(t, err)
+	assert.Equal(t, 11, length)
+
+	length, err = service.StrLen("missing")
+	require.NoError(t, err)
+	assert.Equal(t, 0, length)
+
+	value, err := service.GetRange("key", 0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", string(value))
+
+	value, err = service.GetRange("key", -5, -1)
+	require.NoError(t, err)
+	assert.Equal(t, "World", string(value))
+
+	length, err = service.SetRange("key", 6, []byte("Redis"))
+	require.NoError(t, err)
+	assert.Equal(t, 11, length)
+
+	value, err = service.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello Redis", string(value))
+
+	// Setting a range beyond the end pads the value with zero bytes
+	length, err = service.SetRange("padded", 2, []byte("x"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, length)
+
+	value, err = service.Get("padded")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 'x'}, value)
+}
@@ -0,0 +1,114 @@
+package gousuredis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+)
+
+// ErrTxConflict is returned if a transaction failed on every attempt because watched keys were changed
+var ErrTxConflict = errors.New("transaction failed due to conflicting changes of watched keys")
+
+// ITx defines the interface of Tx
+type ITx interface {
+	Do(commandName string, args ...interface{}) (interface{}, error)
+	Queue(commandName string, args ...interface{})
+}
+
+// Tx is passed to the callback of Service.Tx(...) for reading the watched
+// keys and queueing the commands of the transaction
+type Tx struct {
+	conn     redis.Conn
+	commands []pipelineCommand
+}
+
+var _ (ITx) = (*Tx)(nil)
+
+// Do executes a command immediately (e.g. for reading the current values of the watched keys)
+func (t *Tx) Do(commandName string, args ...interface{}) (interface{}, error) {
+	return t.conn.Do(commandName, args...)
+}
+
+// Queue adds a command to the transaction, which is executed atomically after the callback returned
+func (t *Tx) Queue(commandName string, args ...interface{}) {
+	t.commands = append(t.commands, pipelineCommand{
+		name: commandName,
+		args: args,
+	})
+}
+
+// Tx runs an optimistic transaction: the keys are watched, the callback reads the current
+// state and queues commands, which are then executed via MULTI/EXEC
+//
+// If a watched key was changed in the meantime the callback is called again, up to
+// redis_tx_max_retries times before ErrTxConflict is returned. Returns the replies of the
+// queued commands. In cluster mode all keys must be located in the same hash slot.
+func (s *Service) Tx(watchKeys []string, fn func(tx ITx) error) ([]interface{}, error) {
+	for attempt := 0; attempt <= s.options.TxMaxRetries; attempt++ {
+		replies, err := s.runTx(watchKeys, fn)
+		if err == ErrTxConflict {
+			continue
+		}
+
+		return replies, err
+	}
+
+	return nil, ErrTxConflict
+}
+
+func (s *Service) runTx(watchKeys []string, fn func(tx ITx) error) ([]interface{}, error) {
+	conn, err := s.openConn(false)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	if s.cluster != nil && len(watchKeys) > 0 {
+		err = redisc.BindConn(conn.(*serviceConn).delegate, s.prefixKeys(watchKeys)...)
+		if err != nil {
+			return nil, fmt.Errorf("can't bind connection to keys: %s", err)
+		}
+	}
+
+	if len(watchKeys) > 0 {
+		_, err = conn.Do("WATCH", redis.Args{}.AddFlat(watchKeys)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx := &Tx{
+		conn: conn,
+	}
+
+	err = fn(tx)
+	if err != nil {
+		conn.Do("UNWATCH")
+
+		return nil, err
+	}
+
+	err = conn.Send("MULTI")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, command := range tx.commands {
+		err = conn.Send(command.name, command.args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err == redis.ErrNil {
+		return nil, ErrTxConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return replies, nil
+}
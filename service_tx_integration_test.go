@@ -0,0 +1,133 @@
+package gousuredis_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commandRecorder is a hook recording the names of all commands
+type commandRecorder struct {
+	mutex    sync.Mutex
+	commands []string
+}
+
+func (r *commandRecorder) BeforeCommand(ctx context.Context, commandName string, args []interface{}) context.Context {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.commands = append(r.commands, commandName)
+
+	return ctx
+}
+
+func (r *commandRecorder) AfterCommand(ctx context.Context, commandName string, args []interface{}, duration time.Duration, err error) {
+}
+
+func (r *commandRecorder) recorded() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string{}, r.commands...)
+}
+
+// incrementTx reads and increments the counter, calling change before queueing the increment
+func incrementTx(change func(attempt int)) (func(tx gousuredis.ITx) error, *int) {
+	attempts := 0
+
+	return func(tx gousuredis.ITx) error {
+		attempts++
+
+		value, err := redis.Int(tx.Do("GET", "counter"))
+		if err != nil && err != redis.ErrNil {
+			return err
+		}
+
+		change(attempts)
+
+		tx.Queue("SET", "counter", strconv.Itoa(value+1))
+
+		return nil
+	}, &attempts
+}
+
+func TestTxRetriesOnConflict(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.TxMaxRetries = 3
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	require.NoError(t, service.Set("counter", []byte("10")))
+
+	fn, attempts := incrementTx(func(attempt int) {
+		if attempt == 1 {
+			// Change the watched key from a second connection
+			require.NoError(t, service.Set("counter", []byte("20")))
+		}
+	})
+
+	replies, err := service.Tx([]string{"counter"}, fn)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *attempts)
+	assert.Equal(t, []interface{}{"OK"}, replies)
+
+	value, err := service.Get("counter")
+	require.NoError(t, err)
+	assert.Equal(t, "21", string(value))
+}
+
+func TestTxConflictAfterMaxRetries(t *testing.T) {
+	options := gousuredis.DefaultOptions()
+	options.TxMaxRetries = 2
+
+	service := testutil.StartTestServiceWithOptions(t, options)
+
+	require.NoError(t, service.Set("counter", []byte("10")))
+
+	fn, attempts := incrementTx(func(attempt int) {
+		require.NoError(t, service.Set("counter", []byte(strconv.Itoa(100+attempt))))
+	})
+
+	_, err := service.Tx([]string{"counter"}, fn)
+	assert.Equal(t, gousuredis.ErrTxConflict, err)
+
+	// The first attempt and 2 retries
+	assert.Equal(t, 3, *attempts)
+
+	value, err := service.Get("counter")
+	require.NoError(t, err)
+	assert.Equal(t, "103", string(value))
+}
+
+func TestTxUnwatchOnError(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	recorder := &commandRecorder{}
+	service.AddHook(recorder)
+
+	errFailed := errors.New("failed")
+
+	_, err := service.Tx([]string{"counter"}, func(tx gousuredis.ITx) error {
+		tx.Queue("SET", "counter", "1")
+
+		return errFailed
+	})
+	assert.Equal(t, errFailed, err)
+
+	// The keys are unwatched and the queued commands are discarded
+	assert.Equal(t, []string{"WATCH", "UNWATCH"}, recorder.recorded())
+
+	exists, err := service.Exists("counter")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,84 @@
+package gousuredis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clusterNodeConn struct {
+	fakeConn
+	addr     string
+	commands *[]string
+}
+
+func (c *clusterNodeConn) Send(commandName string, args ...interface{}) error {
+	*c.commands = append(*c.commands, c.addr+" "+commandName)
+
+	return nil
+}
+
+func (c *clusterNodeConn) Flush() error {
+	return nil
+}
+
+func (c *clusterNodeConn) Receive() (interface{}, error) {
+	return nil, errors.New("connection closed")
+}
+
+func (c *clusterNodeConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	switch commandName {
+	case "":
+		return nil, nil
+	case "CLUSTER":
+		// slot 8000 separates the unprefixed key "c" (7365) from "app:c" (8043)
+		return []interface{}{
+			[]interface{}{int64(0), int64(7999), []interface{}{[]byte("node1"), int64(6379)}},
+			[]interface{}{int64(8000), int64(16383), []interface{}{[]byte("node2"), int64(6379)}},
+		}, nil
+	}
+
+	*c.commands = append(*c.commands, c.addr+" "+commandName)
+
+	if commandName == "EXEC" {
+		return []interface{}{}, nil
+	}
+
+	return "OK", nil
+}
+
+func TestTxBindsPrefixedKeys(t *testing.T) {
+	options := DefaultOptions()
+	options.KeyPrefix = "app:"
+
+	service := NewServiceWithOptions("redis", options)
+
+	commands := []string{}
+
+	service.cluster = &redisc.Cluster{
+		StartupNodes: []string{"node1:6379", "node2:6379"},
+		CreatePool: func(addr string, opts ...redis.DialOption) (*redis.Pool, error) {
+			return &redis.Pool{
+				Dial: func() (redis.Conn, error) {
+					return &clusterNodeConn{addr: addr, commands: &commands}, nil
+				},
+			}, nil
+		},
+	}
+	require.NoError(t, service.cluster.Refresh())
+
+	_, err := service.Tx([]string{"c"}, func(tx ITx) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"node2:6379 WATCH",
+		"node2:6379 MULTI",
+		"node2:6379 EXEC",
+	}, commands)
+}
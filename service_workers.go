@@ -0,0 +1,99 @@
+package gousuredis
+
+import (
+	"time"
+)
+
+// worker is a background consumer (e.g. a subscription) stopped by Service.Stop
+type worker interface {
+	// drain asks the worker to finish after delivering all buffered messages
+	drain()
+	// kill aborts the worker if it didn't finish within redis_stop_timeout_ms
+	kill()
+}
+
+// backgroundWorker is a goroutine of the service itself (e.g. the queue monitor), which
+// finishes on its own once the service is stopped
+type backgroundWorker struct {
+	name string
+}
+
+func (w *backgroundWorker) drain() {}
+
+func (w *backgroundWorker) kill() {}
+
+// goWorker runs fn in a goroutine tracked as worker, so Stop waits for it to finish before
+// closing the pools, fn must return once the service is stopped
+func (s *Service) goWorker(name string, fn func()) {
+	done := s.startWorker(&backgroundWorker{name: name})
+
+	go func() {
+		defer done()
+
+		fn()
+	}()
+}
+
+// startWorker tracks a worker until done is called after its goroutine finished
+func (s *Service) startWorker(w worker) func() {
+	s.workersMutex.Lock()
+	defer s.workersMutex.Unlock()
+
+	if s.workers == nil {
+		s.workers = map[worker]struct{}{}
+	}
+
+	s.workers[w] = struct{}{}
+	s.workersGroup.Add(1)
+
+	return func() {
+		s.workersMutex.Lock()
+		delete(s.workers, w)
+		s.workersMutex.Unlock()
+
+		s.workersGroup.Done()
+	}
+}
+
+// stopWorkers stops all workers and waits up to timeout for them to finish before
+// killing the remaining ones
+func (s *Service) stopWorkers(timeout time.Duration) {
+	s.workersMutex.Lock()
+	workers := make([]worker, 0, len(s.workers))
+	for w := range s.workers {
+		workers = append(workers, w)
+	}
+	s.workersMutex.Unlock()
+
+	if len(workers) == 0 {
+		return
+	}
+
+	for _, w := range workers {
+		w.drain()
+	}
+
+	finished := make(chan struct{})
+
+	go func() {
+		s.workersGroup.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return
+	case <-time.After(timeout):
+	}
+
+	s.workersMutex.Lock()
+	remaining := len(s.workers)
+	for w := range s.workers {
+		w.kill()
+	}
+	s.workersMutex.Unlock()
+
+	if remaining > 0 && timeout > 0 {
+		s.log.Warnf("Killed %d redis subscriptions and consumers not finished after %s", remaining, timeout)
+	}
+}
@@ -0,0 +1,102 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/indece-official/go-gousu"
+	"github.com/stretchr/testify/assert"
+)
+
+type testWorker struct {
+	drained chan struct{}
+	killed  chan struct{}
+	// ignoreDrain simulates a worker blocked on delivering a message
+	ignoreDrain bool
+}
+
+func newTestWorker(ignoreDrain bool) *testWorker {
+	return &testWorker{
+		drained:     make(chan struct{}),
+		killed:      make(chan struct{}),
+		ignoreDrain: ignoreDrain,
+	}
+}
+
+func (w *testWorker) drain() {
+	close(w.drained)
+}
+
+func (w *testWorker) kill() {
+	close(w.killed)
+}
+
+func (w *testWorker) run(done func()) {
+	defer done()
+
+	if w.ignoreDrain {
+		<-w.killed
+
+		return
+	}
+
+	<-w.drained
+}
+
+func TestStopWorkers(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	worker1 := newTestWorker(false)
+	go worker1.run(service.startWorker(worker1))
+
+	start := time.Now()
+	service.stopWorkers(1 * time.Second)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Empty(t, service.workers)
+
+	worker2 := newTestWorker(false)
+	worker3 := newTestWorker(true)
+	go worker2.run(service.startWorker(worker2))
+	go worker3.run(service.startWorker(worker3))
+
+	start = time.Now()
+	service.stopWorkers(100 * time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	select {
+	case <-worker3.killed:
+	default:
+		assert.Fail(t, "worker not killed")
+	}
+}
+
+func TestStopBackgroundWorkers(t *testing.T) {
+	service := NewServiceWithOptions("redis", DefaultOptions())
+
+	finished := make(chan struct{})
+	service.goWorker("test", func() {
+		<-service.done
+		time.Sleep(50 * time.Millisecond)
+		close(finished)
+	})
+
+	assert.NoError(t, service.Stop())
+
+	select {
+	case <-finished:
+	default:
+		assert.Fail(t, "stopped before background worker finished")
+	}
+}
+
+func TestStopBeforeStart(t *testing.T) {
+	service := NewService(gousu.NewContext()).(*Service)
+
+	assert.NoError(t, service.Stop())
+	assert.NoError(t, service.Stop())
+
+	service = NewServiceWithOptions("redis", DefaultOptions())
+
+	assert.NoError(t, service.Stop())
+	assert.NoError(t, service.Stop())
+}
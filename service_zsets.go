@@ -0,0 +1,216 @@
+package gousuredis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ZMember is a member of a sorted set with its score
+type ZMember struct {
+	Member []byte
+	Score  float64
+}
+
+// zMembersReply converts a member-score-list reply to a list of ZMember
+func zMembersReply(reply interface{}, err error) ([]ZMember, error) {
+	arr, err := redis.ByteSlices(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(arr)%2 != 0 {
+		return nil, fmt.Errorf("expected even number of values for sorted set, got %d", len(arr))
+	}
+
+	members := make([]ZMember, 0, len(arr)/2)
+	for i := 0; i < len(arr); i += 2 {
+		score, err := redis.Float64(arr[i+1], nil)
+		if err != nil {
+			return nil, fmt.Errorf("parsing score failed: %s", err)
+		}
+
+		members = append(members, ZMember{
+			Member: arr[i],
+			Score:  score,
+		})
+	}
+
+	return members, nil
+}
+
+// ZAdd adds a member with a score to a sorted set or updates its score
+//
+// Returns the number of newly added members
+func (s *Service) ZAdd(key string, score float64, member []byte) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("ZADD", key, score, member))
+}
+
+// ZRange loads members from a sorted set by their index
+func (s *Service) ZRange(key string, start int, stop int) ([][]byte, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.ByteSlices(conn.Do("ZRANGE", key, start, stop))
+}
+
+// ZRangeByScore loads members with their scores from a sorted set by score
+//
+// min and max support the redis syntax for infinite ("-inf", "+inf") and exclusive ("(1.5") bounds
+func (s *Service) ZRangeByScore(key string, min string, max string) ([]ZMember, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return zMembersReply(conn.Do("ZRANGEBYSCORE", key, min, max, "WITHSCORES"))
+}
+
+// ZRem removes a member from a sorted set
+func (s *Service) ZRem(key string, member []byte) (int, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("ZREM", key, member))
+}
+
+// ZIncrBy increments the score of a member in a sorted set and returns the new score
+func (s *Service) ZIncrBy(key string, increment float64, member []byte) (float64, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Float64(conn.Do("ZINCRBY", key, increment, member))
+}
+
+// ZScore gets the score of a member in a sorted set
+func (s *Service) ZScore(key string, member []byte) (float64, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Float64(conn.Do("ZSCORE", key, member))
+}
+
+// ZRank gets the index of a member in a sorted set ordered by ascending score
+func (s *Service) ZRank(key string, member []byte) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("ZRANK", key, member))
+}
+
+// ZCard gets the number of members in a sorted set
+func (s *Service) ZCard(key string) (int, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return redis.Int(conn.Do("ZCARD", key))
+}
+
+// ZPopMin removes and returns up to count members with the lowest scores from a sorted set
+func (s *Service) ZPopMin(key string, count int) ([]ZMember, error) {
+	conn, err := s.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return zMembersReply(conn.Do("ZPOPMIN", key, count))
+}
+
+// ZScan scans a sorted set for members matching the glob-style pattern (all if empty) and
+// returns the next cursor and the members with their scores
+//
+// count is a hint for the number of members checked per call (0 uses the server default)
+func (s *Service) ZScan(key string, cursor int, match string, count int) (int, []ZMember, error) {
+	conn, err := s.openReadConn()
+	if err != nil {
+		return 0, nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	return zscan(conn, key, cursor, match, count)
+}
+
+func zscan(conn redis.Conn, key string, cursor int, match string, count int) (int, []ZMember, error) {
+	var items interface{}
+
+	resp, err := redis.Values(conn.Do("ZSCAN", collectionScanArgs(key, cursor, match, count)...))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	_, err = redis.Scan(resp, &cursor, &items)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	members, err := zMembersReply(items, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return cursor, members, nil
+}
+
+// ZScanAll loads all members matching the glob-style pattern (all if empty) of a sorted set
+// with their scores by iterating ZSCAN, so large sorted sets don't block the server
+//
+// The members are not ordered by score. Members added or removed while iterating may be missing
+func (s *Service) ZScanAll(key string, match string) ([]ZMember, error) {
+	conn, err := s.openPinnedReadConn()
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	result := []ZMember{}
+	seen := map[string]bool{}
+	cursor := 0
+
+	for {
+		var members []ZMember
+
+		cursor, members, err = zscan(conn, key, cursor, match, scanBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			if seen[string(member.Member)] {
+				continue
+			}
+
+			seen[string(member.Member)] = true
+			result = append(result, member)
+		}
+
+		if cursor == 0 {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package gousuredis_test
+
+import (
+	"fmt"
+	"testing"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedSets(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	added, err := service.ZAdd("zset", 2, []byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	added, err = service.ZAdd("zset", 1, []byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	added, err = service.ZAdd("zset", 3, []byte("c"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	// Updating the score of an existing member adds nothing
+	added, err = service.ZAdd("zset", 2.5, []byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	members, err := service.ZRange("zset", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, members)
+
+	scored, err := service.ZRangeByScore("zset", "(1", "+inf")
+	require.NoError(t, err)
+	assert.Equal(t, []gousuredis.ZMember{{Member: []byte("b"), Score: 2.5}, {Member: []byte("c"), Score: 3}}, scored)
+
+	score, err := service.ZIncrBy("zset", 2, []byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, score)
+
+	score, err = service.ZScore("zset", []byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, score)
+
+	_, err = service.ZScore("zset", []byte("missing"))
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+
+	rank, err := service.ZRank("zset", []byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, rank)
+
+	_, err = service.ZRank("zset", []byte("missing"))
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+
+	count, err := service.ZCard("zset")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	removed, err := service.ZRem("zset", []byte("c"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	popped, err := service.ZPopMin("zset", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []gousuredis.ZMember{{Member: []byte("b"), Score: 2.5}, {Member: []byte("a"), Score: 3}}, popped)
+
+	count, err = service.ZCard("zset")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSortedSetScan(t *testing.T) {
+	service := testutil.StartTestService(t)
+
+	for i := 0; i < 200; i++ {
+		_, err := service.ZAdd("zset", float64(i), []byte(fmt.Sprintf("user:%d", i)))
+		require.NoError(t, err)
+	}
+	_, err := service.ZAdd("zset", 1000, []byte("other"))
+	require.NoError(t, err)
+
+	scanned := map[string]float64{}
+	cursor := 0
+
+	for {
+		var members []gousuredis.ZMember
+
+		cursor, members, err = service.ZScan("zset", cursor, "user:*", 10)
+		require.NoError(t, err)
+
+		for _, member := range members {
+			scanned[string(member.Member)] = member.Score
+		}
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	assert.Len(t, scanned, 200)
+	assert.Equal(t, float64(42), scanned["user:42"])
+	assert.NotContains(t, scanned, "other")
+
+	members, err := service.ZScanAll("zset", "")
+	require.NoError(t, err)
+	assert.Len(t, members, 201)
+
+	members, err = service.ZScanAll("zset", "other")
+	require.NoError(t, err)
+	assert.Equal(t, []gousuredis.ZMember{{Member: []byte("other"), Score: 1000}}, members)
+
+	members, err = service.ZScanAll("missing", "")
+	require.NoError(t, err)
+	assert.Len(t, members, 0)
+}
@@ -0,0 +1,261 @@
+package gousuredis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// sessionTTLField and sessionUserField store the metadata of a session unencrypted
+	// next to its fields
+	sessionTTLField  = "_session_ttl_ms"
+	sessionUserField = "_session_user"
+)
+
+// sessionCreateScript replaces a session hash and sets its expiration
+//
+// KEYS[1] = session, ARGV[1] = ttl in ms, ARGV[2...] = field / value pairs
+var sessionCreateScript = redis.NewScript(1, `
+redis.call('DEL', KEYS[1])
+redis.call('HSET', KEYS[1], unpack(ARGV, 2))
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+return 1
+`)
+
+// sessionTouchScript resets the expiration of a session to its ttl
+//
+// KEYS[1] = session, returns {ttl in ms, user id} or nil if the session doesn't exist
+var sessionTouchScript = redis.NewScript(1, `
+local meta = redis.call('HMGET', KEYS[1], '`+sessionTTLField+`', '`+sessionUserField+`')
+if not meta[1] then
+	return nil
+end
+redis.call('PEXPIRE', KEYS[1], meta[1])
+return {meta[1], meta[2] or ''}
+`)
+
+// sessionIndexScript adds a session to the index of its user, which expires with the
+// last session
+//
+// KEYS[1] = user index, ARGV[1] = session id, ARGV[2] = ttl in ms
+var sessionIndexScript = redis.NewScript(1, `
+redis.call('SADD', KEYS[1], ARGV[1])
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// ISessionStore defines the interface of SessionStore
+type ISessionStore interface {
+	Create(sessionID string, userID string, fields map[string][]byte, ttl time.Duration) error
+	Get(sessionID string) (map[string][]byte, error)
+	Touch(sessionID string) error
+	Destroy(sessionID string) error
+	DestroyAllForUser(userID string) (int, error)
+}
+
+// SessionStore stores sessions as hashes expiring after a ttl, which is extended by Touch
+//
+// Sessions are indexed by user, so all sessions of an user can be destroyed (e.g. on logout
+// or password change). All commands work on single keys, so no hash tags are required in
+// cluster mode.
+type SessionStore struct {
+	service *Service
+	key     string
+}
+
+var _ (ISessionStore) = (*SessionStore)(nil)
+
+func (s *SessionStore) sessionKey(sessionID string) string {
+	return fmt.Sprintf("%s:session:%s", s.key, sessionID)
+}
+
+func (s *SessionStore) userKey(userID string) string {
+	return fmt.Sprintf("%s:user:%s", s.key, userID)
+}
+
+func (s *SessionStore) index(conn redis.Conn, userID string, sessionID string, ttlMS int64) error {
+	if userID == "" {
+		return nil
+	}
+
+	_, err := sessionIndexScript.Do(conn, s.userKey(userID), sessionID, ttlMS)
+
+	return err
+}
+
+// Create stores a session with its fields, replacing an existing session with the same id
+//
+// The session expires after ttl unless it is touched. userID can be empty for anonymous
+// sessions, which are not indexed. Fields starting with "_session_" are reserved.
+func (s *SessionStore) Create(sessionID string, userID string, fields map[string][]byte, ttl time.Duration) error {
+	if ttl.Milliseconds() <= 0 {
+		return fmt.Errorf("invalid session ttl %s", ttl)
+	}
+
+	args := redis.Args{}.Add(ttl.Milliseconds())
+	for field, data := range fields {
+		if strings.HasPrefix(field, "_session_") {
+			return fmt.Errorf("session field %s is reserved", field)
+		}
+
+		data, err := s.service.encryptField(s.sessionKey(sessionID), field, data)
+		if err != nil {
+			return err
+		}
+
+		args = args.Add(field, data)
+	}
+
+	args = args.Add(sessionTTLField, ttl.Milliseconds(), sessionUserField, userID)
+
+	conn, err := s.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = sessionCreateScript.Do(conn, append([]interface{}{s.sessionKey(sessionID)}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	return s.index(conn, userID, sessionID, ttl.Milliseconds())
+}
+
+// Get loads the fields of a session without extending its expiration
+//
+// Returns ErrNil if the session doesn't exist or expired
+func (s *SessionStore) Get(sessionID string) (map[string][]byte, error) {
+	conn, err := s.service.openConn(true)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	values, err := hashReply(conn.Do("HGETALL", s.sessionKey(sessionID)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return nil, ErrNil
+	}
+
+	fields := map[string][]byte{}
+	for field, data := range values {
+		if strings.HasPrefix(field, "_session_") {
+			continue
+		}
+
+		fields[field], err = s.service.decryptField(s.sessionKey(sessionID), field, data, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
+// Touch resets the expiration of a session to its ttl (sliding expiration)
+//
+// Returns ErrNil if the session doesn't exist or expired
+func (s *SessionStore) Touch(sessionID string) error {
+	conn, err := s.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	meta, err := redis.Values(sessionTouchScript.Do(conn, s.sessionKey(sessionID)))
+	if err != nil {
+		return err
+	}
+
+	var ttlMS int64
+	var userID string
+
+	_, err = redis.Scan(meta, &ttlMS, &userID)
+	if err != nil {
+		return err
+	}
+
+	return s.index(conn, userID, sessionID, ttlMS)
+}
+
+// Destroy deletes a session, destroying a missing session is no error
+func (s *SessionStore) Destroy(sessionID string) error {
+	conn, err := s.service.openConn(true)
+	if err != nil {
+		return fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	userID, err := redis.String(conn.Do("HGET", s.sessionKey(sessionID), sessionUserField))
+	if err != nil && err != redis.ErrNil {
+		return err
+	}
+
+	_, err = conn.Do("DEL", s.sessionKey(sessionID))
+	if err != nil {
+		return err
+	}
+
+	if userID == "" {
+		return nil
+	}
+
+	_, err = conn.Do("SREM", s.userKey(userID), sessionID)
+
+	return err
+}
+
+// DestroyAllForUser deletes all sessions of an user
+//
+// Returns the number of deleted sessions
+func (s *SessionStore) DestroyAllForUser(userID string) (int, error) {
+	conn, err := s.service.openConn(true)
+	if err != nil {
+		return 0, fmt.Errorf("can't connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	sessionIDs, err := redis.Strings(conn.Do("SMEMBERS", s.userKey(userID)))
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	// sessions are deleted one by one, as they can be stored in different slots in cluster mode
+	for _, sessionID := range sessionIDs {
+		deleted, err := redis.Int(conn.Do("DEL", s.sessionKey(sessionID)))
+		if err != nil {
+			return count, err
+		}
+
+		count += deleted
+	}
+
+	_, err = conn.Do("DEL", s.userKey(userID))
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// NewSessionStore creates a new session store
+//
+// Sessions are stored in hashes at key:session:sessionID, the sessions of each user are
+// indexed in sets at key:user:userID.
+func NewSessionStore(service *Service, key string) *SessionStore {
+	return &SessionStore{
+		service: service,
+		key:     key,
+	}
+}
@@ -0,0 +1,114 @@
+package gousuredis_test
+
+import (
+	"testing"
+	"time"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/indece-official/go-gousu-redis/v2/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStoreCreateGet(t *testing.T) {
+	service := testutil.StartTestService(t)
+	store := gousuredis.NewSessionStore(service, "sessions")
+
+	require.NoError(t, store.Create("abc", "42", map[string][]byte{"name": []byte("alice")}, time.Minute))
+
+	fields, err := store.Get("abc")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"name": []byte("alice")}, fields)
+
+	members, err := service.SMembers("sessions:user:42")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("abc")}, members)
+
+	// Create replaces the existing session
+	require.NoError(t, store.Create("abc", "42", map[string][]byte{"role": []byte("admin")}, time.Minute))
+
+	fields, err = store.Get("abc")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"role": []byte("admin")}, fields)
+
+	_, err = store.Get("missing")
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+}
+
+func TestSessionStoreTouch(t *testing.T) {
+	service := testutil.StartTestService(t)
+	store := gousuredis.NewSessionStore(service, "sessions")
+
+	require.NoError(t, store.Create("abc", "42", map[string][]byte{"name": []byte("alice")}, time.Minute))
+
+	_, err := service.PExpire("sessions:session:abc", 1000)
+	require.NoError(t, err)
+	_, err = service.PExpire("sessions:user:42", 1000)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Touch("abc"))
+
+	// The ttl of the session and the user index is refreshed
+	ttl, err := service.PTTL("sessions:session:abc")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 50000)
+
+	ttl, err = service.PTTL("sessions:user:42")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 50000)
+
+	assert.ErrorIs(t, store.Touch("missing"), gousuredis.ErrNil)
+}
+
+func TestSessionStoreDestroy(t *testing.T) {
+	service := testutil.StartTestService(t)
+	store := gousuredis.NewSessionStore(service, "sessions")
+
+	require.NoError(t, store.Create("abc", "42", nil, time.Minute))
+	require.NoError(t, store.Create("def", "42", nil, time.Minute))
+
+	require.NoError(t, store.Destroy("abc"))
+
+	_, err := store.Get("abc")
+	assert.ErrorIs(t, err, gousuredis.ErrNil)
+
+	// The destroyed session is removed from the index of its user
+	members, err := service.SMembers("sessions:user:42")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("def")}, members)
+
+	assert.NoError(t, store.Destroy("missing"))
+}
+
+func TestSessionStoreDestroyAllForUser(t *testing.T) {
+	service := testutil.StartTestService(t)
+	store := gousuredis.NewSessionStore(service, "sessions")
+
+	require.NoError(t, store.Create("abc", "42", nil, time.Minute))
+	require.NoError(t, store.Create("def", "42", nil, time.Minute))
+	require.NoError(t, store.Create("ghi", "43", nil, time.Minute))
+	// A session id looking like a user index doesn't collide with it
+	require.NoError(t, store.Create("user:42", "43", nil, time.Minute))
+
+	count, err := store.DestroyAllForUser("42")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	for _, sessionID := range []string{"abc", "def"} {
+		_, err = store.Get(sessionID)
+		assert.ErrorIs(t, err, gousuredis.ErrNil)
+	}
+
+	for _, sessionID := range []string{"ghi", "user:42"} {
+		_, err = store.Get(sessionID)
+		assert.NoError(t, err)
+	}
+
+	exists, err := service.Exists("sessions:user:42")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	count, err = store.DestroyAllForUser("42")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
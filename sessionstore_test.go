@@ -0,0 +1,23 @@
+package gousuredis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionStoreKeys(t *testing.T) {
+	store := NewSessionStore(NewServiceWithOptions("redis", DefaultOptions()), "sessions")
+
+	assert.Equal(t, "sessions:session:abc", store.sessionKey("abc"))
+	assert.Equal(t, "sessions:user:42", store.userKey("42"))
+	assert.NotEqual(t, store.userKey("42"), store.sessionKey("user:42"))
+}
+
+func TestSessionStoreCreateInvalid(t *testing.T) {
+	store := NewSessionStore(NewServiceWithOptions("redis", DefaultOptions()), "sessions")
+
+	assert.Error(t, store.Create("abc", "42", nil, 0))
+	assert.Error(t, store.Create("abc", "42", map[string][]byte{sessionTTLField: []byte("1")}, time.Minute))
+}
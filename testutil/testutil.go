@@ -0,0 +1,282 @@
+// Package testutil provides a disposable redis for integration tests of services using gousuredis
+//
+// The redis is chosen in the following order:
+//   - GOUSU_REDIS_TEST_ADDR (host:port) connects to an existing redis
+//   - a redis-server binary found in PATH is started on a free port
+//   - a redis container is started via docker (image from GOUSU_REDIS_TEST_IMAGE, default redis:7-alpine)
+//
+// Tests are skipped if none of them is available. The redis is shared by all tests of a package,
+// use Run in TestMain to stop it after the tests:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(testutil.Run(m))
+//	}
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+)
+
+const (
+	// EnvAddr is the environment variable containing the address of an existing redis
+	EnvAddr = "GOUSU_REDIS_TEST_ADDR"
+	// EnvImage is the environment variable overriding the docker image
+	EnvImage = "GOUSU_REDIS_TEST_IMAGE"
+
+	defaultImage = "redis:7-alpine"
+	startTimeout = 30 * time.Second
+)
+
+// ErrNoRedis is returned if neither GOUSU_REDIS_TEST_ADDR is set nor redis-server or docker is available
+var ErrNoRedis = errors.New("no redis available, set GOUSU_REDIS_TEST_ADDR or install redis-server or docker")
+
+type testRedis struct {
+	addr string
+	stop func() error
+}
+
+var (
+	sharedOnce  sync.Once
+	shared      *testRedis
+	sharedError error
+)
+
+func sharedRedis() (*testRedis, error) {
+	sharedOnce.Do(func() {
+		shared, sharedError = startRedis()
+	})
+
+	return shared, sharedError
+}
+
+func startRedis() (*testRedis, error) {
+	addr := os.Getenv(EnvAddr)
+	if addr != "" {
+		return &testRedis{addr: addr}, nil
+	}
+
+	if _, err := exec.LookPath("redis-server"); err == nil {
+		return startRedisServer()
+	}
+
+	if _, err := exec.LookPath("docker"); err == nil {
+		return startDocker()
+	}
+
+	return nil, ErrNoRedis
+}
+
+// startRedisServer starts an in-memory redis-server on a free port
+func startRedisServer() (*testRedis, error) {
+	port, err := freePort()
+	if err != nil {
+		return nil, fmt.Errorf("can't find free port: %w", err)
+	}
+
+	cmd := exec.Command(
+		"redis-server",
+		"--bind", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+		"--save", "",
+		"--appendonly", "no",
+	)
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf("can't start redis-server: %w", err)
+	}
+
+	r := &testRedis{
+		addr: net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+		stop: func() error {
+			cmd.Process.Kill()
+
+			return cmd.Wait()
+		},
+	}
+
+	err = waitForRedis(r.addr)
+	if err != nil {
+		r.stop()
+
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// startDocker starts a redis container published on a free port of localhost
+func startDocker() (*testRedis, error) {
+	image := os.Getenv(EnvImage)
+	if image == "" {
+		image = defaultImage
+	}
+
+	output, err := exec.Command("docker", "run", "-d", "--rm", "-p", "127.0.0.1::6379", image).Output()
+	if err != nil {
+		return nil, fmt.Errorf("can't start redis container: %w", err)
+	}
+
+	containerID := strings.TrimSpace(string(output))
+
+	r := &testRedis{
+		stop: func() error {
+			return exec.Command("docker", "rm", "-f", containerID).Run()
+		},
+	}
+
+	output, err = exec.Command("docker", "port", containerID, "6379/tcp").Output()
+	if err != nil {
+		r.stop()
+
+		return nil, fmt.Errorf("can't get port of redis container: %w", err)
+	}
+
+	// docker port prints one line per published address (e.g. "127.0.0.1:49153")
+	r.addr = strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+
+	err = waitForRedis(r.addr)
+	if err != nil {
+		r.stop()
+
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// waitForRedis pings redis until it responds or startTimeout is reached
+func waitForRedis(addr string) error {
+	deadline := time.Now().Add(startTimeout)
+
+	for {
+		conn, err := redis.Dial("tcp", addr, redis.DialConnectTimeout(time.Second))
+		if err == nil {
+			_, err = conn.Do("PING")
+			conn.Close()
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("redis at %s not ready after %s: %w", addr, startTimeout, err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func splitAddr(addr string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid redis address %s: %w", addr, err)
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid redis port in %s: %w", addr, err)
+	}
+
+	return host, port, nil
+}
+
+// Addr returns the address (host:port) of the disposable redis, the test is skipped if
+// no redis is available
+func Addr(t testing.TB) string {
+	t.Helper()
+
+	r, err := sharedRedis()
+	if errors.Is(err, ErrNoRedis) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("can't start redis: %s", err)
+	}
+
+	return r.addr
+}
+
+// StartTestService starts a redis service with default options connected to the disposable redis
+func StartTestService(t testing.TB) *gousuredis.Service {
+	t.Helper()
+
+	return StartTestServiceWithOptions(t, gousuredis.DefaultOptions())
+}
+
+// StartTestServiceWithOptions starts a redis service with options connected to the disposable redis
+//
+// Host, Port and AllowFlush of options are overwritten. The database is flushed before the
+// test and when the test finished, after which the service is stopped. As all tests share the
+// database, tests running in parallel should select separate databases via options.DB.
+func StartTestServiceWithOptions(t testing.TB, options *gousuredis.Options) *gousuredis.Service {
+	t.Helper()
+
+	host, port, err := splitAddr(Addr(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options.Host = host
+	options.Port = port
+	options.AllowFlush = true
+
+	service := gousuredis.NewServiceWithOptions(gousuredis.ServiceName, options)
+
+	err = service.Start()
+	if err != nil {
+		t.Fatalf("can't start redis service: %s", err)
+	}
+
+	err = service.FlushDB()
+	if err != nil {
+		service.Stop()
+
+		t.Fatalf("can't flush redis: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := service.FlushDB()
+		if err != nil {
+			t.Errorf("can't flush redis: %s", err)
+		}
+
+		service.Stop()
+	})
+
+	return service
+}
+
+// Run runs the tests and stops the disposable redis afterwards, intended for TestMain
+func Run(m *testing.M) int {
+	code := m.Run()
+
+	if shared != nil && shared.stop != nil {
+		shared.stop()
+	}
+
+	return code
+}
@@ -0,0 +1,38 @@
+package testutil
+
+import (
+	"os"
+	"testing"
+
+	gousuredis "github.com/indece-official/go-gousu-redis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(Run(m))
+}
+
+func TestSplitAddr(t *testing.T) {
+	host, port, err := splitAddr("127.0.0.1:49153")
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", host)
+	assert.Equal(t, 49153, port)
+
+	_, _, err = splitAddr("127.0.0.1")
+	assert.Error(t, err)
+}
+
+func TestStartTestService(t *testing.T) {
+	t.Run("write", func(t *testing.T) {
+		service := StartTestService(t)
+
+		assert.NoError(t, service.Set("testutil01", []byte("value")))
+	})
+
+	t.Run("flushed", func(t *testing.T) {
+		service := StartTestService(t)
+
+		_, err := service.Get("testutil01")
+		assert.ErrorIs(t, err, gousuredis.ErrNil)
+	})
+}
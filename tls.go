@@ -0,0 +1,44 @@
+package gousuredis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// loadTLSConfig builds the tls config from the options
+func (s *Service) loadTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: s.options.TLSSkipVerify,
+		ServerName:         s.options.TLSServerName,
+	}
+
+	// verify the certificate against the host instead of the resolved ip
+	if config.ServerName == "" && s.options.DNSDiscovery == DNSDiscoveryA {
+		config.ServerName = s.options.Host
+	}
+
+	if s.options.TLSCAFile != "" {
+		caCert, err := ioutil.ReadFile(s.options.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read ca file: %s", err)
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("can't parse ca file %s", s.options.TLSCAFile)
+		}
+	}
+
+	if s.options.TLSCertFile != "" || s.options.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(s.options.TLSCertFile, s.options.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't load client certificate: %s", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,95 @@
+package gousuredis
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Tracer creates spans for the commands sent and the messages received by the service
+//
+// Set it via Options.Tracer, it gets registered as first Hook on Start. The package doesn't depend on a tracing library, so the
+// interface is small enough to adapt e.g. an OpenTelemetry trace.Tracer:
+//
+//	type otelTracer struct{ tracer trace.Tracer }
+//
+//	func (t *otelTracer) Start(ctx context.Context, name string, attributes map[string]string) (context.Context, gousuredis.Span) {
+//		kvs := []attribute.KeyValue{}
+//		for key, value := range attributes {
+//			kvs = append(kvs, attribute.String(key, value))
+//		}
+//
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(kvs...))
+//
+//		return ctx, &otelSpan{span}
+//	}
+//
+// where otelSpan calls span.RecordError / span.SetStatus and span.End.
+type Tracer interface {
+	// Start starts a span as child of the span in ctx (the context set via WithContext)
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+// Span is a span created by a Tracer
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+type tracingSpanKey struct{}
+
+// tracingHook creates a span for each command via a Tracer
+type tracingHook struct {
+	tracer Tracer
+}
+
+var _ (Hook) = (*tracingHook)(nil)
+
+func (h *tracingHook) BeforeCommand(ctx context.Context, commandName string, args []interface{}) context.Context {
+	ctx, span := h.tracer.Start(ctx, strings.ToUpper(commandName), commandAttributes(commandName, args))
+
+	return context.WithValue(ctx, tracingSpanKey{}, span)
+}
+
+func (h *tracingHook) AfterCommand(ctx context.Context, commandName string, args []interface{}, duration time.Duration, err error) {
+	span, ok := ctx.Value(tracingSpanKey{}).(Span)
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	span.End()
+}
+
+// commandAttributes returns the span attributes of a command
+func commandAttributes(commandName string, args []interface{}) map[string]string {
+	attributes := map[string]string{
+		"db.system":    "redis",
+		"db.operation": commandName,
+	}
+
+	if key := commandKey(commandName, args); key != "" {
+		attributes["db.redis.key"] = key
+	}
+
+	return attributes
+}
+
+// startMessage starts a span for a message received via Subscribe and returns a function
+// ending it
+func (s *Service) startMessage(channel string) func() {
+	if s.options.Tracer == nil {
+		return func() {}
+	}
+
+	_, span := s.options.Tracer.Start(s.ctx, "redis.message", map[string]string{
+		"db.system":             "redis",
+		"messaging.destination": channel,
+		"messaging.operation":   "receive",
+	})
+
+	return span.End
+}
@@ -0,0 +1,60 @@
+package gousuredis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSpan struct {
+	name       string
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span) {
+	span := &testSpan{name: name, attributes: attributes}
+	t.spans = append(t.spans, span)
+
+	return ctx, span
+}
+
+func TestStartCommandTracing(t *testing.T) {
+	tracer := &testTracer{}
+
+	options := DefaultOptions()
+	options.Tracer = tracer
+
+	service := NewServiceWithOptions("redis", options)
+	service.initHooks()
+
+	finish := service.startCommand("get", []interface{}{"key1"})
+	finish(nil)
+
+	finish = service.startCommand("PING", nil)
+	finish(errors.New("failed"))
+
+	assert.Len(t, tracer.spans, 2)
+	assert.Equal(t, "GET", tracer.spans[0].name)
+	assert.Equal(t, "key1", tracer.spans[0].attributes["db.redis.key"])
+	assert.True(t, tracer.spans[0].ended)
+	assert.Nil(t, tracer.spans[0].err)
+	assert.NotContains(t, tracer.spans[1].attributes, "db.redis.key")
+	assert.EqualError(t, tracer.spans[1].err, "failed")
+	assert.Equal(t, uint64(2), service.Stats().Commands)
+}
@@ -0,0 +1,225 @@
+package gousuredis
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Codec converts values of type T to and from their stored representation
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+// JSONCodec stores values as json
+type JSONCodec[T any] struct{}
+
+var _ (Codec[interface{}]) = JSONCodec[interface{}]{}
+
+// Marshal converts a value to json
+func (c JSONCodec[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal converts json to a value
+func (c JSONCodec[T]) Unmarshal(data []byte) (T, error) {
+	var v T
+
+	err := json.Unmarshal(data, &v)
+
+	return v, err
+}
+
+// RawCodec stores byte slices unchanged
+type RawCodec struct{}
+
+var _ (Codec[[]byte]) = RawCodec{}
+
+// Marshal returns the data unchanged
+func (c RawCodec) Marshal(v []byte) ([]byte, error) {
+	return v, nil
+}
+
+// Unmarshal returns the data unchanged
+func (c RawCodec) Unmarshal(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// StringCodec stores strings as their bytes
+type StringCodec struct{}
+
+var _ (Codec[string]) = StringCodec{}
+
+// Marshal converts a string to bytes
+func (c StringCodec) Marshal(v string) ([]byte, error) {
+	return []byte(v), nil
+}
+
+// Unmarshal converts bytes to a string
+func (c StringCodec) Unmarshal(data []byte) (string, error) {
+	return string(data), nil
+}
+
+// TypedService wraps IService and converts all values to and from T via a Codec
+type TypedService[T any] struct {
+	service IService
+	codec   Codec[T]
+}
+
+// Typed creates a typed accessor for values of type T, using JSON if codec is nil
+//
+// Other formats (e.g. msgpack) can be used by implementing Codec[T].
+func Typed[T any](service IService, codec Codec[T]) *TypedService[T] {
+	if codec == nil {
+		codec = JSONCodec[T]{}
+	}
+
+	return &TypedService[T]{
+		service: service,
+		codec:   codec,
+	}
+}
+
+func (t *TypedService[T]) unmarshal(data []byte) (T, error) {
+	v, err := t.codec.Unmarshal(data)
+	if err != nil {
+		return v, fmt.Errorf("can't unmarshal value: %s", err)
+	}
+
+	return v, nil
+}
+
+// unmarshalAll converts multiple values, nil values (e.g. missing keys) result in zero values
+func (t *TypedService[T]) unmarshalAll(items [][]byte) ([]T, error) {
+	values := make([]T, len(items))
+
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+
+		v, err := t.unmarshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+func (t *TypedService[T]) marshal(v T) ([]byte, error) {
+	data, err := t.codec.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal value: %s", err)
+	}
+
+	return data, nil
+}
+
+// Get retrieves a key's value from redis
+func (t *TypedService[T]) Get(key string) (T, error) {
+	data, err := t.service.Get(key)
+	if err != nil {
+		var empty T
+
+		return empty, err
+	}
+
+	return t.unmarshal(data)
+}
+
+// Set stores a key and its value in redis
+func (t *TypedService[T]) Set(key string, v T) error {
+	data, err := t.marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return t.service.Set(key, data)
+}
+
+// SetPX stores a key and its value with expiration time in redis
+func (t *TypedService[T]) SetPX(key string, v T, timeoutMS int) error {
+	data, err := t.marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return t.service.SetPX(key, data, timeoutMS)
+}
+
+// LPush prepends an item to a list
+func (t *TypedService[T]) LPush(key string, v T) (int, error) {
+	data, err := t.marshal(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.service.LPush(key, data)
+}
+
+// RPush appends an item to a list
+func (t *TypedService[T]) RPush(key string, v T) (int, error) {
+	data, err := t.marshal(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.service.RPush(key, data)
+}
+
+// LRange loads elements from a list
+func (t *TypedService[T]) LRange(key string, start int, stop int) ([]T, error) {
+	items, err := t.service.LRange(key, start, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.unmarshalAll(items)
+}
+
+// MGet retrieves the values of multiple keys from redis
+//
+// Missing keys result in zero values, use a pointer type for T to tell them apart.
+func (t *TypedService[T]) MGet(keys ...string) ([]T, error) {
+	items, err := t.service.MGet(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.unmarshalAll(items)
+}
+
+// SMembers loads all members of a set
+func (t *TypedService[T]) SMembers(key string) ([]T, error) {
+	items, err := t.service.SMembers(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.unmarshalAll(items)
+}
+
+// HGet retrieves a hash value from redis
+func (t *TypedService[T]) HGet(key string, field string) (T, error) {
+	data, err := t.service.HGet(key, field)
+	if err != nil {
+		var empty T
+
+		return empty, err
+	}
+
+	return t.unmarshal(data)
+}
+
+// HSet stores a field and its value in a hash in redis
+func (t *TypedService[T]) HSet(key string, field string, v T) error {
+	data, err := t.marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return t.service.HSet(key, field, data)
+}
@@ -0,0 +1,67 @@
+package gousuredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typedTestValue struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestTyped(t *testing.T) {
+	stored := map[string][]byte{}
+
+	mock := NewMockService()
+	mock.SetFunc = func(key string, data []byte) error {
+		stored[key] = data
+		return nil
+	}
+	mock.GetFunc = func(key string) ([]byte, error) {
+		data, ok := stored[key]
+		if !ok {
+			return nil, ErrNil
+		}
+
+		return data, nil
+	}
+
+	typed := Typed[typedTestValue](mock, nil)
+
+	assert.NoError(t, typed.Set("key1", typedTestValue{Name: "test", Count: 3}))
+	assert.Equal(t, `{"name":"test","count":3}`, string(stored["key1"]))
+
+	value, err := typed.Get("key1")
+	assert.NoError(t, err)
+	assert.Equal(t, typedTestValue{Name: "test", Count: 3}, value)
+
+	_, err = typed.Get("key2")
+	assert.Equal(t, ErrNil, err)
+
+	strings := Typed[string](mock, StringCodec{})
+	assert.NoError(t, strings.Set("key3", "value"))
+	assert.Equal(t, []byte("value"), stored["key3"])
+}
+
+func TestTypedMulti(t *testing.T) {
+	mock := NewMockService()
+	mock.MGetFunc = func(keys ...string) ([][]byte, error) {
+		return [][]byte{[]byte(`{"name":"a"}`), nil, []byte(`{"name":"c"}`)}, nil
+	}
+	mock.SMembersFunc = func(key string) ([][]byte, error) {
+		return [][]byte{[]byte("invalid")}, nil
+	}
+
+	values, err := Typed[typedTestValue](mock, nil).MGet("key1", "key2", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, []typedTestValue{{Name: "a"}, {}, {Name: "c"}}, values)
+
+	pointers, err := Typed[*typedTestValue](mock, nil).MGet("key1", "key2", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, []*typedTestValue{{Name: "a"}, nil, {Name: "c"}}, pointers)
+
+	_, err = Typed[typedTestValue](mock, nil).SMembers("key")
+	assert.Error(t, err)
+}